@@ -1,8 +1,10 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"log/slog"
+	"slices"
 
 	"github.com/google/uuid"
 
@@ -12,15 +14,21 @@ import (
 
 var ErrAccountNotFound = errors.New("account: not found")
 var ErrDeleteAdminAccount = errors.New("account: cannot delete admin account")
+var ErrBlogAlreadyFollowed = errors.New("account: blog already followed")
+var ErrBlogNotFollowed = errors.New("account: blog not followed")
 
-func (cmd *Command) FollowBlog(accountID uuid.UUID, blogID uuid.UUID) error {
-	return cmd.repo.WithTransaction(func(tx *repository.Repository) error {
-		account, err := tx.Account().Read(accountID)
+func (cmd *Command) FollowBlog(ctx context.Context, accountID uuid.UUID, blogID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
 		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
 			return err
 		}
 
-		blog, err := tx.Blog().Read(blogID)
+		blog, err := tx.Blog().Read(ctx, blogID)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrBlogNotFound
@@ -29,12 +37,16 @@ func (cmd *Command) FollowBlog(accountID uuid.UUID, blogID uuid.UUID) error {
 			return err
 		}
 
+		if slices.Contains(account.FollowedBlogIDs(), blog.ID()) {
+			return ErrBlogAlreadyFollowed
+		}
+
 		err = account.FollowBlog(blog)
 		if err != nil {
 			return err
 		}
 
-		err = tx.Account().Update(account)
+		err = tx.Account().Update(ctx, account)
 		if err != nil {
 			return err
 		}
@@ -50,14 +62,18 @@ func (cmd *Command) FollowBlog(accountID uuid.UUID, blogID uuid.UUID) error {
 	})
 }
 
-func (cmd *Command) UnfollowBlog(accountID uuid.UUID, blogID uuid.UUID) error {
-	return cmd.repo.WithTransaction(func(tx *repository.Repository) error {
-		account, err := tx.Account().Read(accountID)
+func (cmd *Command) UnfollowBlog(ctx context.Context, accountID uuid.UUID, blogID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
 		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
 			return err
 		}
 
-		blog, err := tx.Blog().Read(blogID)
+		blog, err := tx.Blog().Read(ctx, blogID)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrBlogNotFound
@@ -66,12 +82,16 @@ func (cmd *Command) UnfollowBlog(accountID uuid.UUID, blogID uuid.UUID) error {
 			return err
 		}
 
+		if !slices.Contains(account.FollowedBlogIDs(), blog.ID()) {
+			return ErrBlogNotFollowed
+		}
+
 		err = account.UnfollowBlog(blog)
 		if err != nil {
 			return err
 		}
 
-		err = tx.Account().Update(account)
+		err = tx.Account().Update(ctx, account)
 		if err != nil {
 			return err
 		}
@@ -87,9 +107,9 @@ func (cmd *Command) UnfollowBlog(accountID uuid.UUID, blogID uuid.UUID) error {
 	})
 }
 
-func (cmd *Command) DeleteAccount(accountID uuid.UUID) error {
-	return cmd.repo.WithTransaction(func(tx *repository.Repository) error {
-		account, err := tx.Account().Read(accountID)
+func (cmd *Command) DeleteAccount(ctx context.Context, accountID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrAccountNotFound
@@ -103,7 +123,7 @@ func (cmd *Command) DeleteAccount(accountID uuid.UUID) error {
 			return ErrDeleteAdminAccount
 		}
 
-		err = tx.Account().Delete(account)
+		err = tx.Account().Delete(ctx, account)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrAccountNotFound
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+)
+
+var ErrAPITokenNotFound = errors.New("api token: not found")
+var ErrAPITokenNameConflict = errors.New("api token: name already exists")
+
+func (cmd *Command) CreateAPIToken(ctx context.Context, accountID uuid.UUID, name string) (string, error) {
+	// NOTE: Like SignIn, this command needs to return a value (the plaintext token)
+	// because it is only ever available at the moment of creation.
+	var token string
+	err := cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		var apiToken *model.APIToken
+		apiToken, token, err = model.NewAPIToken(account, name)
+		if err != nil {
+			return err
+		}
+
+		err = tx.APIToken().Create(ctx, apiToken)
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				return ErrAPITokenNameConflict
+			}
+
+			return err
+		}
+
+		slog.Info("api token created",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"api_token_id", apiToken.ID(),
+			"api_token_name", apiToken.Name(),
+		)
+
+		return nil
+	})
+
+	return token, err
+}
+
+func (cmd *Command) DeleteAPIToken(ctx context.Context, accountID uuid.UUID, apiTokenID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		apiToken, err := tx.APIToken().Read(ctx, apiTokenID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAPITokenNotFound
+			}
+
+			return err
+		}
+
+		// Accounts can only revoke their own tokens (pretend that others don't exist).
+		if apiToken.AccountID() != accountID {
+			return ErrAPITokenNotFound
+		}
+
+		err = tx.APIToken().Delete(ctx, apiToken)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAPITokenNotFound
+			}
+
+			return err
+		}
+
+		slog.Info("api token deleted",
+			"account_id", accountID,
+			"api_token_id", apiToken.ID(),
+			"api_token_name", apiToken.Name(),
+		)
+
+		return nil
+	})
+}
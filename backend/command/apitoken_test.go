@@ -0,0 +1,58 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestCreateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	name := test.RandomString(20)
+
+	token, err := cmd.CreateAPIToken(context.Background(), account.ID(), name)
+	test.AssertNilError(t, err)
+
+	// The plaintext token should authenticate the account.
+	got, err := repo.Account().ReadByAPIToken(context.Background(), token)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.ID(), account.ID())
+
+	// Names must be unique per account.
+	_, err = cmd.CreateAPIToken(context.Background(), account.ID(), name)
+	test.AssertErrorIs(t, err, command.ErrAPITokenNameConflict)
+}
+
+func TestDeleteAPIToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	apiToken, token := test.CreateAPIToken(t, repo, account)
+
+	// Other accounts shouldn't be able to revoke this token.
+	otherAccount := test.CreateAccount(t, repo)
+	err := cmd.DeleteAPIToken(context.Background(), otherAccount.ID(), apiToken.ID())
+	test.AssertErrorIs(t, err, command.ErrAPITokenNotFound)
+
+	err = cmd.DeleteAPIToken(context.Background(), account.ID(), apiToken.ID())
+	test.AssertNilError(t, err)
+
+	_, err = repo.Account().ReadByAPIToken(context.Background(), token)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
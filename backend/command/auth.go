@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"time"
@@ -14,12 +15,12 @@ import (
 
 var ErrSessionNotFound = errors.New("session: not found")
 
-func (cmd *Command) SignIn(username string) (string, error) {
+func (cmd *Command) SignIn(ctx context.Context, username string) (string, error) {
 	// NOTE: Handling state outside the transaciton is the exception, not the rule.
 	// This is a special case where a command needs to return a value (the session ID).
 	var sessionID string
-	err := cmd.repo.WithTransaction(func(tx *repository.Repository) error {
-		account, err := tx.Account().ReadByUsername(username)
+	err := cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().ReadByUsername(ctx, username)
 		if err != nil {
 			if !errors.Is(err, postgres.ErrNotFound) {
 				return err
@@ -31,7 +32,7 @@ func (cmd *Command) SignIn(username string) (string, error) {
 				return err
 			}
 
-			err = tx.Account().Create(account)
+			err = tx.Account().Create(ctx, account)
 			if err != nil {
 				return err
 			}
@@ -48,7 +49,7 @@ func (cmd *Command) SignIn(username string) (string, error) {
 			return err
 		}
 
-		err = tx.Session().Create(session)
+		err = tx.Session().Create(ctx, session)
 		if err != nil {
 			return err
 		}
@@ -64,9 +65,9 @@ func (cmd *Command) SignIn(username string) (string, error) {
 	return sessionID, err
 }
 
-func (cmd *Command) SignOut(sessionID string) error {
-	return cmd.repo.WithTransaction(func(tx *repository.Repository) error {
-		session, err := tx.Session().ReadBySessionID(sessionID)
+func (cmd *Command) SignOut(ctx context.Context, sessionID string) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		session, err := tx.Session().ReadBySessionID(ctx, sessionID)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrSessionNotFound
@@ -75,7 +76,7 @@ func (cmd *Command) SignOut(sessionID string) error {
 			return err
 		}
 
-		err = tx.Session().Delete(session)
+		err = tx.Session().Delete(ctx, session)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrSessionNotFound
@@ -88,16 +89,16 @@ func (cmd *Command) SignOut(sessionID string) error {
 	})
 }
 
-func (cmd *Command) DeleteExpiredSessions(now time.Time) error {
-	return cmd.repo.WithTransaction(func(tx *repository.Repository) error {
+func (cmd *Command) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
 		now := timeutil.Now()
-		expiredSessions, err := tx.Session().ListExpired(now)
+		expiredSessions, err := tx.Session().ListExpired(ctx, now)
 		if err != nil {
 			return err
 		}
 
 		for _, session := range expiredSessions {
-			err := tx.Session().Delete(session)
+			err := tx.Session().Delete(ctx, session)
 			if err != nil {
 				// Ignore any "not found" errors here.
 				if errors.Is(err, postgres.ErrNotFound) {
@@ -1,20 +1,96 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 
 	"github.com/google/uuid"
 
+	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/repository"
 )
 
 var ErrBlogNotFound = errors.New("blog: not found")
 
-func (cmd *Command) DeleteBlog(blogID uuid.UUID) error {
-	return cmd.repo.WithTransaction(func(tx *repository.Repository) error {
-		blog, err := tx.Blog().Read(blogID)
+// Add (if necessary) and follow a blog for the given account. Feed errors (like
+// feed.ErrUnreachableFeed and feed.ErrInvalidFeed) are returned as-is.
+func (cmd *Command) AddBlog(ctx context.Context, accountID uuid.UUID, feedURL string) (*model.Blog, error) {
+	account, err := cmd.repo.Account().Read(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return nil, ErrAccountNotFound
+		}
+
+		return nil, err
+	}
+
+	return cmd.addBlog(ctx, account, feedURL)
+}
+
+// NOTE: This isn't atomic because syncing a new blog (fetching its feed) can take a
+// while and shouldn't hold a transaction open. Conflicts are handled explicitly instead.
+func (cmd *Command) addBlog(ctx context.Context, account *model.Account, feedURL string) (*model.Blog, error) {
+	blog, err := cmd.repo.Blog().ReadByFeedURL(ctx, feedURL)
+	if err != nil {
+		if !errors.Is(err, postgres.ErrNotFound) {
+			return nil, err
+		}
+
+		// The blog doesn't exist yet so sync it for the first time (this might return an
+		// existing blog if the feed has permanently moved to a URL that is already known).
+		blog, err = cmd.blogSyncer.SyncBlog(ctx, feedURL)
+		if err != nil {
+			if !errors.Is(err, postgres.ErrConflict) {
+				return nil, err
+			}
+
+			// Someone else added this blog in the meantime, so just use theirs.
+			blog, err = cmd.repo.Blog().ReadByFeedURL(ctx, feedURL)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			slog.Info("blog added",
+				"account_id", account.ID(),
+				"account_username", account.Username(),
+				"blog_id", blog.ID(),
+				"blog_title", blog.Title(),
+			)
+		}
+	}
+
+	// Follow the blog and check for ErrConflict (already following).
+	err = cmd.repo.AccountBlog().Create(ctx, account, blog)
+	if err != nil {
+		if errors.Is(err, postgres.ErrConflict) {
+			return nil, ErrBlogAlreadyFollowed
+		}
+
+		return nil, err
+	}
+
+	slog.Info("blog followed",
+		"account_id", account.ID(),
+		"account_username", account.Username(),
+		"blog_id", blog.ID(),
+		"blog_title", blog.Title(),
+	)
+
+	return blog, nil
+}
+
+// Find the feed(s) available at a URL (which can be either a feed or a web page). Returns
+// feed.ErrNoFeedsFound if nothing was found and feed.ErrUnreachableFeed if the URL can't be fetched.
+func (cmd *Command) DiscoverFeeds(ctx context.Context, pageURL string) ([]feed.DiscoveredFeed, error) {
+	return feed.Discover(ctx, cmd.feedFetcher, pageURL)
+}
+
+func (cmd *Command) DeleteBlog(ctx context.Context, blogID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		blog, err := tx.Blog().Read(ctx, blogID)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrBlogNotFound
@@ -23,7 +99,7 @@ func (cmd *Command) DeleteBlog(blogID uuid.UUID) error {
 			return err
 		}
 
-		err = tx.Blog().Delete(blog)
+		err = tx.Blog().Delete(ctx, blog)
 		if err != nil {
 			if errors.Is(err, postgres.ErrNotFound) {
 				return ErrBlogNotFound
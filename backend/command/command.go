@@ -1,19 +1,32 @@
 package command
 
 import (
+	"context"
+
 	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/repository"
 )
 
+// BlogSyncer syncs a new or existing blog based on its feed URL (this is
+// satisfied by the application's shared job.SyncService).
+type BlogSyncer interface {
+	SyncBlog(ctx context.Context, feedURL string) (*model.Blog, error)
+}
+
 type Command struct {
 	repo        *repository.Repository
 	feedFetcher feed.FeedFetcher
+
+	// New blogs are added the same way that the sync job adds them.
+	blogSyncer BlogSyncer
 }
 
-func New(repo *repository.Repository, feedFetcher feed.FeedFetcher) *Command {
+func New(repo *repository.Repository, feedFetcher feed.FeedFetcher, blogSyncer BlogSyncer) *Command {
 	cmd := Command{
 		repo:        repo,
 		feedFetcher: feedFetcher,
+		blogSyncer:  blogSyncer,
 	}
 	return &cmd
 }
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+)
+
+var ErrContentFilterNotFound = errors.New("content filter: not found")
+var ErrContentFilterConflict = errors.New("content filter: already exists")
+var ErrContentFilterInvalidPattern = errors.New("content filter: invalid title pattern")
+
+// Create a content filter for an account. Blog filters must reference an existing blog
+// and title patterns must be valid (PostgreSQL) regular expressions.
+func (cmd *Command) CreateContentFilter(ctx context.Context, accountID uuid.UUID, kind model.ContentFilterKind, value string) (*model.ContentFilter, error) {
+	var filter *model.ContentFilter
+	err := cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		filter, err = model.NewContentFilter(account, kind, value)
+		if err != nil {
+			return err
+		}
+
+		// Title patterns are evaluated by PostgreSQL so make sure that it can handle them.
+		if filter.Kind() == model.ContentFilterKindTitle {
+			ok, err := tx.ContentFilter().IsValidTitlePattern(ctx, filter.Value())
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return ErrContentFilterInvalidPattern
+			}
+		}
+
+		if filter.Kind() == model.ContentFilterKindBlog {
+			_, err = tx.Blog().Read(ctx, filter.BlogID())
+			if err != nil {
+				if errors.Is(err, postgres.ErrNotFound) {
+					return ErrBlogNotFound
+				}
+
+				return err
+			}
+		}
+
+		err = tx.ContentFilter().Create(ctx, filter)
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				return ErrContentFilterConflict
+			}
+
+			return err
+		}
+
+		slog.Info("content filter created",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"content_filter_id", filter.ID(),
+			"content_filter_kind", filter.Kind(),
+			"content_filter_value", filter.Value(),
+		)
+
+		return nil
+	})
+
+	return filter, err
+}
+
+func (cmd *Command) DeleteContentFilter(ctx context.Context, accountID uuid.UUID, filterID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		filter, err := tx.ContentFilter().Read(ctx, filterID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrContentFilterNotFound
+			}
+
+			return err
+		}
+
+		// Accounts can only delete their own filters (pretend that others don't exist).
+		if filter.AccountID() != accountID {
+			return ErrContentFilterNotFound
+		}
+
+		err = tx.ContentFilter().Delete(ctx, filter)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrContentFilterNotFound
+			}
+
+			return err
+		}
+
+		slog.Info("content filter deleted",
+			"account_id", accountID,
+			"content_filter_id", filter.ID(),
+			"content_filter_kind", filter.Kind(),
+			"content_filter_value", filter.Value(),
+		)
+
+		return nil
+	})
+}
@@ -0,0 +1,99 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestCreateContentFilter(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+
+	// Tags should be normalized.
+	filter, err := cmd.CreateContentFilter(context.Background(), account.ID(), model.ContentFilterKindTag, "  Off  Topic ")
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, filter.Value(), "off topic")
+
+	got, err := repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.Kind(), model.ContentFilterKindTag)
+
+	// Filters must be unique per account.
+	_, err = cmd.CreateContentFilter(context.Background(), account.ID(), model.ContentFilterKindTag, "off topic")
+	test.AssertErrorIs(t, err, command.ErrContentFilterConflict)
+}
+
+func TestCreateContentFilterBlogNotFound(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+
+	_, err := cmd.CreateContentFilter(context.Background(), account.ID(), model.ContentFilterKindBlog, uuid.New().String())
+	test.AssertErrorIs(t, err, command.ErrBlogNotFound)
+}
+
+func TestCreateContentFilterInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+
+	// These are all fine as far as Go is concerned but PostgreSQL rejects them.
+	patterns := []string{`\pL`, `\Qa.b\E`, `foo\z`, `a{300}`}
+	for _, pattern := range patterns {
+		test.AssertEqual(t, model.ContentFilterKindTitle.IsValidValue(pattern), true)
+
+		_, err := cmd.CreateContentFilter(context.Background(), account.ID(), model.ContentFilterKindTitle, pattern)
+		test.AssertErrorIs(t, err, command.ErrContentFilterInvalidPattern)
+	}
+
+	// Valid patterns are still allowed.
+	_, err := cmd.CreateContentFilter(context.Background(), account.ID(), model.ContentFilterKindTitle, `^sponsored`)
+	test.AssertNilError(t, err)
+}
+
+func TestDeleteContentFilter(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	filter := test.CreateContentFilter(t, repo, account)
+
+	// Other accounts shouldn't be able to delete this filter.
+	otherAccount := test.CreateAccount(t, repo)
+	err := cmd.DeleteContentFilter(context.Background(), otherAccount.ID(), filter.ID())
+	test.AssertErrorIs(t, err, command.ErrContentFilterNotFound)
+
+	err = cmd.DeleteContentFilter(context.Background(), account.ID(), filter.ID())
+	test.AssertNilError(t, err)
+
+	_, err = repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+)
+
+var ErrFeedTokenNotFound = errors.New("feed token: not found")
+
+// Create a new token for an account's personal feed. Any existing token is revoked
+// (so this also serves as a way to reset a feed URL that has been shared too widely).
+func (cmd *Command) CreateFeedToken(ctx context.Context, accountID uuid.UUID) (string, error) {
+	// NOTE: Like CreateAPIToken, this command needs to return a value (the plaintext
+	// token) because it is only ever available at the moment of creation.
+	var token string
+	err := cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		existing, err := tx.FeedToken().ReadByAccount(ctx, account)
+		if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+			return err
+		}
+
+		if existing != nil {
+			err = tx.FeedToken().Delete(ctx, existing)
+			if err != nil {
+				return err
+			}
+		}
+
+		var feedToken *model.FeedToken
+		feedToken, token, err = model.NewFeedToken(account)
+		if err != nil {
+			return err
+		}
+
+		err = tx.FeedToken().Create(ctx, feedToken)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("feed token created",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"feed_token_id", feedToken.ID(),
+		)
+
+		return nil
+	})
+
+	return token, err
+}
+
+func (cmd *Command) DeleteFeedToken(ctx context.Context, accountID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		feedToken, err := tx.FeedToken().ReadByAccount(ctx, account)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrFeedTokenNotFound
+			}
+
+			return err
+		}
+
+		err = tx.FeedToken().Delete(ctx, feedToken)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrFeedTokenNotFound
+			}
+
+			return err
+		}
+
+		slog.Info("feed token deleted",
+			"account_id", account.ID(),
+			"feed_token_id", feedToken.ID(),
+		)
+
+		return nil
+	})
+}
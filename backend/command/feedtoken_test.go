@@ -0,0 +1,63 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestCreateFeedToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+
+	token, err := cmd.CreateFeedToken(context.Background(), account.ID())
+	test.AssertNilError(t, err)
+
+	// The plaintext token should identify the account.
+	feedToken, err := repo.FeedToken().ReadByToken(context.Background(), token)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, feedToken.AccountID(), account.ID())
+
+	// Creating another token revokes the old one.
+	newToken, err := cmd.CreateFeedToken(context.Background(), account.ID())
+	test.AssertNilError(t, err)
+
+	_, err = repo.FeedToken().ReadByToken(context.Background(), token)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+
+	feedToken, err = repo.FeedToken().ReadByToken(context.Background(), newToken)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, feedToken.AccountID(), account.ID())
+}
+
+func TestDeleteFeedToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	_, token := test.CreateFeedToken(t, repo, account)
+
+	err := cmd.DeleteFeedToken(context.Background(), account.ID())
+	test.AssertNilError(t, err)
+
+	_, err = repo.FeedToken().ReadByToken(context.Background(), token)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+
+	// There's nothing left to revoke.
+	err = cmd.DeleteFeedToken(context.Background(), account.ID())
+	test.AssertErrorIs(t, err, command.ErrFeedTokenNotFound)
+}
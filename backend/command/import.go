@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+const (
+	// How many feeds to import at once.
+	ImportConcurrency = 8
+)
+
+type ImportFeedStatus string
+
+const (
+	ImportFeedFollowed        ImportFeedStatus = "followed"
+	ImportFeedAlreadyFollowed ImportFeedStatus = "already followed"
+	ImportFeedUnreachable     ImportFeedStatus = "unreachable"
+	ImportFeedUnparseable     ImportFeedStatus = "unparseable"
+	ImportFeedFailed          ImportFeedStatus = "failed"
+	ImportFeedTimedOut        ImportFeedStatus = "timed out"
+)
+
+type ImportFeedResult struct {
+	FeedURL string
+	Status  ImportFeedStatus
+}
+
+// Add (if necessary) and follow a single feed for the given account. Errors are
+// never returned: anything unexpected is logged and reported as a failure so that
+// one bad feed can't spoil the rest of the import.
+func (cmd *Command) importFeed(ctx context.Context, account *model.Account, feedURL string) ImportFeedStatus {
+	// Don't bother starting if the import has already run out of time.
+	if ctx.Err() != nil {
+		return ImportFeedTimedOut
+	}
+
+	_, err := cmd.addBlog(ctx, account, feedURL)
+	if err != nil {
+		switch {
+		case ctx.Err() != nil:
+			return ImportFeedTimedOut
+		case errors.Is(err, ErrBlogAlreadyFollowed):
+			return ImportFeedAlreadyFollowed
+		case errors.Is(err, feed.ErrUnreachableFeed):
+			return ImportFeedUnreachable
+		case errors.Is(err, feed.ErrInvalidFeed):
+			return ImportFeedUnparseable
+		default:
+			slog.Error("error importing feed",
+				"account_id", account.ID(),
+				"feed_url", feedURL,
+				"error", err.Error(),
+			)
+			return ImportFeedFailed
+		}
+	}
+
+	return ImportFeedFollowed
+}
+
+// Add and follow many feeds at once (like those from an OPML file). Unlike most
+// commands, this isn't atomic: each feed is synced and followed independently
+// and the outcome for each one is reported back to the caller. If the context
+// ends early (a deadline, for example), any feeds that weren't finished by then
+// are reported as timed out instead of discarding the whole import.
+func (cmd *Command) ImportFeeds(ctx context.Context, accountID uuid.UUID, feedURLs []string) ([]ImportFeedResult, error) {
+	account, err := cmd.repo.Account().Read(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return nil, ErrAccountNotFound
+		}
+
+		return nil, err
+	}
+
+	results := make([]ImportFeedResult, len(feedURLs))
+
+	// NOTE: These goroutines never return an error (see importFeed).
+	var g errgroup.Group
+	g.SetLimit(ImportConcurrency)
+	for i, feedURL := range feedURLs {
+		feedURL = strings.TrimSpace(feedURL)
+		g.Go(func() error {
+			results[i] = ImportFeedResult{
+				FeedURL: feedURL,
+				Status:  cmd.importFeed(ctx, account, feedURL),
+			}
+			return nil
+		})
+	}
+
+	g.Wait()
+
+	return results, nil
+}
@@ -0,0 +1,132 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/feed"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestImportFeeds(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	// A feed for a blog that doesn't exist yet.
+	newFeedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+	newAtomFeed, err := feedMock.GenerateAtomFeed(newFeedBlog)
+	test.AssertNilError(t, err)
+
+	// A blog that exists and is already being followed.
+	followedBlog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, followedBlog)
+
+	unparseableFeedURL := test.RandomURL(20)
+	unreachableFeedURL := test.RandomURL(20)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		newFeedBlog.FeedURL: {Feed: newAtomFeed},
+		unparseableFeedURL:  {Feed: "<html><body>not a feed</body></html>"},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
+
+	results, err := cmd.ImportFeeds(context.Background(), account.ID(), []string{
+		newFeedBlog.FeedURL,
+		followedBlog.FeedURL(),
+		unparseableFeedURL,
+		unreachableFeedURL,
+	})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(results), 4)
+
+	test.AssertEqual(t, results[0].Status, command.ImportFeedFollowed)
+	test.AssertEqual(t, results[1].Status, command.ImportFeedAlreadyFollowed)
+	test.AssertEqual(t, results[2].Status, command.ImportFeedUnparseable)
+	test.AssertEqual(t, results[3].Status, command.ImportFeedUnreachable)
+
+	// The new blog should now exist and be followed.
+	blog, err := repo.Blog().ReadByFeedURL(context.Background(), newFeedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	updatedAccount, err := repo.Account().Read(context.Background(), account.ID())
+	test.AssertNilError(t, err)
+	test.AssertSliceContains(t, updatedAccount.FollowedBlogIDs(), blog.ID())
+}
+
+// A BlogSyncer that always fails with the given function's error.
+type failingBlogSyncer struct {
+	fail func() error
+}
+
+func (s *failingBlogSyncer) SyncBlog(ctx context.Context, feedURL string) (*model.Blog, error) {
+	return nil, s.fail()
+}
+
+func TestImportFeedsFailed(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	// Unexpected errors should be reported per feed instead of aborting the import.
+	blogSyncer := &failingBlogSyncer{fail: func() error { return errors.New("boom") }}
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), blogSyncer)
+
+	results, err := cmd.ImportFeeds(context.Background(), account.ID(), []string{
+		test.RandomURL(20),
+		test.RandomURL(20),
+	})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(results), 2)
+
+	for _, result := range results {
+		test.AssertEqual(t, result.Status, command.ImportFeedFailed)
+	}
+}
+
+func TestImportFeedsTimedOut(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Simulate the import running out of time while syncing.
+	blogSyncer := &failingBlogSyncer{fail: func() error {
+		cancel()
+		return ctx.Err()
+	}}
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), blogSyncer)
+
+	results, err := cmd.ImportFeeds(ctx, account.ID(), []string{
+		test.RandomURL(20),
+		test.RandomURL(20),
+	})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(results), 2)
+
+	for _, result := range results {
+		test.AssertEqual(t, result.Status, command.ImportFeedTimedOut)
+	}
+}
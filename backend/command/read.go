@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+)
+
+var ErrPostNotFound = errors.New("post: not found")
+
+// NOTE: Marking posts as read (or unread) is idempotent: repeating the same
+// command is not an error.
+
+func (cmd *Command) MarkPostRead(ctx context.Context, accountID uuid.UUID, postID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		post, err := tx.Post().Read(ctx, postID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrPostNotFound
+			}
+
+			return err
+		}
+
+		return tx.PostRead().Create(ctx, account, post)
+	})
+}
+
+func (cmd *Command) MarkPostUnread(ctx context.Context, accountID uuid.UUID, postID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		post, err := tx.Post().Read(ctx, postID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrPostNotFound
+			}
+
+			return err
+		}
+
+		err = tx.PostRead().Delete(ctx, account, post)
+		if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Mark every post (from followed blogs) added at or before a given time as read.
+// Using a timestamp (instead of "now") ensures that posts which showed up after
+// the user last looked at their timeline are left unread (even back-dated ones).
+func (cmd *Command) MarkAllPostsRead(ctx context.Context, accountID uuid.UUID, before time.Time) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		err = tx.PostRead().CreateByAccount(ctx, account, before)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("all posts marked read",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"before", before,
+		)
+
+		return nil
+	})
+}
+
+func (cmd *Command) MarkBlogRead(ctx context.Context, accountID uuid.UUID, blogID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		blog, err := tx.Blog().Read(ctx, blogID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrBlogNotFound
+			}
+
+			return err
+		}
+
+		err = tx.PostRead().CreateByBlog(ctx, account, blog)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("blog marked read",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"blog_id", blog.ID(),
+			"blog_title", blog.Title(),
+		)
+
+		return nil
+	})
+}
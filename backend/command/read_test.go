@@ -0,0 +1,118 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestMarkPostReadUnread(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+	post := test.CreatePost(t, repo, blog)
+
+	// Marking a post as read should be idempotent.
+	err := cmd.MarkPostRead(context.Background(), account.ID(), post.ID())
+	test.AssertNilError(t, err)
+	err = cmd.MarkPostRead(context.Background(), account.ID(), post.ID())
+	test.AssertNilError(t, err)
+
+	count, err := qry.CountUnreadArticlesByAccount(context.Background(), account, false)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 0)
+
+	// As should marking it as unread.
+	err = cmd.MarkPostUnread(context.Background(), account.ID(), post.ID())
+	test.AssertNilError(t, err)
+	err = cmd.MarkPostUnread(context.Background(), account.ID(), post.ID())
+	test.AssertNilError(t, err)
+
+	count, err = qry.CountUnreadArticlesByAccount(context.Background(), account, false)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+
+	err = cmd.MarkPostRead(context.Background(), account.ID(), test.NewPost(t, blog).ID())
+	test.AssertErrorIs(t, err, command.ErrPostNotFound)
+}
+
+func TestMarkAllPostsRead(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	// A post that was around when the account last looked at its timeline.
+	oldPost := test.CreatePost(t, repo, blog)
+	before := oldPost.CreatedAt()
+
+	// And one that showed up afterwards (but claims to be older).
+	newPost, err := model.NewPost(blog, test.RandomURL(32), test.RandomString(32), test.RandomString(32), before.Add(-24*time.Hour))
+	test.AssertNilError(t, err)
+	err = repo.Post().Create(context.Background(), newPost)
+	test.AssertNilError(t, err)
+
+	// Only posts added before the timestamp should be marked as read.
+	err = cmd.MarkAllPostsRead(context.Background(), account.ID(), before)
+	test.AssertNilError(t, err)
+
+	articles, err := qry.ListUnreadArticlesByAccount(context.Background(), account, false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+	test.AssertEqual(t, articles[0].ID, newPost.ID())
+}
+
+func TestMarkBlogRead(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+
+	readBlog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, readBlog)
+	test.CreatePost(t, repo, readBlog)
+	test.CreatePost(t, repo, readBlog)
+
+	otherBlog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, otherBlog)
+	test.CreatePost(t, repo, otherBlog)
+
+	err := cmd.MarkBlogRead(context.Background(), account.ID(), readBlog.ID())
+	test.AssertNilError(t, err)
+
+	// Only the post from the other blog should remain unread.
+	count, err := qry.CountUnreadArticlesByAccount(context.Background(), account, false)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+}
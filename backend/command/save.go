@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+)
+
+var ErrPostAlreadySaved = errors.New("account: post already saved")
+var ErrPostNotSaved = errors.New("account: post not saved")
+
+func (cmd *Command) SavePost(ctx context.Context, accountID uuid.UUID, postID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		post, err := tx.Post().Read(ctx, postID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrPostNotFound
+			}
+
+			return err
+		}
+
+		err = tx.AccountPost().Create(ctx, account, post)
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				return ErrPostAlreadySaved
+			}
+
+			return err
+		}
+
+		slog.Info("post saved",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"post_id", post.ID(),
+			"post_title", post.Title(),
+		)
+
+		return nil
+	})
+}
+
+func (cmd *Command) UnsavePost(ctx context.Context, accountID uuid.UUID, postID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		post, err := tx.Post().Read(ctx, postID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrPostNotFound
+			}
+
+			return err
+		}
+
+		err = tx.AccountPost().Delete(ctx, account, post)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrPostNotSaved
+			}
+
+			return err
+		}
+
+		slog.Info("post unsaved",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"post_id", post.ID(),
+			"post_title", post.Title(),
+		)
+
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestSavePost(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := cmd.SavePost(context.Background(), account.ID(), post.ID())
+	test.AssertNilError(t, err)
+
+	count, err := qry.CountSavedArticlesByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+
+	err = cmd.SavePost(context.Background(), account.ID(), post.ID())
+	test.AssertErrorIs(t, err, command.ErrPostAlreadySaved)
+
+	err = cmd.SavePost(context.Background(), account.ID(), test.NewPost(t, blog).ID())
+	test.AssertErrorIs(t, err, command.ErrPostNotFound)
+}
+
+func TestUnsavePost(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+	test.CreateAccountPost(t, repo, account, post)
+
+	err := cmd.UnsavePost(context.Background(), account.ID(), post.ID())
+	test.AssertNilError(t, err)
+
+	count, err := qry.CountSavedArticlesByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 0)
+
+	err = cmd.UnsavePost(context.Background(), account.ID(), post.ID())
+	test.AssertErrorIs(t, err, command.ErrPostNotSaved)
+}
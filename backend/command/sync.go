@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"errors"
 
 	"github.com/theandrew168/bloggulus/backend/command/sync"
@@ -8,8 +9,8 @@ import (
 )
 
 // Sync a new or existing Blog based on the provided feed URL.
-func (cmd *Command) SyncBlog(feedURL string) error {
-	blog, err := cmd.repo.Blog().ReadByFeedURL(feedURL)
+func (cmd *Command) SyncBlog(ctx context.Context, feedURL string) error {
+	blog, err := cmd.repo.Blog().ReadByFeedURL(ctx, feedURL)
 	if err != nil {
 		if !errors.Is(err, postgres.ErrNotFound) {
 			return err
@@ -18,8 +19,10 @@ func (cmd *Command) SyncBlog(feedURL string) error {
 		// An ErrNotFound is acceptable (and expected) here. The only difference
 		// is that we won't be able to include the ETag and Last-Modified headers
 		// in the request. This is fine for new blogs (an unconditional fetch).
-		return sync.SyncNewBlog(cmd.repo, cmd.feedFetcher, feedURL)
+		_, err = sync.SyncNewBlog(ctx, cmd.repo, cmd.feedFetcher, feedURL)
+		return err
 	}
 
-	return sync.SyncExistingBlog(cmd.repo, cmd.feedFetcher, blog)
+	_, err = sync.SyncExistingBlog(ctx, cmd.repo, cmd.feedFetcher, blog)
+	return err
 }
@@ -1,7 +1,9 @@
 package sync
 
 import (
+	"context"
 	"log/slog"
+	"slices"
 
 	"github.com/theandrew168/bloggulus/backend/feed"
 	"github.com/theandrew168/bloggulus/backend/model"
@@ -25,6 +27,20 @@ func UpdateCacheHeaders(blog *model.Blog, response feed.FetchFeedResponse) bool
 	return headersChanged
 }
 
+// Convert a feed post's enclosures into their model equivalent.
+func convertEnclosures(feedEnclosures []feed.Enclosure) []model.PostEnclosure {
+	var enclosures []model.PostEnclosure
+	for _, enclosure := range feedEnclosures {
+		enclosures = append(enclosures, model.PostEnclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: enclosure.Length,
+		})
+	}
+
+	return enclosures
+}
+
 type ComparePostsResult struct {
 	PostsToCreate []*model.Post
 	PostsToUpdate []*model.Post
@@ -58,6 +74,12 @@ func ComparePosts(blog *model.Blog, knownPosts []*model.Post, feedPosts []feed.P
 				return ComparePostsResult{}, err
 			}
 
+			postToCreate.SetAuthors(feedPost.Authors)
+			postToCreate.SetSummary(feedPost.Summary)
+			postToCreate.SetCategories(feedPost.Categories)
+			postToCreate.SetImageURL(feedPost.ImageURL)
+			postToCreate.SetEnclosures(convertEnclosures(feedPost.Enclosures))
+
 			postsToCreate = append(postsToCreate, postToCreate)
 		} else {
 			// The post already exists but we might need to update it.
@@ -81,6 +103,37 @@ func ComparePosts(blog *model.Blog, knownPosts []*model.Post, feedPosts []feed.P
 				knownPostShouldBeUpdated = true
 			}
 
+			// Check if the post's authors have changed.
+			if len(feedPost.Authors) > 0 && !slices.Equal(feedPost.Authors, knownPost.Authors()) {
+				knownPost.SetAuthors(feedPost.Authors)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's summary has changed.
+			if feedPost.Summary != "" && feedPost.Summary != knownPost.Summary() {
+				knownPost.SetSummary(feedPost.Summary)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's categories have changed.
+			if len(feedPost.Categories) > 0 && !slices.Equal(feedPost.Categories, knownPost.Categories()) {
+				knownPost.SetCategories(feedPost.Categories)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's image has changed.
+			if feedPost.ImageURL != "" && feedPost.ImageURL != knownPost.ImageURL() {
+				knownPost.SetImageURL(feedPost.ImageURL)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's enclosures have changed.
+			enclosures := convertEnclosures(feedPost.Enclosures)
+			if len(enclosures) > 0 && !slices.Equal(enclosures, knownPost.Enclosures()) {
+				knownPost.SetEnclosures(enclosures)
+				knownPostShouldBeUpdated = true
+			}
+
 			// If any post data has changed, add it to the list of posts to update.
 			if knownPostShouldBeUpdated {
 				postsToUpdate = append(postsToUpdate, knownPost)
@@ -95,24 +148,24 @@ func ComparePosts(blog *model.Blog, knownPosts []*model.Post, feedPosts []feed.P
 	return result, nil
 }
 
-func SyncNewBlog(repo *repository.Repository, feedFetcher feed.FeedFetcher, feedURL string) error {
+func SyncNewBlog(ctx context.Context, repo *repository.Repository, feedFetcher feed.FeedFetcher, feedURL string) (*model.Blog, error) {
 	// Make an unconditional fetch for the blog's feed.
 	req := feed.FetchFeedRequest{
 		URL: feedURL,
 	}
-	resp, err := feedFetcher.FetchFeed(req)
+	resp, err := feedFetcher.FetchFeed(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// No feed data from a new blog is an error.
 	if resp.Feed == "" {
-		return feed.ErrUnreachableFeed
+		return nil, feed.ErrUnreachableFeed
 	}
 
 	feedBlog, err := feed.Parse(feedURL, resp.Feed)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create a new blog based on the feed data.
@@ -125,64 +178,64 @@ func SyncNewBlog(repo *repository.Repository, feedFetcher feed.FeedFetcher, feed
 		timeutil.Now(),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = repo.Blog().Create(blog)
+	err = repo.Blog().Create(ctx, blog)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = SyncPosts(repo, blog, feedBlog.Posts)
+	err = SyncPosts(ctx, repo, blog, feedBlog.Posts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return blog, nil
 }
 
-func SyncExistingBlog(repo *repository.Repository, feedFetcher feed.FeedFetcher, blog *model.Blog) error {
+func SyncExistingBlog(ctx context.Context, repo *repository.Repository, feedFetcher feed.FeedFetcher, blog *model.Blog) (*model.Blog, error) {
 	// Make a conditional fetch for the blog's feed.
 	req := feed.FetchFeedRequest{
 		URL:          blog.FeedURL(),
 		ETag:         blog.ETag(),
 		LastModified: blog.LastModified(),
 	}
-	resp, err := feedFetcher.FetchFeed(req)
+	resp, err := feedFetcher.FetchFeed(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Update the blog's cache headers if they have changed.
 	headersChanged := UpdateCacheHeaders(blog, resp)
 	if headersChanged {
-		err = repo.Blog().Update(blog)
+		err = repo.Blog().Update(ctx, blog)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if resp.Feed == "" {
 		slog.Info("skipping blog (no feed content)", "title", blog.Title(), "id", blog.ID())
-		return nil
+		return blog, nil
 	}
 
 	feedBlog, err := feed.Parse(blog.FeedURL(), resp.Feed)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = SyncPosts(repo, blog, feedBlog.Posts)
+	err = SyncPosts(ctx, repo, blog, feedBlog.Posts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return blog, nil
 }
 
-func SyncPosts(repo *repository.Repository, blog *model.Blog, feedPosts []feed.Post) error {
+func SyncPosts(ctx context.Context, repo *repository.Repository, blog *model.Blog, feedPosts []feed.Post) error {
 	// List all known posts for the current blog.
-	knownPosts, err := repo.Post().ListByBlog(blog)
+	knownPosts, err := repo.Post().ListByBlog(ctx, blog)
 	if err != nil {
 		return err
 	}
@@ -195,17 +248,29 @@ func SyncPosts(repo *repository.Repository, blog *model.Blog, feedPosts []feed.P
 
 	// Create any posts that are new.
 	for _, post := range result.PostsToCreate {
-		err = repo.Post().Create(post)
+		err = repo.Post().Create(ctx, post)
 		if err != nil {
 			slog.Warn("failed to create post", "url", post.URL(), "error", err.Error())
+			continue
+		}
+
+		err = repo.PostTag().Replace(ctx, post, model.NormalizeTagNames(post.Categories()))
+		if err != nil {
+			slog.Warn("failed to tag post", "url", post.URL(), "error", err.Error())
 		}
 	}
 
 	// Update any posts that have changed.
 	for _, post := range result.PostsToUpdate {
-		err = repo.Post().Update(post)
+		err = repo.Post().Update(ctx, post)
 		if err != nil {
 			slog.Warn("failed to update post", "url", post.URL(), "error", err.Error())
+			continue
+		}
+
+		err = repo.PostTag().Replace(ctx, post, model.NormalizeTagNames(post.Categories()))
+		if err != nil {
+			slog.Warn("failed to tag post", "url", post.URL(), "error", err.Error())
 		}
 	}
 
@@ -1,6 +1,7 @@
 package sync_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/theandrew168/bloggulus/backend/command/sync"
 	"github.com/theandrew168/bloggulus/backend/feed"
 	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/job"
 	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/test"
 )
@@ -88,6 +90,72 @@ func TestComparePosts(t *testing.T) {
 	test.AssertEqual(t, result.PostsToUpdate[0].PublishedAt(), updatedPost.PublishedAt)
 }
 
+func TestComparePostsMetadata(t *testing.T) {
+	t.Parallel()
+
+	blog := test.NewBlog(t)
+
+	knownPost := test.NewPost(t, blog)
+	unchangedPost := test.NewPost(t, blog)
+	knownPosts := []*model.Post{
+		knownPost,
+		unchangedPost,
+	}
+
+	newPost := feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		Content:     test.RandomString(200),
+		PublishedAt: test.RandomTime(),
+		Authors:     []string{"Alice"},
+		Summary:     test.RandomString(50),
+		Categories:  []string{"foo", "bar"},
+		ImageURL:    test.RandomURL(20),
+		Enclosures: []feed.Enclosure{
+			{URL: test.RandomURL(20), Type: "audio/mpeg", Length: 12345},
+		},
+	}
+	updatedPost := feed.Post{
+		URL:         knownPost.URL(),
+		Title:       knownPost.Title(),
+		Content:     knownPost.Content(),
+		PublishedAt: knownPost.PublishedAt(),
+		Summary:     test.RandomString(50),
+		Categories:  []string{"baz"},
+	}
+	// Posts with no metadata in the feed should be left alone.
+	sameFeedPost := feed.Post{
+		URL:         unchangedPost.URL(),
+		Title:       unchangedPost.Title(),
+		Content:     unchangedPost.Content(),
+		PublishedAt: unchangedPost.PublishedAt(),
+	}
+	feedPosts := []feed.Post{
+		newPost,
+		updatedPost,
+		sameFeedPost,
+	}
+
+	result, err := sync.ComparePosts(blog, knownPosts, feedPosts)
+	test.AssertNilError(t, err)
+
+	// Verify that the new post includes all of its metadata.
+	test.AssertEqual(t, len(result.PostsToCreate), 1)
+	test.AssertEqual(t, result.PostsToCreate[0].Authors(), newPost.Authors)
+	test.AssertEqual(t, result.PostsToCreate[0].Summary(), newPost.Summary)
+	test.AssertEqual(t, result.PostsToCreate[0].Categories(), newPost.Categories)
+	test.AssertEqual(t, result.PostsToCreate[0].ImageURL(), newPost.ImageURL)
+	test.AssertEqual(t, result.PostsToCreate[0].Enclosures(), []model.PostEnclosure{
+		{URL: newPost.Enclosures[0].URL, Type: "audio/mpeg", Length: 12345},
+	})
+
+	// Verify that only the post with changed metadata should be updated.
+	test.AssertEqual(t, len(result.PostsToUpdate), 1)
+	test.AssertEqual(t, result.PostsToUpdate[0].URL(), knownPost.URL())
+	test.AssertEqual(t, result.PostsToUpdate[0].Summary(), updatedPost.Summary)
+	test.AssertEqual(t, result.PostsToUpdate[0].Categories(), updatedPost.Categories)
+}
+
 func TestNewBlog(t *testing.T) {
 	t.Parallel()
 
@@ -115,13 +183,13 @@ func TestNewBlog(t *testing.T) {
 	}
 	feedFetcher := feedMock.NewFeedFetcher(feeds)
 
-	cmd := command.New(repo, feedFetcher)
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
 	// sync a new blog
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
-	blog, err := repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err := repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify blog data
@@ -131,7 +199,7 @@ func TestNewBlog(t *testing.T) {
 	test.AssertEqual(t, blog.FeedURL(), feedBlog.FeedURL)
 
 	// fetch posts and verify count
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -162,13 +230,13 @@ func TestExistingBlog(t *testing.T) {
 	}
 	feedFetcher := feedMock.NewFeedFetcher(feeds)
 
-	cmd := command.New(repo, feedFetcher)
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
 	// sync a new blog
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
-	blog, err := repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err := repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify blog data
@@ -178,7 +246,7 @@ func TestExistingBlog(t *testing.T) {
 	test.AssertEqual(t, blog.FeedURL(), feedBlog.FeedURL)
 
 	// fetch posts and verify count (should be none)
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 0)
 
@@ -198,11 +266,11 @@ func TestExistingBlog(t *testing.T) {
 	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
 
 	// sync the blog again
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// fetch posts and verify count
-	posts, err = repo.Post().ListByBlog(blog)
+	posts, err = repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -224,9 +292,9 @@ func TestUnreachableFeed(t *testing.T) {
 	feeds := map[string]feed.FetchFeedResponse{}
 	feedFetcher := feedMock.NewFeedFetcher(feeds)
 
-	cmd := command.New(repo, feedFetcher)
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
-	err := cmd.SyncBlog(feedURL)
+	err := cmd.SyncBlog(context.Background(), feedURL)
 	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
 }
 
@@ -256,17 +324,17 @@ func TestUpdatePostContent(t *testing.T) {
 	}
 	feedFetcher := feedMock.NewFeedFetcher(feeds)
 
-	cmd := command.New(repo, feedFetcher)
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
 	// sync a new blog
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
-	blog, err := repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err := repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// fetch posts and verify count
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -285,11 +353,11 @@ func TestUpdatePostContent(t *testing.T) {
 	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
 
 	// sync the blog again
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// refetch posts and verify count
-	posts, err = repo.Post().ListByBlog(blog)
+	posts, err = repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -319,27 +387,27 @@ func TestCacheHeaderOverwrite(t *testing.T) {
 	}
 	feedFetcher := feedMock.NewFeedFetcher(feeds)
 
-	cmd := command.New(repo, feedFetcher)
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
 	// sync a new blog
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
-	blog, err := repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err := repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// update the blog's ETag and LastModified to something non-empty
 	blog.SetETag("foo")
 	blog.SetLastModified("bar")
-	err = repo.Blog().Update(blog)
+	err = repo.Blog().Update(context.Background(), blog)
 	test.AssertNilError(t, err)
 
 	// sync the blog again (will see empty ETag and LastModified values)
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// refetch the blog
-	blog, err = repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err = repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify that the existing ETag and LastModified values haven't been wiped out
@@ -372,13 +440,13 @@ func TestCacheHeaderUpdate(t *testing.T) {
 	}
 	feedFetcher := feedMock.NewFeedFetcher(feeds)
 
-	cmd := command.New(repo, feedFetcher)
+	cmd := command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
 	// sync a new blog
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
-	blog, err := repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err := repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, blog.ETag(), "etag")
 	test.AssertEqual(t, blog.LastModified(), "lastModified")
@@ -392,14 +460,14 @@ func TestCacheHeaderUpdate(t *testing.T) {
 	}
 	feedFetcher = feedMock.NewFeedFetcher(feeds)
 
-	cmd = command.New(repo, feedFetcher)
+	cmd = command.New(repo, feedFetcher, job.NewSyncService(repo, feedFetcher))
 
 	// sync the blog again (will see new ETag and LastModified values)
-	err = cmd.SyncBlog(feedBlog.FeedURL)
+	err = cmd.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// refetch the blog
-	blog, err = repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err = repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify that the ETag and LastModified values got updated
@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+)
+
+var ErrWebhookNotFound = errors.New("webhook: not found")
+var ErrWebhookURLConflict = errors.New("webhook: url already exists")
+
+// Create a webhook for an account. If no secret is provided, a random one is generated.
+func (cmd *Command) CreateWebhook(ctx context.Context, accountID uuid.UUID, url, secret string) (*model.Webhook, error) {
+	var webhook *model.Webhook
+	err := cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		account, err := tx.Account().Read(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrAccountNotFound
+			}
+
+			return err
+		}
+
+		if secret == "" {
+			secret, err = model.GenerateWebhookSecret()
+			if err != nil {
+				return err
+			}
+		}
+
+		webhook, err = model.NewWebhook(account, url, secret)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Webhook().Create(ctx, webhook)
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				return ErrWebhookURLConflict
+			}
+
+			return err
+		}
+
+		slog.Info("webhook created",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"webhook_id", webhook.ID(),
+			"webhook_url", webhook.URL(),
+		)
+
+		return nil
+	})
+
+	return webhook, err
+}
+
+func (cmd *Command) DeleteWebhook(ctx context.Context, accountID uuid.UUID, webhookID uuid.UUID) error {
+	return cmd.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		webhook, err := tx.Webhook().Read(ctx, webhookID)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrWebhookNotFound
+			}
+
+			return err
+		}
+
+		// Accounts can only delete their own webhooks (pretend that others don't exist).
+		if webhook.AccountID() != accountID {
+			return ErrWebhookNotFound
+		}
+
+		err = tx.Webhook().Delete(ctx, webhook)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return ErrWebhookNotFound
+			}
+
+			return err
+		}
+
+		slog.Info("webhook deleted",
+			"account_id", accountID,
+			"webhook_id", webhook.ID(),
+			"webhook_url", webhook.URL(),
+		)
+
+		return nil
+	})
+}
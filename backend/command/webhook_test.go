@@ -0,0 +1,61 @@
+package command_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	url := test.RandomURL(32)
+
+	// A secret should be generated if one isn't provided.
+	webhook, err := cmd.CreateWebhook(context.Background(), account.ID(), url, "")
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, strings.HasPrefix(webhook.Secret(), model.WebhookSecretPrefix), true)
+
+	got, err := repo.Webhook().Read(context.Background(), webhook.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.URL(), url)
+
+	// URLs must be unique per account.
+	_, err = cmd.CreateWebhook(context.Background(), account.ID(), url, "secret")
+	test.AssertErrorIs(t, err, command.ErrWebhookURLConflict)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+
+	// Other accounts shouldn't be able to delete this webhook.
+	otherAccount := test.CreateAccount(t, repo)
+	err := cmd.DeleteWebhook(context.Background(), otherAccount.ID(), webhook.ID())
+	test.AssertErrorIs(t, err, command.ErrWebhookNotFound)
+
+	err = cmd.DeleteWebhook(context.Background(), account.ID(), webhook.ID())
+	test.AssertNilError(t, err)
+
+	_, err = repo.Webhook().Read(context.Background(), webhook.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
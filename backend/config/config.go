@@ -9,7 +9,9 @@ import (
 )
 
 const (
-	DefaultPort = "5000"
+	DefaultPort     = "5000"
+	DefaultSMTPPort = "587"
+	DefaultSiteURL  = "https://bloggulus.com"
 )
 
 type Config struct {
@@ -23,12 +25,20 @@ type Config struct {
 	GoogleClientSecret string `toml:"google_client_secret"`
 	GoogleRedirectURI  string `toml:"google_redirect_uri"`
 	GoatCounterCode    string `toml:"goatcounter_code"`
+	SiteURL            string `toml:"site_url"`
+	SMTPHost           string `toml:"smtp_host"`
+	SMTPPort           string `toml:"smtp_port"`
+	SMTPUsername       string `toml:"smtp_username"`
+	SMTPPassword       string `toml:"smtp_password"`
+	SMTPFrom           string `toml:"smtp_from"`
 }
 
 func Read(data string) (Config, error) {
 	// Initialize config with default values.
 	conf := Config{
-		Port: DefaultPort,
+		Port:     DefaultPort,
+		SMTPPort: DefaultSMTPPort,
+		SiteURL:  DefaultSiteURL,
 	}
 	meta, err := toml.Decode(data, &conf)
 	if err != nil {
@@ -43,6 +43,8 @@ func TestOptional(t *testing.T) {
 
 	test.AssertEqual(t, cfg.DatabaseURI, databaseURI)
 	test.AssertEqual(t, cfg.Port, config.DefaultPort)
+	test.AssertEqual(t, cfg.SMTPPort, config.DefaultSMTPPort)
+	test.AssertEqual(t, cfg.SiteURL, config.DefaultSiteURL)
 }
 
 func TestRequired(t *testing.T) {
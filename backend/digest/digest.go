@@ -0,0 +1,105 @@
+package digest
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+
+	"github.com/theandrew168/bloggulus/backend/mail"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/query"
+)
+
+//go:embed digest.html
+var DigestHTML string
+
+//go:embed digest.txt
+var DigestText string
+
+//go:embed verify.html
+var VerifyHTML string
+
+//go:embed verify.txt
+var VerifyText string
+
+var (
+	htmlTmpl = htmlTemplate.Must(htmlTemplate.New("digest").Parse(DigestHTML))
+	textTmpl = textTemplate.Must(textTemplate.New("digest").Parse(DigestText))
+
+	verifyHTMLTmpl = htmlTemplate.Must(htmlTemplate.New("verify").Parse(VerifyHTML))
+	verifyTextTmpl = textTemplate.Must(textTemplate.New("verify").Parse(VerifyText))
+)
+
+type Data struct {
+	Username  string
+	Frequency model.DigestFrequency
+	Articles  []query.Article
+
+	// Where to link back to (for managing the digest and reading more).
+	SiteURL string
+}
+
+func subject(data Data) string {
+	posts := "posts"
+	if len(data.Articles) == 1 {
+		posts = "post"
+	}
+
+	return fmt.Sprintf("Your %s Bloggulus digest: %d new %s", data.Frequency, len(data.Articles), posts)
+}
+
+// Render a digest into an email message (with both text and HTML bodies).
+func Render(to string, data Data) (mail.Message, error) {
+	var text bytes.Buffer
+	err := textTmpl.Execute(&text, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	var html bytes.Buffer
+	err = htmlTmpl.Execute(&html, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	message := mail.Message{
+		To:      to,
+		Subject: subject(data),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}
+	return message, nil
+}
+
+type VerificationData struct {
+	Username string
+
+	// The confirmation link (and how long until it stops working).
+	VerifyURL string
+	ExpiresIn string
+}
+
+// Render a request to confirm a digest's email address into an email message.
+func RenderVerification(to string, data VerificationData) (mail.Message, error) {
+	var text bytes.Buffer
+	err := verifyTextTmpl.Execute(&text, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	var html bytes.Buffer
+	err = verifyHTMLTmpl.Execute(&html, data)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	message := mail.Message{
+		To:      to,
+		Subject: "Confirm your Bloggulus digest",
+		Text:    text.String(),
+		HTML:    html.String(),
+	}
+	return message, nil
+}
@@ -0,0 +1,67 @@
+package digest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/digest"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	data := digest.Data{
+		Username:  "foo",
+		Frequency: model.DigestFrequencyWeekly,
+		SiteURL:   "https://bloggulus.com",
+		Articles: []query.Article{
+			{
+				Title:       "Tom & Jerry",
+				URL:         "https://example.com/tom-and-jerry",
+				BlogTitle:   "Cartoons",
+				BlogURL:     "https://example.com",
+				PublishedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	message, err := digest.Render("foo@example.com", data)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, message.To, "foo@example.com")
+	test.AssertStringContains(t, message.Subject, "weekly")
+	test.AssertStringContains(t, message.Subject, "1 new post")
+
+	// The text body shouldn't be escaped.
+	test.AssertStringContains(t, message.Text, "Tom & Jerry")
+	test.AssertStringContains(t, message.Text, "Cartoons - Jan 2, 2024")
+	test.AssertStringContains(t, message.Text, "https://bloggulus.com/digest")
+
+	// But the HTML body should be.
+	test.AssertStringContains(t, message.HTML, "Tom &amp; Jerry")
+	test.AssertStringContains(t, message.HTML, `href="https://example.com/tom-and-jerry"`)
+	test.AssertStringContains(t, message.HTML, `href="https://bloggulus.com/digest"`)
+}
+
+func TestRenderVerification(t *testing.T) {
+	t.Parallel()
+
+	data := digest.VerificationData{
+		Username:  "foo",
+		VerifyURL: "https://bloggulus.com/digest/verify?token=a&b",
+		ExpiresIn: "7 days",
+	}
+
+	message, err := digest.RenderVerification("foo@example.com", data)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, message.To, "foo@example.com")
+	test.AssertStringContains(t, message.Subject, "Confirm")
+
+	test.AssertStringContains(t, message.Text, "https://bloggulus.com/digest/verify?token=a&b")
+	test.AssertStringContains(t, message.Text, "7 days")
+	test.AssertStringContains(t, message.HTML, `href="https://bloggulus.com/digest/verify?token=a&amp;b"`)
+}
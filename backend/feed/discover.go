@@ -0,0 +1,165 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+)
+
+var (
+	ErrNoFeedsFound = errors.New("feed: no feeds found")
+)
+
+// Paths that commonly host a site's feed (checked if a page doesn't link to any).
+var CommonFeedPaths = []string{
+	"/feed",
+	"/feed.xml",
+	"/rss",
+	"/rss.xml",
+	"/atom.xml",
+	"/index.xml",
+	"/feed.json",
+}
+
+// MIME types used by <link rel="alternate"> elements that point to feeds.
+var feedLinkTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/feed+json": true,
+}
+
+type DiscoveredFeed struct {
+	URL   string
+	Title string
+}
+
+// Find the feeds linked from an HTML page via <link rel="alternate"> elements.
+// Relative links are resolved against the page's URL.
+func FindFeedLinks(pageURL string, pageBody string) []DiscoveredFeed {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	var feeds []DiscoveredFeed
+	seen := make(map[string]bool)
+
+	z := html.NewTokenizer(strings.NewReader(pageBody))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return feeds
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := z.Token()
+			if token.Data != "link" {
+				continue
+			}
+
+			var rel, typ, href, title string
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "rel":
+					rel = strings.ToLower(attr.Val)
+				case "type":
+					typ = strings.ToLower(strings.TrimSpace(attr.Val))
+				case "href":
+					href = strings.TrimSpace(attr.Val)
+				case "title":
+					title = attr.Val
+				}
+			}
+
+			// The rel attribute can hold multiple space-separated values.
+			isAlternate := false
+			for _, value := range strings.Fields(rel) {
+				if value == "alternate" {
+					isAlternate = true
+				}
+			}
+
+			if !isAlternate || !feedLinkTypes[typ] || href == "" {
+				continue
+			}
+
+			ref, err := url.Parse(href)
+			if err != nil {
+				continue
+			}
+
+			feedURL := base.ResolveReference(ref).String()
+			if seen[feedURL] {
+				continue
+			}
+			seen[feedURL] = true
+
+			feeds = append(feeds, DiscoveredFeed{URL: feedURL, Title: title})
+		}
+	}
+}
+
+// Check if some content is a valid (parsable) feed.
+func isFeed(body string) (*gofeed.Feed, bool) {
+	fp := gofeed.NewParser()
+	feed, err := fp.ParseString(body)
+	if err != nil {
+		return nil, false
+	}
+
+	return feed, true
+}
+
+// Find the feed(s) for a URL. If the URL points directly to a feed, then that is the
+// only result. Otherwise, the URL is treated as a web page and searched for links
+// to feeds. If there aren't any, then some common feed paths are tried instead.
+func Discover(ctx context.Context, feedFetcher FeedFetcher, pageURL string) ([]DiscoveredFeed, error) {
+	resp, err := feedFetcher.FetchFeed(ctx, FetchFeedRequest{URL: pageURL})
+	if err != nil {
+		return nil, err
+	}
+
+	if feed, ok := isFeed(resp.Feed); ok {
+		return []DiscoveredFeed{{URL: pageURL, Title: feed.Title}}, nil
+	}
+
+	feeds := FindFeedLinks(pageURL, resp.Feed)
+	if len(feeds) > 0 {
+		return feeds, nil
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, ErrNoFeedsFound
+	}
+
+	for _, path := range CommonFeedPaths {
+		feedURL := base.ResolveReference(&url.URL{Path: path}).String()
+
+		resp, err := feedFetcher.FetchFeed(ctx, FetchFeedRequest{URL: feedURL})
+		if err != nil {
+			// Stop looking altogether if the discovery was cancelled.
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			continue
+		}
+
+		feed, ok := isFeed(resp.Feed)
+		if !ok {
+			continue
+		}
+
+		feeds = append(feeds, DiscoveredFeed{URL: feedURL, Title: feed.Title})
+	}
+
+	if len(feeds) == 0 {
+		return nil, ErrNoFeedsFound
+	}
+
+	return feeds, nil
+}
@@ -0,0 +1,149 @@
+package feed_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	feedWeb "github.com/theandrew168/bloggulus/backend/feed/web"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+// Create a FeedFetcher that can reach the local test servers (which are on loopback).
+func newLocalFeedFetcher() *feedWeb.FeedFetcher {
+	return feedWeb.NewFeedFetcherWithConfig(feedWeb.FeedFetcherConfig{
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+}
+
+// Start a test server that responds to each path with the given content (and 404s otherwise).
+func newSiteServer(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(page))
+	}))
+	return server
+}
+
+func newAtomFeed(t *testing.T, title string) string {
+	t.Helper()
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feed.Blog{
+		Title:   title,
+		SiteURL: "https://example.com",
+		FeedURL: "https://example.com/atom.xml",
+	})
+	test.AssertNilError(t, err)
+
+	return atomFeed
+}
+
+func TestFindFeedLinks(t *testing.T) {
+	t.Parallel()
+
+	page := `
+		<html>
+		<head>
+			<link rel="stylesheet" href="/style.css">
+			<link rel="alternate" type="application/rss+xml" title="RSS" href="/rss.xml">
+			<link rel="alternate" type="application/atom+xml" title="Atom" href="https://example.com/atom.xml" />
+			<link rel="alternate" type="application/rss+xml" href="/rss.xml">
+			<link rel="alternate" hreflang="fr" href="/fr/">
+		</head>
+		<body></body>
+		</html>`
+
+	feeds := feed.FindFeedLinks("https://example.com/blog/", page)
+	test.AssertEqual(t, feeds, []feed.DiscoveredFeed{
+		{URL: "https://example.com/rss.xml", Title: "RSS"},
+		{URL: "https://example.com/atom.xml", Title: "Atom"},
+	})
+}
+
+func TestDiscoverFeedURL(t *testing.T) {
+	t.Parallel()
+
+	server := newSiteServer(t, map[string]string{
+		"/atom.xml": newAtomFeed(t, "Foo"),
+	})
+	defer server.Close()
+
+	// A URL that already points to a feed is returned as-is.
+	feeds, err := feed.Discover(context.Background(), newLocalFeedFetcher(), server.URL+"/atom.xml")
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, feeds, []feed.DiscoveredFeed{
+		{URL: server.URL + "/atom.xml", Title: "Foo"},
+	})
+}
+
+func TestDiscoverFeedLinks(t *testing.T) {
+	t.Parallel()
+
+	server := newSiteServer(t, map[string]string{
+		"/": `
+			<html><head>
+			<link rel="alternate" type="application/rss+xml" title="Posts" href="/posts.xml">
+			<link rel="alternate" type="application/atom+xml" title="Comments" href="/comments.xml">
+			</head></html>`,
+	})
+	defer server.Close()
+
+	// Every linked feed should be found (so that the user can choose).
+	feeds, err := feed.Discover(context.Background(), newLocalFeedFetcher(), server.URL+"/")
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, feeds, []feed.DiscoveredFeed{
+		{URL: server.URL + "/posts.xml", Title: "Posts"},
+		{URL: server.URL + "/comments.xml", Title: "Comments"},
+	})
+}
+
+func TestDiscoverCommonPaths(t *testing.T) {
+	t.Parallel()
+
+	server := newSiteServer(t, map[string]string{
+		"/blog/":    `<html><head><title>No feed links here</title></head></html>`,
+		"/feed":     `<html><body>not actually a feed</body></html>`,
+		"/atom.xml": newAtomFeed(t, "Foo"),
+	})
+	defer server.Close()
+
+	// Common paths are checked relative to the site's root (and must be valid feeds).
+	feeds, err := feed.Discover(context.Background(), newLocalFeedFetcher(), server.URL+"/blog/")
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, feeds, []feed.DiscoveredFeed{
+		{URL: server.URL + "/atom.xml", Title: "Foo"},
+	})
+}
+
+func TestDiscoverNoFeeds(t *testing.T) {
+	t.Parallel()
+
+	server := newSiteServer(t, map[string]string{
+		"/": `<html><head><title>Nothing to see here</title></head></html>`,
+	})
+	defer server.Close()
+
+	_, err := feed.Discover(context.Background(), newLocalFeedFetcher(), server.URL+"/")
+	test.AssertErrorIs(t, err, feed.ErrNoFeedsFound)
+}
+
+func TestDiscoverUnreachable(t *testing.T) {
+	t.Parallel()
+
+	server := newSiteServer(t, nil)
+	defer server.Close()
+
+	_, err := feed.Discover(context.Background(), newLocalFeedFetcher(), server.URL+"/")
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+}
@@ -1,23 +1,157 @@
 package feed
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
 var (
 	ErrUnreachableFeed = errors.New("feed: unreachable feed")
+	ErrInvalidFeed     = errors.New("feed: invalid feed")
+
+	// A more specific ErrUnreachableFeed for when the feed's server is too slow.
+	ErrTimeout = fmt.Errorf("%w (timed out)", ErrUnreachableFeed)
+
+	// A more specific ErrUnreachableFeed for when the feed has been removed for good (410 Gone).
+	ErrGoneFeed = fmt.Errorf("%w (gone)", ErrUnreachableFeed)
+
+	// A more specific ErrUnreachableFeed for when the feed's server isn't on the public internet.
+	ErrForbiddenAddress = fmt.Errorf("%w (forbidden address)", ErrUnreachableFeed)
+
+	// A more specific ErrInvalidFeed for when the feed is too big to bother with.
+	ErrTooLarge = fmt.Errorf("%w (too large)", ErrInvalidFeed)
 )
 
+// Returned when a feed's server responds with an error status code. Wraps ErrUnreachableFeed.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s (status code %d)", ErrUnreachableFeed, e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	return ErrUnreachableFeed
+}
+
+// Returned when a feed's server asks to be left alone for a while (usually via a 429
+// or 503 response that includes a Retry-After header). Wraps ErrUnreachableFeed.
+type RetryAfterError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrUnreachableFeed, e.RetryAfter)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return ErrUnreachableFeed
+}
+
 type FetchFeedRequest struct {
 	URL          string
 	ETag         string
 	LastModified string
 }
 
+// A single hop in the chain of redirects followed while fetching a feed.
+type Redirect struct {
+	StatusCode int
+	// Where the redirect pointed to (resolved against the URL that was redirected).
+	URL string
+}
+
 type FetchFeedResponse struct {
 	Feed         string
 	ETag         string
 	LastModified string
+	StatusCode   int
+
+	// How long the response can be cached (from Cache-Control, zero if not given).
+	MaxAge time.Duration
+
+	// The redirects (if any) that were followed to get to the feed, in order.
+	Redirects []Redirect
 }
 
+// Fetching a feed should stop early (returning the context's error) if ctx is done.
 type FeedFetcher interface {
-	FetchFeed(request FetchFeedRequest) (FetchFeedResponse, error)
+	FetchFeed(ctx context.Context, request FetchFeedRequest) (FetchFeedResponse, error)
+}
+
+// Determine where a feed has permanently moved to based on the redirects followed while
+// fetching it (empty if it hasn't moved). Only an unbroken chain of permanent redirects
+// (301 or 308) counts: anything after a temporary redirect could change at any time.
+func PermanentRedirectURL(redirects []Redirect) string {
+	var url string
+	for _, redirect := range redirects {
+		if redirect.StatusCode != http.StatusMovedPermanently && redirect.StatusCode != http.StatusPermanentRedirect {
+			break
+		}
+
+		url = redirect.URL
+	}
+
+	return url
+}
+
+// Determine how long a response can be cached from its Cache-Control header. Shared
+// cache directives (s-maxage) take priority and responses that shouldn't be cached
+// (no-cache, no-store) return zero.
+func ParseCacheControl(header string) time.Duration {
+	var maxAge, sharedMaxAge time.Duration
+	for _, directive := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		name = strings.ToLower(name)
+
+		switch name {
+		case "no-cache", "no-store":
+			return 0
+		case "max-age", "s-maxage":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil || seconds < 0 {
+				continue
+			}
+
+			if name == "max-age" {
+				maxAge = time.Duration(seconds) * time.Second
+			} else {
+				sharedMaxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if sharedMaxAge > 0 {
+		return sharedMaxAge
+	}
+
+	return maxAge
+}
+
+// Determine how long to wait from a Retry-After header (either a number of seconds or
+// an HTTP date). Invalid values (and dates in the past) return zero.
+func ParseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(header)
+	if err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return 0
+	}
+
+	return max(date.Sub(now), 0)
 }
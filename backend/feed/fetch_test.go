@@ -0,0 +1,87 @@
+package feed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestPermanentRedirectURL(t *testing.T) {
+	t.Parallel()
+
+	permanent := feed.Redirect{StatusCode: 301, URL: "https://example.com/moved.xml"}
+	permanentAgain := feed.Redirect{StatusCode: 308, URL: "https://example.com/feed.xml"}
+	temporary := feed.Redirect{StatusCode: 302, URL: "https://example.com/temp.xml"}
+
+	tests := []struct {
+		redirects []feed.Redirect
+		want      string
+	}{
+		{redirects: nil, want: ""},
+		{redirects: []feed.Redirect{permanent}, want: permanent.URL},
+		{redirects: []feed.Redirect{permanent, permanentAgain}, want: permanentAgain.URL},
+		{redirects: []feed.Redirect{permanent, temporary}, want: permanent.URL},
+		{redirects: []feed.Redirect{temporary, permanent}, want: ""},
+	}
+	for _, tt := range tests {
+		got := feed.PermanentRedirectURL(tt.redirects)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestParseCacheControl(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{header: "", want: 0},
+		{header: "max-age=3600", want: time.Hour},
+		{header: "public, max-age=600", want: 10 * time.Minute},
+		{header: "max-age=600, s-maxage=1800", want: 30 * time.Minute},
+		{header: `max-age="120"`, want: 2 * time.Minute},
+		{header: "no-cache, max-age=600", want: 0},
+		{header: "no-store", want: 0},
+		{header: "max-age=forever", want: 0},
+	}
+	for _, tt := range tests {
+		got := feed.ParseCacheControl(tt.header)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{header: "", want: 0},
+		{header: "120", want: 2 * time.Minute},
+		{header: "-5", want: 0},
+		{header: "Mon, 01 Jan 2024 13:00:00 GMT", want: time.Hour},
+		{header: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0},
+		{header: "later", want: 0},
+	}
+	for _, tt := range tests {
+		got := feed.ParseRetryAfter(tt.header, now)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestRetryAfterError(t *testing.T) {
+	t.Parallel()
+
+	var err error = &feed.RetryAfterError{RetryAfter: time.Minute}
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+
+	var retryAfterErr *feed.RetryAfterError
+	test.AssertErrorAs(t, err, &retryAfterErr)
+	test.AssertEqual(t, retryAfterErr.RetryAfter, time.Minute)
+}
@@ -38,11 +38,18 @@ func GenerateAtomFeed(blog feed.Blog) (string, error) {
 		})
 	}
 
+	links := []xmlLink{
+		{HREF: blog.FeedURL, Rel: "self"},
+		{HREF: blog.SiteURL, Rel: "alternate"},
+	}
+
+	// Advertise a WebSub hub (if the blog has one).
+	if blog.HubURL != "" {
+		links = append(links, xmlLink{HREF: blog.HubURL, Rel: "hub"})
+	}
+
 	b := xmlBlog{
-		Links: []xmlLink{
-			{HREF: blog.FeedURL, Rel: "self"},
-			{HREF: blog.SiteURL, Rel: "alternate"},
-		},
+		Links: links,
 		Title: blog.Title,
 		Posts: posts,
 	}
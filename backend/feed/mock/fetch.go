@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/theandrew168/bloggulus/backend/feed"
 )
 
@@ -16,7 +18,11 @@ func NewFeedFetcher(feeds map[string]feed.FetchFeedResponse) *FeedFetcher {
 	return &f
 }
 
-func (f *FeedFetcher) FetchFeed(request feed.FetchFeedRequest) (feed.FetchFeedResponse, error) {
+func (f *FeedFetcher) FetchFeed(ctx context.Context, request feed.FetchFeedRequest) (feed.FetchFeedResponse, error) {
+	if ctx.Err() != nil {
+		return feed.FetchFeedResponse{}, ctx.Err()
+	}
+
 	feedForURL, ok := f.feeds[request.URL]
 	if !ok {
 		return feed.FetchFeedResponse{}, feed.ErrUnreachableFeed
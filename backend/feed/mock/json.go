@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+)
+
+const JSONFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonPost struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url,omitempty"`
+	Title         string       `json:"title,omitempty"`
+	ContentHTML   string       `json:"content_html,omitempty"`
+	DatePublished string       `json:"date_published,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+}
+
+type jsonBlog struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Posts       []jsonPost `json:"items"`
+}
+
+// Convert a feed.Blog into a JSON Feed (1.1) document.
+func GenerateJSONFeed(blog feed.Blog) (string, error) {
+	posts := []jsonPost{}
+	for _, post := range blog.Posts {
+		var authors []jsonAuthor
+		for _, author := range post.Authors {
+			authors = append(authors, jsonAuthor{Name: author})
+		}
+
+		posts = append(posts, jsonPost{
+			ID:            post.URL,
+			URL:           post.URL,
+			Title:         post.Title,
+			ContentHTML:   post.Content,
+			DatePublished: post.PublishedAt.Format(time.RFC3339),
+			Authors:       authors,
+			Tags:          post.Categories,
+		})
+	}
+
+	b := jsonBlog{
+		Version:     JSONFeedVersion,
+		Title:       blog.Title,
+		HomePageURL: blog.SiteURL,
+		FeedURL:     blog.FeedURL,
+		Posts:       posts,
+	}
+
+	out, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
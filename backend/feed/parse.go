@@ -1,11 +1,15 @@
 package feed
 
 import (
+	"encoding/xml"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
 
 	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
@@ -19,6 +23,13 @@ type Blog struct {
 	SiteURL string
 	Title   string
 	Posts   []Post
+
+	// WebSub details (only present if the feed advertises a hub).
+	HubURL  string
+	SelfURL string
+
+	// How often the feed claims to be updated (zero if it doesn't say).
+	UpdateInterval time.Duration
 }
 
 type Post struct {
@@ -26,6 +37,21 @@ type Post struct {
 	Title       string
 	Content     string
 	PublishedAt time.Time
+
+	// Extra metadata (only present if the feed includes it). JSON Feed
+	// tags are treated as categories.
+	Authors    []string
+	Summary    string
+	Categories []string
+	ImageURL   string
+	Enclosures []Enclosure
+}
+
+// Media attached to a post (like a podcast episode's audio file).
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
 }
 
 // Normalize post URLs, ensuring they are full URLs with valid schemes.
@@ -57,6 +83,11 @@ func DeterminePublishedAt(feed *gofeed.Feed, item *gofeed.Item, now time.Time) t
 		return timeutil.Normalize(*item.PublishedParsed)
 	}
 
+	// Next, if the item has an updated date, use it since it is still item-specific.
+	if item.UpdatedParsed != nil {
+		return timeutil.Normalize(*item.UpdatedParsed)
+	}
+
 	// Otherwise, if the feed itself has an updated date, use it instead.
 	if feed.UpdatedParsed != nil {
 		return timeutil.Normalize(*feed.UpdatedParsed)
@@ -66,11 +97,222 @@ func DeterminePublishedAt(feed *gofeed.Feed, item *gofeed.Item, now time.Time) t
 	return timeutil.Normalize(now)
 }
 
+// Find the WebSub hub advertised by a feed (via a <link rel="hub"> element), if any.
+// Both Atom feeds and RSS feeds (using atom:link) are supported. Only feed-level
+// links are considered so the search stops at the first entry / item.
+func DiscoverHubURL(feedBody string) string {
+	d := xml.NewDecoder(strings.NewReader(feedBody))
+	d.Strict = false
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return ""
+		}
+
+		elem, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch elem.Name.Local {
+		case "entry", "item":
+			return ""
+		case "link":
+			var rel, href string
+			for _, attr := range elem.Attr {
+				switch attr.Name.Local {
+				case "rel":
+					rel = attr.Value
+				case "href":
+					href = attr.Value
+				}
+			}
+
+			if rel == "hub" && href != "" {
+				return href
+			}
+		}
+	}
+}
+
+// How long each syndication update period (sy:updatePeriod) lasts.
+var updatePeriods = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+	"yearly":  365 * 24 * time.Hour,
+}
+
+// Find how often a feed claims to be updated, if at all. RSS feeds can include a <ttl>
+// (in minutes) and any feed can use the syndication module's sy:updatePeriod and
+// sy:updateFrequency (which default to "daily" and 1). If a feed includes both, the longer
+// interval wins. Like DiscoverHubURL, the search stops at the first entry / item.
+func DiscoverUpdateInterval(feedBody string) time.Duration {
+	d := xml.NewDecoder(strings.NewReader(feedBody))
+	d.Strict = false
+
+	var ttl time.Duration
+	var period string
+	var frequency int
+
+	// Read the (trimmed) text content of the current element.
+	readText := func(elem xml.StartElement) string {
+		var text string
+		err := d.DecodeElement(&text, &elem)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(text)
+	}
+
+loop:
+	for {
+		token, err := d.Token()
+		if err != nil {
+			break
+		}
+
+		elem, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch elem.Name.Local {
+		case "entry", "item":
+			break loop
+		case "ttl":
+			minutes, err := strconv.Atoi(readText(elem))
+			if err == nil && minutes > 0 {
+				ttl = time.Duration(minutes) * time.Minute
+			}
+		case "updatePeriod":
+			period = strings.ToLower(readText(elem))
+		case "updateFrequency":
+			n, err := strconv.Atoi(readText(elem))
+			if err == nil && n > 0 {
+				frequency = n
+			}
+		}
+	}
+
+	var syndication time.Duration
+	if period != "" || frequency > 0 {
+		if period == "" {
+			period = "daily"
+		}
+		if frequency == 0 {
+			frequency = 1
+		}
+		syndication = updatePeriods[period] / time.Duration(frequency)
+	}
+
+	return max(ttl, syndication)
+}
+
+// Collect the names of an item's authors (skipping any that are blank).
+func DetermineAuthors(item *gofeed.Item) []string {
+	var authors []string
+	for _, author := range item.Authors {
+		if author == nil || author.Name == "" {
+			continue
+		}
+
+		authors = append(authors, author.Name)
+	}
+
+	return authors
+}
+
+// Limit post summaries to a reasonable length (in characters).
+const MaxSummaryLength = 500
+
+// HTML elements that separate the text within them from their surroundings.
+var summaryBlockTags = map[string]bool{
+	"p":          true,
+	"br":         true,
+	"div":        true,
+	"li":         true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"blockquote": true,
+	"pre":        true,
+}
+
+// Convert an item's description into a plain text summary: HTML is stripped, whitespace
+// is collapsed, and overly long descriptions are truncated.
+func DetermineSummary(item *gofeed.Item) string {
+	var text strings.Builder
+
+	z := html.NewTokenizer(strings.NewReader(item.Description))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		switch tt {
+		case html.TextToken:
+			text.Write(z.Text())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			// Keep words in separate blocks (paragraphs, line breaks, etc) apart.
+			name, _ := z.TagName()
+			if summaryBlockTags[string(name)] {
+				text.WriteString(" ")
+			}
+		}
+	}
+
+	summary := strings.Join(strings.Fields(text.String()), " ")
+
+	runes := []rune(summary)
+	if len(runes) > MaxSummaryLength {
+		summary = strings.TrimSpace(string(runes[:MaxSummaryLength])) + "…"
+	}
+
+	return summary
+}
+
+// Determine an item's image (if it has one).
+func DetermineImageURL(item *gofeed.Item) string {
+	if item.Image == nil {
+		return ""
+	}
+
+	return item.Image.URL
+}
+
+// Collect an item's enclosures (skipping any without a URL). Invalid lengths are ignored.
+func DetermineEnclosures(item *gofeed.Item) []Enclosure {
+	var enclosures []Enclosure
+	for _, enclosure := range item.Enclosures {
+		if enclosure == nil || enclosure.URL == "" {
+			continue
+		}
+
+		length, _ := strconv.ParseInt(enclosure.Length, 10, 64)
+		enclosures = append(enclosures, Enclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: length,
+		})
+	}
+
+	return enclosures
+}
+
+// Parse an Atom, RSS, or JSON Feed document. For JSON Feeds, content_html is preferred
+// over content_text and date_published is preferred over date_modified.
 func Parse(feedURL string, feedBody string) (Blog, error) {
 	fp := gofeed.NewParser()
 	feed, err := fp.ParseString(feedBody)
 	if err != nil {
-		return Blog{}, err
+		return Blog{}, fmt.Errorf("%w: %w", ErrInvalidFeed, err)
 	}
 
 	var posts []Post
@@ -88,6 +330,12 @@ func Parse(feedURL string, feedBody string) (Blog, error) {
 			Title:       item.Title,
 			Content:     item.Content,
 			PublishedAt: publishedAt,
+
+			Authors:    DetermineAuthors(item),
+			Summary:    DetermineSummary(item),
+			Categories: item.Categories,
+			ImageURL:   DetermineImageURL(item),
+			Enclosures: DetermineEnclosures(item),
 		}
 		posts = append(posts, post)
 	}
@@ -97,6 +345,11 @@ func Parse(feedURL string, feedBody string) (Blog, error) {
 		SiteURL: feed.Link,
 		Title:   feed.Title,
 		Posts:   posts,
+
+		HubURL:  DiscoverHubURL(feedBody),
+		SelfURL: feed.FeedLink,
+
+		UpdateInterval: DiscoverUpdateInterval(feedBody),
 	}
 	return blog, nil
 }
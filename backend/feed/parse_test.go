@@ -1,6 +1,7 @@
 package feed_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -38,11 +39,13 @@ func TestDeterminePublishedAt(t *testing.T) {
 
 	feedUpdatedParsed := time.Now().AddDate(0, 0, -1)
 	itemPublishedParsed := time.Now().AddDate(0, 0, -2)
+	itemUpdatedParsed := time.Now().AddDate(0, 0, -3)
 	now := time.Now()
 
 	tests := []struct {
 		feedUpdatedParsed   *time.Time
 		itemPublishedParsed *time.Time
+		itemUpdatedParsed   *time.Time
 		now                 time.Time
 		want                time.Time
 	}{
@@ -74,12 +77,26 @@ func TestDeterminePublishedAt(t *testing.T) {
 			now:                 now,
 			want:                timeutil.Normalize(itemPublishedParsed),
 		},
+		{
+			// If the item only has an updated date, use it even if the feed has an updated date.
+			feedUpdatedParsed: &feedUpdatedParsed,
+			itemUpdatedParsed: &itemUpdatedParsed,
+			now:               now,
+			want:              timeutil.Normalize(itemUpdatedParsed),
+		},
+		{
+			// If item has a published date, use it even if the item has an updated date.
+			itemPublishedParsed: &itemPublishedParsed,
+			itemUpdatedParsed:   &itemUpdatedParsed,
+			now:                 now,
+			want:                timeutil.Normalize(itemPublishedParsed),
+		},
 	}
 
 	for _, tt := range tests {
 		got := feed.DeterminePublishedAt(
 			&gofeed.Feed{UpdatedParsed: tt.feedUpdatedParsed},
-			&gofeed.Item{PublishedParsed: tt.itemPublishedParsed},
+			&gofeed.Item{PublishedParsed: tt.itemPublishedParsed, UpdatedParsed: tt.itemUpdatedParsed},
 			tt.now,
 		)
 		test.AssertEqual(t, got, tt.want)
@@ -267,6 +284,346 @@ func TestParsePublishedAtUTC(t *testing.T) {
 	}
 }
 
+func TestParseAtomEntryUpdatedOnly(t *testing.T) {
+	t.Parallel()
+
+	// Atom entries must have an updated date but published is optional.
+	atomFeed := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>FooBar</title>
+	<link href="https://example.com"/>
+	<id>https://example.com/atom.xml</id>
+	<updated>2006-01-05T15:04:05Z</updated>
+	<entry>
+		<title>Foo</title>
+		<link href="https://example.com/foo"/>
+		<id>https://example.com/foo</id>
+		<updated>2006-01-02T15:04:05Z</updated>
+		<content>content about foo</content>
+	</entry>
+</feed>`
+
+	updatedAt, err := time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+	test.AssertNilError(t, err)
+
+	parsedBlog, err := feed.Parse("https://example.com/atom.xml", atomFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsedBlog.Posts), 1)
+
+	// The entry's own updated date is used instead of the feed's.
+	test.AssertEqual(t, parsedBlog.Posts[0].PublishedAt, timeutil.Normalize(updatedAt))
+}
+
+func TestParseRSSItemWithoutDate(t *testing.T) {
+	t.Parallel()
+
+	// RSS items only have an updated date (dc:date) when they also have a published one.
+	rssFeed := `<?xml version="1.0" encoding="utf-8"?>
+<rss version="2.0">
+	<channel>
+		<title>FooBar</title>
+		<link>https://example.com</link>
+		<lastBuildDate>Thu, 05 Jan 2006 15:04:05 +0000</lastBuildDate>
+		<item>
+			<title>Foo</title>
+			<link>https://example.com/foo</link>
+			<description>content about foo</description>
+		</item>
+	</channel>
+</rss>`
+
+	lastBuildDate, err := time.Parse(time.RFC1123Z, "Thu, 05 Jan 2006 15:04:05 +0000")
+	test.AssertNilError(t, err)
+
+	parsedBlog, err := feed.Parse("https://example.com/rss.xml", rssFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsedBlog.Posts), 1)
+
+	// So an undated item still falls back to the feed's updated date.
+	test.AssertEqual(t, parsedBlog.Posts[0].PublishedAt, timeutil.Normalize(lastBuildDate))
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := feed.Parse("https://example.com/atom.xml", "<html><body>not a feed</body></html>")
+	test.AssertErrorIs(t, err, feed.ErrInvalidFeed)
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Parallel()
+
+	rssFeed := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:media="http://search.yahoo.com/mrss/">
+		<channel>
+			<title>FooBar</title>
+			<link>https://example.com</link>
+			<item>
+				<title>Foo</title>
+				<link>https://example.com/foo</link>
+				<description><![CDATA[<p>A post   about <b>foo</b>.</p><p></p>]]></description>
+				<dc:creator>Alice</dc:creator>
+				<category>go</category>
+				<category>web</category>
+				<media:content url="https://example.com/foo.png" medium="image" />
+				<enclosure url="https://example.com/foo.mp3" type="audio/mpeg" length="12345" />
+			</item>
+		</channel>
+		</rss>`
+
+	parsedBlog, err := feed.Parse("https://example.com/rss.xml", rssFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsedBlog.Posts), 1)
+
+	post := parsedBlog.Posts[0]
+	test.AssertEqual(t, post.Authors, []string{"Alice"})
+	test.AssertEqual(t, post.Summary, "A post about foo.")
+	test.AssertEqual(t, post.Categories, []string{"go", "web"})
+	test.AssertEqual(t, post.ImageURL, "https://example.com/foo.png")
+	test.AssertEqual(t, post.Enclosures, []feed.Enclosure{
+		{URL: "https://example.com/foo.mp3", Type: "audio/mpeg", Length: 12345},
+	})
+}
+
+func TestParseSummaryTruncated(t *testing.T) {
+	t.Parallel()
+
+	item := gofeed.Item{
+		Description: strings.Repeat("a", feed.MaxSummaryLength+100),
+	}
+
+	summary := feed.DetermineSummary(&item)
+	test.AssertEqual(t, summary, strings.Repeat("a", feed.MaxSummaryLength)+"…")
+}
+
+func TestParseJSONFeed(t *testing.T) {
+	t.Parallel()
+
+	publishedAt, err := time.Parse(time.RFC3339, "2006-01-02T15:04:05+07:00")
+	test.AssertNilError(t, err)
+
+	feedPostFoo := feed.Post{
+		URL:         "https://example.com/foo",
+		Title:       "Foo",
+		Content:     "<p>content about foo</p>",
+		PublishedAt: publishedAt,
+		Authors:     []string{"Alice", "Bob"},
+		Categories:  []string{"go", "web"},
+	}
+	feedPostBar := feed.Post{
+		URL:         "/bar",
+		Title:       "Bar",
+		Content:     "<p>content about bar</p>",
+		PublishedAt: publishedAt,
+	}
+	feedBlog := feed.Blog{
+		Title:   "FooBar",
+		SiteURL: "https://example.com",
+		FeedURL: "https://example.com/feed.json",
+		Posts:   []feed.Post{feedPostFoo, feedPostBar},
+	}
+
+	jsonFeed, err := feedMock.GenerateJSONFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	parsedBlog, err := feed.Parse("https://example.com/feed.json", jsonFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, parsedBlog.Title, feedBlog.Title)
+	test.AssertEqual(t, parsedBlog.SiteURL, feedBlog.SiteURL)
+	test.AssertEqual(t, parsedBlog.FeedURL, feedBlog.FeedURL)
+	test.AssertEqual(t, parsedBlog.SelfURL, feedBlog.FeedURL)
+	test.AssertEqual(t, len(parsedBlog.Posts), 2)
+
+	foo := parsedBlog.Posts[0]
+	test.AssertEqual(t, foo.URL, feedPostFoo.URL)
+	test.AssertEqual(t, foo.Title, feedPostFoo.Title)
+	test.AssertEqual(t, foo.Content, feedPostFoo.Content)
+	test.AssertEqual(t, foo.PublishedAt, timeutil.Normalize(publishedAt))
+	test.AssertEqual(t, foo.Authors, feedPostFoo.Authors)
+	test.AssertEqual(t, foo.Categories, feedPostFoo.Categories)
+
+	// Relative post URLs should be normalized just like other feed formats.
+	bar := parsedBlog.Posts[1]
+	test.AssertEqual(t, bar.URL, "https://example.com/bar")
+	test.AssertEqual(t, len(bar.Authors), 0)
+	test.AssertEqual(t, len(bar.Categories), 0)
+}
+
+func TestParseJSONFeedFallbacks(t *testing.T) {
+	t.Parallel()
+
+	// A JSON Feed 1.0 document (singular author, text-only content, and only a modified date).
+	jsonFeed := `{
+		"version": "https://jsonfeed.org/version/1",
+		"title": "FooBar",
+		"home_page_url": "https://example.com",
+		"feed_url": "https://example.com/feed.json",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.com/foo",
+				"title": "Foo",
+				"content_text": "content about foo",
+				"date_modified": "2006-01-02T15:04:05+07:00",
+				"author": {"name": "Alice"}
+			}
+		]
+	}`
+
+	modifiedAt, err := time.Parse(time.RFC3339, "2006-01-02T15:04:05+07:00")
+	test.AssertNilError(t, err)
+
+	parsedBlog, err := feed.Parse("https://example.com/feed.json", jsonFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsedBlog.Posts), 1)
+
+	post := parsedBlog.Posts[0]
+	test.AssertEqual(t, post.URL, "https://example.com/foo")
+	test.AssertEqual(t, post.Content, "content about foo")
+	test.AssertEqual(t, post.PublishedAt, timeutil.Normalize(modifiedAt))
+	test.AssertEqual(t, post.Authors, []string{"Alice"})
+}
+
+func TestParseJSONFeedContentHTML(t *testing.T) {
+	t.Parallel()
+
+	// If both are present, content_html is preferred over content_text.
+	jsonFeed := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "FooBar",
+		"home_page_url": "https://example.com",
+		"items": [
+			{
+				"id": "1",
+				"url": "https://example.com/foo",
+				"title": "Foo",
+				"content_html": "<p>html about foo</p>",
+				"content_text": "text about foo",
+				"date_published": "2006-01-02T15:04:05Z",
+				"date_modified": "2007-01-02T15:04:05Z"
+			}
+		]
+	}`
+
+	publishedAt, err := time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+	test.AssertNilError(t, err)
+
+	parsedBlog, err := feed.Parse("https://example.com/feed.json", jsonFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsedBlog.Posts), 1)
+
+	post := parsedBlog.Posts[0]
+	test.AssertEqual(t, post.Content, "<p>html about foo</p>")
+	test.AssertEqual(t, post.PublishedAt, timeutil.Normalize(publishedAt))
+}
+
+func TestParseHub(t *testing.T) {
+	t.Parallel()
+
+	feedBlog := feed.Blog{
+		Title:   "FooBar",
+		SiteURL: "https://example.com",
+		FeedURL: "https://example.com/atom.xml",
+		HubURL:  "https://hub.example.com",
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	parsedBlog, err := feed.Parse("https://example.com/atom.xml", atomFeed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, parsedBlog.HubURL, feedBlog.HubURL)
+	test.AssertEqual(t, parsedBlog.SelfURL, feedBlog.FeedURL)
+}
+
+func TestDiscoverHubURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		feed string
+		want string
+	}{
+		{
+			// Atom feeds use plain link elements.
+			feed: `<feed xmlns="http://www.w3.org/2005/Atom"><link rel="hub" href="https://hub.example.com"/></feed>`,
+			want: "https://hub.example.com",
+		},
+		{
+			// RSS feeds use atom:link elements.
+			feed: `<rss xmlns:atom="http://www.w3.org/2005/Atom"><channel><link>https://example.com</link><atom:link rel="hub" href="https://hub.example.com"/></channel></rss>`,
+			want: "https://hub.example.com",
+		},
+		{
+			// Feeds without a hub link have no hub.
+			feed: `<feed xmlns="http://www.w3.org/2005/Atom"><link rel="self" href="https://example.com/atom.xml"/></feed>`,
+			want: "",
+		},
+		{
+			// Links within entries don't count.
+			feed: `<feed xmlns="http://www.w3.org/2005/Atom"><entry><link rel="hub" href="https://hub.example.com"/></entry></feed>`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		got := feed.DiscoverHubURL(tt.feed)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestDiscoverUpdateInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		feed string
+		want time.Duration
+	}{
+		{
+			// RSS feeds can include a TTL (in minutes).
+			feed: `<rss><channel><ttl>90</ttl></channel></rss>`,
+			want: 90 * time.Minute,
+		},
+		{
+			// The syndication module splits an update period into a number of updates.
+			feed: `<rss xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"><channel><sy:updatePeriod>daily</sy:updatePeriod><sy:updateFrequency>4</sy:updateFrequency></channel></rss>`,
+			want: 6 * time.Hour,
+		},
+		{
+			// The update frequency defaults to 1.
+			feed: `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"><sy:updatePeriod>weekly</sy:updatePeriod></feed>`,
+			want: 7 * 24 * time.Hour,
+		},
+		{
+			// The update period defaults to daily.
+			feed: `<rss xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"><channel><sy:updateFrequency>2</sy:updateFrequency></channel></rss>`,
+			want: 12 * time.Hour,
+		},
+		{
+			// When both are present, the longer interval wins.
+			feed: `<rss xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"><channel><ttl>60</ttl><sy:updatePeriod>hourly</sy:updatePeriod><sy:updateFrequency>2</sy:updateFrequency></channel></rss>`,
+			want: 60 * time.Minute,
+		},
+		{
+			// Invalid values are ignored.
+			feed: `<rss><channel><ttl>soon</ttl></channel></rss>`,
+			want: 0,
+		},
+		{
+			// Feeds without any hints have no update interval.
+			feed: `<feed xmlns="http://www.w3.org/2005/Atom"><title>Example</title></feed>`,
+			want: 0,
+		},
+		{
+			// Values within items don't count.
+			feed: `<rss><channel><item><ttl>60</ttl></item></channel></rss>`,
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		got := feed.DiscoverUpdateInterval(tt.feed)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	feedPostFoo := feed.Post{
 		URL:         "https://example.com/foo",
@@ -0,0 +1,94 @@
+package web
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/netutil"
+)
+
+const (
+	// Give up on servers that take too long to connect to (including the TLS handshake).
+	ConnectTimeout = netutil.ConnectTimeout
+
+	// Don't let a slow feed hold up the rest of the sync (this covers the whole request,
+	// reading the body included).
+	FetchTimeout = 30 * time.Second
+
+	// Same limit as the default http.Client (to avoid redirect loops).
+	MaxRedirects = 10
+
+	// Ignore anything larger than this (after decompression). No reasonable feed is this big.
+	MaxFeedSize = 10 * 1024 * 1024
+)
+
+// Limits that apply to each feed fetch. Fields left as zero use the defaults above.
+type FeedFetcherConfig struct {
+	ConnectTimeout time.Duration
+	FetchTimeout   time.Duration
+	MaxFeedSize    int64
+
+	// Networks that would otherwise be refused but are fetched from anyway (like a
+	// local server during testing). Public addresses are always allowed.
+	AllowedNetworks []netip.Prefix
+}
+
+func (c FeedFetcherConfig) withDefaults() FeedFetcherConfig {
+	if c.ConnectTimeout == 0 {
+		c.ConnectTimeout = ConnectTimeout
+	}
+	if c.FetchTimeout == 0 {
+		c.FetchTimeout = FetchTimeout
+	}
+	if c.MaxFeedSize == 0 {
+		c.MaxFeedSize = MaxFeedSize
+	}
+	return c
+}
+
+// Build an http.Client (that refuses non-public addresses) which enforces the given limits.
+func newClient(config FeedFetcherConfig) *http.Client {
+	return netutil.NewClient(netutil.ClientConfig{
+		ConnectTimeout:  config.ConnectTimeout,
+		Timeout:         config.FetchTimeout,
+		AllowedNetworks: config.AllowedNetworks,
+	})
+}
+
+// Wrap a response's body so that it gets decompressed (based on its Content-Encoding).
+// Since the Accept-Encoding header is set explicitly, the http.Transport leaves this to us.
+func decodeBody(resp *http.Response) (io.Reader, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "", "identity":
+		return resp.Body, nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(resp.Body)
+	case "br":
+		return brotli.NewReader(resp.Body), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+}
+
+// Read an entire (decoded) body but stop as soon as it grows past maxSize.
+func readBody(body io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, feed.ErrTooLarge
+	}
+
+	return data, nil
+}
@@ -0,0 +1,238 @@
+package web_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	feedWeb "github.com/theandrew168/bloggulus/backend/feed/web"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestFetchFeedForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	// The default fetcher refuses to connect to local servers.
+	fetcher := feedWeb.NewFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrForbiddenAddress)
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+}
+
+func TestFetchFeedForbiddenAddressAfterResolution(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	// Hostnames are checked based on the addresses that they resolve to.
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	fetcher := feedWeb.NewFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: url})
+	test.AssertErrorIs(t, err, feed.ErrForbiddenAddress)
+}
+
+func TestFetchFeedForbiddenAddressAfterRedirect(t *testing.T) {
+	t.Parallel()
+
+	// A (reachable) server that redirects to a cloud metadata service.
+	server := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/latest/meta-data/", http.StatusFound))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrForbiddenAddress)
+}
+
+func TestFetchFeedConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	// A server that accepts connections but never completes the TLS handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNilError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	fetcher := feedWeb.NewFeedFetcherWithConfig(feedWeb.FeedFetcherConfig{
+		ConnectTimeout:  100 * time.Millisecond,
+		FetchTimeout:    10 * time.Second,
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+
+	start := time.Now()
+	_, err = fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: "https://" + listener.Addr().String()})
+	test.AssertErrorIs(t, err, feed.ErrTimeout)
+
+	// The connect timeout should kick in well before the overall timeout.
+	test.AssertEqual(t, time.Since(start) < 5*time.Second, true)
+}
+
+func TestFetchFeedTimeout(t *testing.T) {
+	t.Parallel()
+
+	// A server that starts responding but never finishes.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	fetcher := feedWeb.NewFeedFetcherWithConfig(feedWeb.FeedFetcherConfig{
+		FetchTimeout:    100 * time.Millisecond,
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrTimeout)
+}
+
+func TestFetchFeedContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	// A server that never responds.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(ctx, feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, context.Canceled)
+
+	// A cancelled fetch says nothing about the feed itself.
+	test.AssertEqual(t, errors.Is(err, feed.ErrUnreachableFeed), false)
+}
+
+func TestFetchFeedMaxFeedSize(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size := 1024
+		if r.URL.Path == "/large.xml" {
+			size = 1025
+		}
+		w.Write(bytes.Repeat([]byte("a"), size))
+	}))
+	defer server.Close()
+
+	fetcher := feedWeb.NewFeedFetcherWithConfig(feedWeb.FeedFetcherConfig{
+		MaxFeedSize:     1024,
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+
+	// Feeds right at the limit are fine.
+	resp, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL + "/small.xml"})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(resp.Feed), 1024)
+
+	// But anything bigger is not.
+	_, err = fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL + "/large.xml"})
+	test.AssertErrorIs(t, err, feed.ErrTooLarge)
+	test.AssertErrorIs(t, err, feed.ErrInvalidFeed)
+}
+
+func TestFetchFeedMaxFeedSizeCompressed(t *testing.T) {
+	t.Parallel()
+
+	// A small response that decompresses into a big one.
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(bytes.Repeat([]byte("a"), 1024*1024))
+	gz.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	fetcher := feedWeb.NewFeedFetcherWithConfig(feedWeb.FeedFetcherConfig{
+		MaxFeedSize:     64 * 1024,
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+
+	// The limit applies to the decompressed size.
+	test.AssertEqual(t, compressed.Len() < 64*1024, true)
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrTooLarge)
+}
+
+func TestFetchFeedDecoding(t *testing.T) {
+	t.Parallel()
+
+	content := "<rss>" + strings.Repeat("compress me ", 100) + "</rss>"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := strings.TrimPrefix(r.URL.Path, "/")
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), encoding) {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", encoding)
+		switch encoding {
+		case "gzip":
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte(content))
+			gz.Close()
+		case "br":
+			br := brotli.NewWriter(w)
+			br.Write([]byte(content))
+			br.Close()
+		}
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	for _, encoding := range []string{"gzip", "br"} {
+		resp, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL + "/" + encoding})
+		test.AssertNilError(t, err)
+		test.AssertEqual(t, resp.Feed, content)
+	}
+}
+
+func TestFetchFeedUnsupportedEncoding(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "compress")
+		w.Write([]byte("???"))
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrInvalidFeed)
+}
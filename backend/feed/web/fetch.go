@@ -1,30 +1,57 @@
 package web
 
 import (
-	"io"
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/netutil"
 )
 
 const UserAgent = "Bloggulus/0.5.2 (+https://bloggulus.com)"
 
+// Advertise support for all feed formats (preferring the feed-specific types). HTML
+// is accepted too since pages are fetched while discovering a site's feeds.
+const Accept = "application/atom+xml, application/rss+xml, application/feed+json, " +
+	"application/xml;q=0.9, text/xml;q=0.9, application/json;q=0.9, text/html;q=0.8, */*;q=0.5"
+
+// Compression schemes that feeds can be sent with (decoded by decodeBody).
+const AcceptEncoding = "br, gzip"
+
 // ensure FeedFetcher interface is satisfied
 var _ feed.FeedFetcher = (*FeedFetcher)(nil)
 
-type FeedFetcher struct{}
+type FeedFetcher struct {
+	client      *http.Client
+	maxFeedSize int64
+}
 
+// Create a FeedFetcher that uses the default limits.
 func NewFeedFetcher() *FeedFetcher {
-	f := FeedFetcher{}
+	return NewFeedFetcherWithConfig(FeedFetcherConfig{})
+}
+
+func NewFeedFetcherWithConfig(config FeedFetcherConfig) *FeedFetcher {
+	config = config.withDefaults()
+	f := FeedFetcher{
+		client:      newClient(config),
+		maxFeedSize: config.MaxFeedSize,
+	}
 	return &f
 }
 
-func (f *FeedFetcher) FetchFeed(request feed.FetchFeedRequest) (feed.FetchFeedResponse, error) {
-	req, err := http.NewRequest("GET", request.URL, nil)
+func (f *FeedFetcher) FetchFeed(ctx context.Context, request feed.FetchFeedRequest) (feed.FetchFeedResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", request.URL, nil)
 	if err != nil {
 		return feed.FetchFeedResponse{}, feed.ErrUnreachableFeed
 	}
 	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", Accept)
+	req.Header.Set("Accept-Encoding", AcceptEncoding)
 
 	if request.ETag != "" {
 		req.Header.Set("If-None-Match", request.ETag)
@@ -33,26 +60,86 @@ func (f *FeedFetcher) FetchFeed(request feed.FetchFeedRequest) (feed.FetchFeedRe
 		req.Header.Set("If-Modified-Since", request.LastModified)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	// Keep track of any redirects along the way (so that permanent moves can be detected).
+	var redirects []feed.Redirect
+	client := *f.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= MaxRedirects {
+			return fmt.Errorf("stopped after %d redirects", MaxRedirects)
+		}
+
+		redirects = append(redirects, feed.Redirect{
+			StatusCode: req.Response.StatusCode,
+			URL:        req.URL.String(),
+		})
+		return nil
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
-		return feed.FetchFeedResponse{}, feed.ErrUnreachableFeed
+		return feed.FetchFeedResponse{}, unreachableError(ctx, err)
 	}
 	defer resp.Body.Close()
 
+	// The feed has been removed for good.
+	if resp.StatusCode == http.StatusGone {
+		return feed.FetchFeedResponse{}, feed.ErrGoneFeed
+	}
+
+	// Servers that are overloaded (or rate limiting) may say when to try again.
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		retryAfter := feed.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if retryAfter > 0 {
+			return feed.FetchFeedResponse{}, &feed.RetryAfterError{StatusCode: resp.StatusCode, RetryAfter: retryAfter}
+		}
+	}
+
 	if resp.StatusCode >= 400 {
-		return feed.FetchFeedResponse{}, feed.ErrUnreachableFeed
+		return feed.FetchFeedResponse{}, &feed.StatusError{StatusCode: resp.StatusCode}
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	decodedBody, err := decodeBody(resp)
 	if err != nil {
-		return feed.FetchFeedResponse{}, feed.ErrUnreachableFeed
+		return feed.FetchFeedResponse{}, feed.ErrInvalidFeed
+	}
+
+	body, err := readBody(decodedBody, f.maxFeedSize)
+	if err != nil {
+		if errors.Is(err, feed.ErrTooLarge) {
+			return feed.FetchFeedResponse{}, err
+		}
+
+		return feed.FetchFeedResponse{}, unreachableError(ctx, err)
 	}
 
 	fetchFeedResponse := feed.FetchFeedResponse{
 		Feed:         string(body),
 		ETag:         resp.Header.Get("ETag"),
 		LastModified: resp.Header.Get("Last-Modified"),
+		StatusCode:   resp.StatusCode,
+		MaxAge:       feed.ParseCacheControl(resp.Header.Get("Cache-Control")),
+		Redirects:    redirects,
 	}
 
 	return fetchFeedResponse, nil
 }
+
+// Distinguish between feeds that time out (or are at forbidden addresses) and those that
+// are otherwise unreachable. If the context is done, its error is returned instead (the
+// fetch was cancelled, which says nothing about the feed itself).
+func unreachableError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if errors.Is(err, netutil.ErrForbiddenAddress) {
+		return feed.ErrForbiddenAddress
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return feed.ErrTimeout
+	}
+
+	return feed.ErrUnreachableFeed
+}
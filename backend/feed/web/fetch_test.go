@@ -0,0 +1,145 @@
+package web_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	feedWeb "github.com/theandrew168/bloggulus/backend/feed/web"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+// Create a FeedFetcher that can reach the local test servers (which are on loopback).
+func newLocalFeedFetcher() *feedWeb.FeedFetcher {
+	return feedWeb.NewFeedFetcherWithConfig(feedWeb.FeedFetcherConfig{
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+}
+
+func TestFetchFeedHeaders(t *testing.T) {
+	t.Parallel()
+
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Header().Set("ETag", "foo")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	resp, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, resp.Feed, "{}")
+	test.AssertEqual(t, resp.ETag, "foo")
+
+	// All supported feed formats (including JSON Feed) should be advertised.
+	accept := header.Get("Accept")
+	for _, mediaType := range []string{"application/atom+xml", "application/rss+xml", "application/feed+json"} {
+		test.AssertStringContains(t, accept, mediaType)
+	}
+	test.AssertEqual(t, header.Get("User-Agent"), feedWeb.UserAgent)
+}
+
+func TestFetchFeedUnreachable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+}
+
+func TestFetchFeedCacheControl(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	resp, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, resp.MaxAge, time.Hour)
+}
+
+func TestFetchFeedRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+
+	var retryAfterErr *feed.RetryAfterError
+	test.AssertErrorAs(t, err, &retryAfterErr)
+	test.AssertEqual(t, retryAfterErr.StatusCode, http.StatusTooManyRequests)
+	test.AssertEqual(t, retryAfterErr.RetryAfter, 2*time.Minute)
+}
+
+func TestFetchFeedStatusError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+
+	var statusErr *feed.StatusError
+	test.AssertErrorAs(t, err, &statusErr)
+	test.AssertEqual(t, statusErr.StatusCode, http.StatusNotFound)
+}
+
+func TestFetchFeedRedirects(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.Handle("/old.xml", http.RedirectHandler("/moved.xml", http.StatusMovedPermanently))
+	mux.Handle("/moved.xml", http.RedirectHandler("/temp.xml", http.StatusFound))
+	mux.HandleFunc("/temp.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	resp, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL + "/old.xml"})
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, resp.Feed, "{}")
+
+	test.AssertEqual(t, len(resp.Redirects), 2)
+	test.AssertEqual(t, resp.Redirects[0], feed.Redirect{StatusCode: http.StatusMovedPermanently, URL: server.URL + "/moved.xml"})
+	test.AssertEqual(t, resp.Redirects[1], feed.Redirect{StatusCode: http.StatusFound, URL: server.URL + "/temp.xml"})
+}
+
+func TestFetchFeedGone(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	fetcher := newLocalFeedFetcher()
+	_, err := fetcher.FetchFeed(context.Background(), feed.FetchFeedRequest{URL: server.URL})
+	test.AssertErrorIs(t, err, feed.ErrGoneFeed)
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+}
@@ -0,0 +1,158 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/readability"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+const (
+	// Check for posts that need their full content fetched every FullContentInterval.
+	FullContentInterval = 5 * time.Minute
+
+	// Fetch (at most) this many posts' full content per run.
+	FullContentBatchSize = 50
+
+	// Wait this long between page fetches (to be polite to the sites being fetched).
+	FullContentFetchDelay = 2 * time.Second
+
+	// Only fetch full content for posts published within this window.
+	FullContentWindow = 30 * 24 * time.Hour
+)
+
+// Downloads and extracts the full content of new posts from blogs whose feeds only
+// include a summary (or truncated content). This runs separately from syncing so that
+// slow or unreachable pages never hold up the discovery of new posts.
+type FullContentService struct {
+	mu          sync.Mutex
+	repo        *repository.Repository
+	pageFetcher readability.PageFetcher
+}
+
+func NewFullContentService(repo *repository.Repository, pageFetcher readability.PageFetcher) *FullContentService {
+	s := FullContentService{
+		repo:        repo,
+		pageFetcher: pageFetcher,
+	}
+	return &s
+}
+
+func (s *FullContentService) Run(ctx context.Context) error {
+	// Fetch any pending full content at service startup.
+	err := s.FetchFullContent(ctx)
+	if err != nil {
+		slog.Error("error fetching full content",
+			"error", err.Error(),
+		)
+	}
+
+	// Then run again every "interval" until stopped (by the context being canceled).
+	ticker := time.NewTicker(FullContentInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping full content service")
+			slog.Info("stopped full content service")
+			return nil
+		case <-ticker.C:
+			err := s.FetchFullContent(ctx)
+			if err != nil {
+				slog.Error("error fetching full content",
+					"error", err.Error(),
+				)
+			}
+		}
+	}
+}
+
+// Fetch the full content of recent posts that don't have it yet. Pages are fetched
+// one at a time (with a delay in between) since many posts may come from the same site.
+func (s *FullContentService) FetchFullContent(ctx context.Context) error {
+	// ensure only one run happens at a time
+	if !s.mu.TryLock() {
+		slog.Info("full content fetch already in progress")
+		return nil
+	}
+	defer s.mu.Unlock()
+
+	since := timeutil.Now().Add(-FullContentWindow)
+	posts, err := s.repo.Post().ListPendingFullContent(ctx, since, FullContentBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i, post := range posts {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(FullContentFetchDelay):
+			}
+		}
+
+		err := s.FetchPostFullContent(ctx, post)
+		if err != nil {
+			slog.Warn("error fetching post full content",
+				"error", err.Error(),
+				"post_id", post.ID(),
+				"post_url", post.URL(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Fetch and extract a single post's full content. Failed attempts are still recorded
+// (with empty content) so that broken pages aren't retried forever.
+func (s *FullContentService) FetchPostFullContent(ctx context.Context, post *model.Post) error {
+	var fullContent string
+
+	page, fetchErr := s.pageFetcher.FetchPage(ctx, post.URL())
+	if fetchErr == nil {
+		fullContent, fetchErr = readability.Extract(page)
+	}
+
+	// Don't record an attempt that was cancelled (like during shutdown): it'll be retried later.
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	err := post.SetFullContent(fullContent, timeutil.Now())
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Post().Update(ctx, post)
+	if err != nil {
+		return err
+	}
+
+	if fetchErr != nil {
+		if errors.Is(fetchErr, readability.ErrNoContent) {
+			slog.Info("no full content found for post",
+				"post_id", post.ID(),
+				"post_url", post.URL(),
+			)
+			return nil
+		}
+		return fetchErr
+	}
+
+	slog.Info("fetched post full content",
+		"post_id", post.ID(),
+		"post_url", post.URL(),
+		"post_full_content_length", len(fullContent),
+	)
+
+	return nil
+}
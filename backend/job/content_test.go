@@ -0,0 +1,112 @@
+package job_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/readability"
+	readabilityMock "github.com/theandrew168/bloggulus/backend/readability/mock"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestFetchPostFullContent(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	page, err := os.ReadFile(filepath.Join("..", "readability", "testdata", "article.html"))
+	test.AssertNilError(t, err)
+
+	pageFetcher := readabilityMock.NewPageFetcher(map[string]string{
+		post.URL(): string(page),
+	})
+	s := job.NewFullContentService(repo, pageFetcher)
+
+	err = s.FetchPostFullContent(context.Background(), post)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+	test.AssertStringContains(t, got.FullContent(), "The first piece is the lexer")
+	test.AssertEqual(t, got.FullContentFetchedAt().IsZero(), false)
+}
+
+func TestFetchPostFullContentNoContent(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	page, err := os.ReadFile(filepath.Join("..", "readability", "testdata", "empty.html"))
+	test.AssertNilError(t, err)
+
+	pageFetcher := readabilityMock.NewPageFetcher(map[string]string{
+		post.URL(): string(page),
+	})
+	s := job.NewFullContentService(repo, pageFetcher)
+
+	// Pages without any content aren't an error (there just isn't anything to store).
+	err = s.FetchPostFullContent(context.Background(), post)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.FullContent(), "")
+	test.AssertEqual(t, got.FullContentFetchedAt().IsZero(), false)
+}
+
+func TestFetchPostFullContentUnreachable(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	pageFetcher := readabilityMock.NewPageFetcher(map[string]string{})
+	s := job.NewFullContentService(repo, pageFetcher)
+
+	err := s.FetchPostFullContent(context.Background(), post)
+	test.AssertErrorIs(t, err, readability.ErrUnreachablePage)
+
+	// The attempt should still be recorded so that the page isn't retried forever.
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.FullContent(), "")
+	test.AssertEqual(t, got.FullContentFetchedAt().IsZero(), false)
+}
+
+func TestFetchPostFullContentCanceled(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	pageFetcher := readabilityMock.NewPageFetcher(map[string]string{})
+	s := job.NewFullContentService(repo, pageFetcher)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.FetchPostFullContent(ctx, post)
+	test.AssertErrorIs(t, err, context.Canceled)
+
+	// A cancelled attempt shouldn't be recorded (so that the page is retried later).
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.FullContentFetchedAt().IsZero(), true)
+}
@@ -0,0 +1,220 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/digest"
+	"github.com/theandrew168/bloggulus/backend/mail"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+const (
+	// Check for digests that need to be sent every DigestInterval.
+	DigestInterval = 15 * time.Minute
+
+	// Include (at most) this many posts in a single digest.
+	DigestArticleLimit = 50
+
+	// Don't let a slow mail server hold up the rest of the digests.
+	DigestSendTimeout = 30 * time.Second
+)
+
+// FilterDueDigests takes a list of digests and returns only those that are ready to be sent
+// (digests are never sent to email addresses that haven't been verified).
+func FilterDueDigests(digests []*model.Digest, now time.Time) []*model.Digest {
+	var dueDigests []*model.Digest
+	for _, d := range digests {
+		if d.IsVerified() && d.IsDue(now) {
+			dueDigests = append(dueDigests, d)
+		}
+	}
+	return dueDigests
+}
+
+type DigestService struct {
+	repo    *repository.Repository
+	qry     *query.Query
+	mailer  mail.Mailer
+	siteURL string
+}
+
+func NewDigestService(repo *repository.Repository, qry *query.Query, mailer mail.Mailer, siteURL string) *DigestService {
+	s := DigestService{
+		repo:    repo,
+		qry:     qry,
+		mailer:  mailer,
+		siteURL: siteURL,
+	}
+	return &s
+}
+
+func (s *DigestService) Run(ctx context.Context) error {
+	// Send any due digests at service startup.
+	err := s.SendDigests(ctx)
+	if err != nil {
+		slog.Error("error sending digests",
+			"error", err.Error(),
+		)
+	}
+
+	// Then run again every "interval" until stopped (by the context being canceled).
+	ticker := time.NewTicker(DigestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping digest service")
+			slog.Info("stopped digest service")
+			return nil
+		case <-ticker.C:
+			err := s.SendDigests(ctx)
+			if err != nil {
+				slog.Error("error sending digests",
+					"error", err.Error(),
+				)
+			}
+		}
+	}
+}
+
+// Send every digest that is due (along with any pending confirmation links). A
+// failure to send one digest doesn't prevent the others from being sent: it'll
+// simply be retried next time around.
+func (s *DigestService) SendDigests(ctx context.Context) error {
+	digests, err := s.repo.Digest().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := timeutil.Now()
+	for _, d := range digests {
+		if !d.NeedsVerification() {
+			continue
+		}
+
+		err := s.SendVerification(ctx, d, now)
+		if err != nil {
+			slog.Warn("error sending digest verification",
+				"error", err.Error(),
+				"digest_id", d.ID(),
+				"account_id", d.AccountID(),
+			)
+		}
+	}
+
+	for _, d := range FilterDueDigests(digests, now) {
+		err := s.SendDigest(ctx, d, now)
+		if err != nil {
+			slog.Warn("error sending digest",
+				"error", err.Error(),
+				"digest_id", d.ID(),
+				"account_id", d.AccountID(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Send a single digest of the posts that showed up since the previous one. No
+// email is sent if there is nothing new.
+func (s *DigestService) SendDigest(ctx context.Context, d *model.Digest, now time.Time) error {
+	account, err := s.repo.Account().Read(ctx, d.AccountID())
+	if err != nil {
+		return err
+	}
+
+	articles, err := s.qry.ListNewArticlesByAccount(ctx, account, d.SentAt(), DigestArticleLimit)
+	if err != nil {
+		return err
+	}
+
+	if len(articles) > 0 {
+		data := digest.Data{
+			Username:  account.Username(),
+			Frequency: d.Frequency(),
+			Articles:  articles,
+			SiteURL:   s.siteURL,
+		}
+
+		message, err := digest.Render(d.Email(), data)
+		if err != nil {
+			return err
+		}
+
+		err = s.sendMail(ctx, message)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("digest sent",
+			"digest_id", d.ID(),
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"article_count", len(articles),
+		)
+	}
+
+	d.SetSentAt(now)
+	return s.repo.Digest().Update(ctx, d)
+}
+
+// Email a link for confirming that the digest's address belongs to the account.
+func (s *DigestService) SendVerification(ctx context.Context, d *model.Digest, now time.Time) error {
+	account, err := s.repo.Account().Read(ctx, d.AccountID())
+	if err != nil {
+		return err
+	}
+
+	token, err := d.StartVerification(now)
+	if err != nil {
+		return err
+	}
+
+	data := digest.VerificationData{
+		Username:  account.Username(),
+		VerifyURL: DigestVerifyURL(s.siteURL, token),
+		ExpiresIn: fmt.Sprintf("%d days", int(model.DigestVerificationTTL.Hours()/24)),
+	}
+
+	message, err := digest.RenderVerification(d.Email(), data)
+	if err != nil {
+		return err
+	}
+
+	// Only record the new token once it has actually been sent (otherwise it'll
+	// be retried next time around).
+	err = s.sendMail(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("digest verification sent",
+		"digest_id", d.ID(),
+		"account_id", account.ID(),
+		"account_username", account.Username(),
+	)
+
+	return s.repo.Digest().Update(ctx, d)
+}
+
+// Send a single email, giving up if it takes longer than DigestSendTimeout.
+func (s *DigestService) sendMail(ctx context.Context, message mail.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, DigestSendTimeout)
+	defer cancel()
+
+	return s.mailer.SendMail(ctx, message)
+}
+
+// The link that confirms a digest's email address (handled by the web server).
+func DigestVerifyURL(siteURL string, token string) string {
+	return siteURL + "/digest/verify?token=" + url.QueryEscape(token)
+}
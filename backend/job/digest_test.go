@@ -0,0 +1,152 @@
+package job_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/mail/mock"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestFilterDueDigests(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	account := test.NewAccount(t)
+
+	dailyDigest := test.NewDigest(t, account)
+	dailyDigest.Verify(now)
+	dailyDigest.SetSentAt(now.Add(-25 * time.Hour))
+	weeklyDigest := test.NewDigest(t, account)
+	weeklyDigest.Verify(now)
+	weeklyDigest.SetFrequency(model.DigestFrequencyWeekly)
+	weeklyDigest.SetSentAt(now.Add(-25 * time.Hour))
+	recentDigest := test.NewDigest(t, account)
+	recentDigest.Verify(now)
+	recentDigest.SetSentAt(now)
+
+	// Digests with unverified email addresses are never due.
+	unverifiedDigest := test.NewDigest(t, account)
+	unverifiedDigest.SetSentAt(now.Add(-25 * time.Hour))
+
+	digests := []*model.Digest{dailyDigest, weeklyDigest, recentDigest, unverifiedDigest}
+
+	dueDigests := job.FilterDueDigests(digests, now)
+	test.AssertEqual(t, len(dueDigests), 1)
+
+	var dueDigestIDs []uuid.UUID
+	for _, digest := range dueDigests {
+		dueDigestIDs = append(dueDigestIDs, digest.ID())
+	}
+
+	test.AssertSliceContains(t, dueDigestIDs, dailyDigest.ID())
+}
+
+func TestSendDigest(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	blog := test.CreateBlog(t, repo)
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	digest := test.NewDigest(t, account)
+	digest.SetSentAt(timeutil.Now().Add(-1 * time.Minute))
+	err := repo.Digest().Create(context.Background(), digest)
+	test.AssertNilError(t, err)
+
+	post := test.CreatePost(t, repo, blog)
+
+	mailer := mock.NewMailer()
+	s := job.NewDigestService(repo, qry, mailer, "https://bloggulus.com")
+
+	now := timeutil.Now()
+	err = s.SendDigest(context.Background(), digest, now)
+	test.AssertNilError(t, err)
+
+	messages := mailer.MessagesTo(digest.Email())
+	test.AssertEqual(t, len(messages), 1)
+	test.AssertStringContains(t, messages[0].Text, post.URL())
+
+	// The digest should be marked as sent.
+	got, err := repo.Digest().Read(context.Background(), digest.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.SentAt().Equal(now), true)
+}
+
+func TestSendDigestNothingNew(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	blog := test.CreateBlog(t, repo)
+	test.CreatePost(t, repo, blog)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	// The only post was synced before subscribing so it shouldn't be included.
+	digest := test.CreateDigest(t, repo, account)
+
+	mailer := mock.NewMailer()
+	s := job.NewDigestService(repo, qry, mailer, "https://bloggulus.com")
+
+	err := s.SendDigest(context.Background(), digest, timeutil.Now())
+	test.AssertNilError(t, err)
+
+	messages := mailer.MessagesTo(digest.Email())
+	test.AssertEqual(t, len(messages), 0)
+}
+
+func TestSendVerification(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	account := test.CreateAccount(t, repo)
+	digest := test.CreateDigest(t, repo, account)
+	test.AssertEqual(t, digest.NeedsVerification(), true)
+
+	mailer := mock.NewMailer()
+	s := job.NewDigestService(repo, qry, mailer, "https://bloggulus.com")
+
+	err := s.SendVerification(context.Background(), digest, timeutil.Now())
+	test.AssertNilError(t, err)
+
+	messages := mailer.MessagesTo(digest.Email())
+	test.AssertEqual(t, len(messages), 1)
+	test.AssertStringContains(t, messages[0].Text, "https://bloggulus.com/digest/verify?token=")
+
+	// The emailed token should lead back to the digest.
+	_, rest, _ := strings.Cut(messages[0].Text, "token=")
+	token, _, _ := strings.Cut(rest, "\n")
+	token, err = url.QueryUnescape(token)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Digest().ReadByVerificationToken(context.Background(), token)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.ID(), digest.ID())
+	test.AssertEqual(t, got.IsVerified(), false)
+	test.AssertEqual(t, got.NeedsVerification(), false)
+}
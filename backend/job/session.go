@@ -27,7 +27,7 @@ func NewSessionService(repo *repository.Repository) *SessionService {
 
 func (s *SessionService) Run(ctx context.Context) error {
 	// Clear out any expired sessions at service startup.
-	err := s.ClearExpiredSessions()
+	err := s.ClearExpiredSessions(ctx)
 	if err != nil {
 		slog.Error("error clearing expired sessions",
 			"error", err.Error(),
@@ -45,7 +45,7 @@ func (s *SessionService) Run(ctx context.Context) error {
 			slog.Info("stopped session service")
 			return nil
 		case <-ticker.C:
-			err := s.ClearExpiredSessions()
+			err := s.ClearExpiredSessions(ctx)
 			if err != nil {
 				slog.Error("error clearing expired sessions",
 					"error", err.Error(),
@@ -55,7 +55,7 @@ func (s *SessionService) Run(ctx context.Context) error {
 	}
 }
 
-func (s *SessionService) ClearExpiredSessions() error {
+func (s *SessionService) ClearExpiredSessions(ctx context.Context) error {
 	now := timeutil.Now()
-	return s.repo.Session().DeleteExpired(now)
+	return s.repo.Session().DeleteExpired(ctx, now)
 }
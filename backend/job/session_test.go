@@ -1,6 +1,7 @@
 package job_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -24,7 +25,7 @@ func TestClearExpiredSessions(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Session().Create(sessionOld)
+	err = repo.Session().Create(context.Background(), sessionOld)
 	test.AssertNilError(t, err)
 
 	sessionNew, _, err := model.NewSession(
@@ -33,16 +34,16 @@ func TestClearExpiredSessions(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Session().Create(sessionNew)
+	err = repo.Session().Create(context.Background(), sessionNew)
 	test.AssertNilError(t, err)
 
 	s := job.NewSessionService(repo)
-	err = s.ClearExpiredSessions()
+	err = s.ClearExpiredSessions(context.Background())
 	test.AssertNilError(t, err)
 
-	_, err = repo.Session().Read(sessionOld.ID())
+	_, err = repo.Session().Read(context.Background(), sessionOld.ID())
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 
-	_, err = repo.Session().Read(sessionNew.ID())
+	_, err = repo.Session().Read(context.Background(), sessionNew.ID())
 	test.AssertNilError(t, err)
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/sync/semaphore"
 
 	"github.com/theandrew168/bloggulus/backend/feed"
@@ -14,31 +17,41 @@ import (
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/repository"
 	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/webhook"
 )
 
 // SYNC:
 // Calculation: Run the sync process every N hours (time.Ticker)
 // Action: List all blogs in the database
-// Calculation: Determine which blogs need to be synced (FilterSyncableBlogs)
+// Calculation: Determine which blogs are due to be synced (FilterSyncableBlogs)
+// Calculation: Skip blogs that a WebSub hub is pushing updates for (FilterPushedBlogs)
 // ADD:
 // Calculation: For each sync-able blog, create it's FetchFeedRequest (CreateSyncRequest)
-// Action: Update sync time for each sync-able blog
+// Action: Update sync time (and provisional next sync time) for each sync-able blog
 // Action: Exchange the request to fetch the blog for a response (with limited concurrency)
+// Action: Record the outcome of the sync, backing off blogs that keep failing (ClassifySyncError)
+// Action: Retire the blog if its feed is gone for good (410 Gone)
+// Action: Update the blog's feed URL if it has permanently moved, merging with any existing blog (PermanentRedirectURL)
 // Action: Update the blog's cache headers if changed
 // Calculation: If the response includes data, parse the RSS / Atom feed for posts
 // Action: List all posts for the current blog
 // Calculation: Determine if any posts in the feed are new / updated
 // Action: Create / update posts in the database
+// Action: Enqueue webhook deliveries for any newly-created posts
+// Action: Create / update the blog's WebSub subscription if its feed advertises a hub
+// Calculation: Adapt the blog's sync interval and schedule its next sync (DetermineMinSyncInterval)
 
 const (
-	// Check for new posts every SyncInterval.
+	// Check for blogs that are due to be synced every SyncInterval (each blog
+	// has its own schedule within the bounds of model.MinSyncInterval and
+	// model.MaxSyncInterval).
 	SyncInterval = 30 * time.Minute
 
 	// How many blogs to sync at once.
 	SyncConcurrency = 8
 )
 
-// FilterSyncableBlogs takes a list of blogs and returns only those that are ready to be synced.
+// FilterSyncableBlogs takes a list of blogs and returns only those whose next sync is due.
 func FilterSyncableBlogs(blogs []*model.Blog, now time.Time) []*model.Blog {
 	var syncableBlogs []*model.Blog
 	for _, blog := range blogs {
@@ -49,6 +62,25 @@ func FilterSyncableBlogs(blogs []*model.Blog, now time.Time) []*model.Blog {
 	return syncableBlogs
 }
 
+// FilterPushedBlogs takes a list of blogs and returns only those without an active
+// WebSub subscription. Once a subscription's lease lapses, the blog gets polled again.
+func FilterPushedBlogs(blogs []*model.Blog, subscriptions []*model.WebSubSubscription, now time.Time) []*model.Blog {
+	pushedBlogIDs := make(map[uuid.UUID]bool)
+	for _, subscription := range subscriptions {
+		if subscription.IsActive(now) {
+			pushedBlogIDs[subscription.BlogID()] = true
+		}
+	}
+
+	var polledBlogs []*model.Blog
+	for _, blog := range blogs {
+		if !pushedBlogIDs[blog.ID()] {
+			polledBlogs = append(polledBlogs, blog)
+		}
+	}
+	return polledBlogs
+}
+
 // UpdateCacheHeaders updates the ETag and Last-Modified headers for a blog if they have changed.
 func UpdateCacheHeaders(blog *model.Blog, response feed.FetchFeedResponse) bool {
 	headersChanged := false
@@ -65,6 +97,53 @@ func UpdateCacheHeaders(blog *model.Blog, response feed.FetchFeedResponse) bool
 	return headersChanged
 }
 
+// DetermineMinSyncInterval finds the shortest interval that a blog should be synced at based
+// on the hints given by its server (Cache-Control) and feed (<ttl> or sy:updatePeriod).
+func DetermineMinSyncInterval(response feed.FetchFeedResponse, feedBlog feed.Blog) time.Duration {
+	return max(response.MaxAge, feedBlog.UpdateInterval)
+}
+
+// ClassifySyncError determines what kind of sync failure an error represents (along with
+// the HTTP status code that caused it, if any). Errors that aren't related to fetching or
+// parsing a feed are classified as SyncErrorNone.
+func ClassifySyncError(err error) (model.SyncError, int) {
+	var statusErr *feed.StatusError
+	var retryAfterErr *feed.RetryAfterError
+
+	switch {
+	case err == nil:
+		return model.SyncErrorNone, 0
+	case errors.Is(err, feed.ErrTimeout):
+		return model.SyncErrorTimeout, 0
+	case errors.Is(err, feed.ErrGoneFeed):
+		return model.SyncErrorUnreachable, http.StatusGone
+	case errors.As(err, &statusErr):
+		return model.SyncErrorUnreachable, statusErr.StatusCode
+	case errors.As(err, &retryAfterErr):
+		return model.SyncErrorUnreachable, retryAfterErr.StatusCode
+	case errors.Is(err, feed.ErrUnreachableFeed):
+		return model.SyncErrorUnreachable, 0
+	case errors.Is(err, feed.ErrInvalidFeed):
+		return model.SyncErrorParse, 0
+	default:
+		return model.SyncErrorNone, 0
+	}
+}
+
+// Convert a feed post's enclosures into their model equivalent.
+func convertEnclosures(feedEnclosures []feed.Enclosure) []model.PostEnclosure {
+	var enclosures []model.PostEnclosure
+	for _, enclosure := range feedEnclosures {
+		enclosures = append(enclosures, model.PostEnclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: enclosure.Length,
+		})
+	}
+
+	return enclosures
+}
+
 type ComparePostsResult struct {
 	PostsToCreate []*model.Post
 	PostsToUpdate []*model.Post
@@ -98,6 +177,12 @@ func ComparePosts(blog *model.Blog, knownPosts []*model.Post, feedPosts []feed.P
 				return ComparePostsResult{}, err
 			}
 
+			postToCreate.SetAuthors(feedPost.Authors)
+			postToCreate.SetSummary(feedPost.Summary)
+			postToCreate.SetCategories(feedPost.Categories)
+			postToCreate.SetImageURL(feedPost.ImageURL)
+			postToCreate.SetEnclosures(convertEnclosures(feedPost.Enclosures))
+
 			postsToCreate = append(postsToCreate, postToCreate)
 		} else {
 			// The post already exists but we might need to update it.
@@ -121,6 +206,37 @@ func ComparePosts(blog *model.Blog, knownPosts []*model.Post, feedPosts []feed.P
 				knownPostShouldBeUpdated = true
 			}
 
+			// Check if the post's authors have changed.
+			if len(feedPost.Authors) > 0 && !slices.Equal(feedPost.Authors, knownPost.Authors()) {
+				knownPost.SetAuthors(feedPost.Authors)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's summary has changed.
+			if feedPost.Summary != "" && feedPost.Summary != knownPost.Summary() {
+				knownPost.SetSummary(feedPost.Summary)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's categories have changed.
+			if len(feedPost.Categories) > 0 && !slices.Equal(feedPost.Categories, knownPost.Categories()) {
+				knownPost.SetCategories(feedPost.Categories)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's image has changed.
+			if feedPost.ImageURL != "" && feedPost.ImageURL != knownPost.ImageURL() {
+				knownPost.SetImageURL(feedPost.ImageURL)
+				knownPostShouldBeUpdated = true
+			}
+
+			// Check if the post's enclosures have changed.
+			enclosures := convertEnclosures(feedPost.Enclosures)
+			if len(enclosures) > 0 && !slices.Equal(enclosures, knownPost.Enclosures()) {
+				knownPost.SetEnclosures(enclosures)
+				knownPostShouldBeUpdated = true
+			}
+
 			// If any post data has changed, add it to the list of posts to update.
 			if knownPostShouldBeUpdated {
 				postsToUpdate = append(postsToUpdate, knownPost)
@@ -135,13 +251,19 @@ func ComparePosts(blog *model.Blog, knownPosts []*model.Post, feedPosts []feed.P
 	return result, nil
 }
 
-func ParallelForEach[T any](concurrency int, items []T, fn func(T)) {
+// Call fn for each item in parallel (up to "concurrency" at once). Once the context is
+// canceled, no new items are started but any that are already running get to finish.
+func ParallelForEach[T any](ctx context.Context, concurrency int, items []T, fn func(T)) {
 	// Use a weighted semaphore to limit concurrency.
 	sem := semaphore.NewWeighted(int64(concurrency))
 
 	// Perform tasks in parallel (up to "concurrency" at once).
 	for _, item := range items {
-		sem.Acquire(context.Background(), 1)
+		// This only fails if the context is canceled.
+		err := sem.Acquire(ctx, 1)
+		if err != nil {
+			break
+		}
 
 		go func(item T) {
 			defer sem.Release(1)
@@ -150,7 +272,7 @@ func ParallelForEach[T any](concurrency int, items []T, fn func(T)) {
 	}
 
 	// Wait for all tasks to finish.
-	sem.Acquire(context.Background(), SyncConcurrency)
+	sem.Acquire(context.Background(), int64(concurrency))
 }
 
 type SyncService struct {
@@ -169,7 +291,7 @@ func NewSyncService(repo *repository.Repository, feedFetcher feed.FeedFetcher) *
 
 func (s *SyncService) Run(ctx context.Context) error {
 	// perform an initial sync at service startup
-	err := s.SyncAllBlogs()
+	err := s.SyncAllBlogs(ctx)
 	if err != nil {
 		slog.Error("error syncing blogs",
 			"error", err.Error(),
@@ -187,7 +309,7 @@ func (s *SyncService) Run(ctx context.Context) error {
 			slog.Info("stopped sync service")
 			return nil
 		case <-ticker.C:
-			err := s.SyncAllBlogs()
+			err := s.SyncAllBlogs(ctx)
 			if err != nil {
 				slog.Error("error syncing blogs",
 					"error", err.Error(),
@@ -198,9 +320,9 @@ func (s *SyncService) Run(ctx context.Context) error {
 }
 
 // Start with the current time and a list of all known blogs. For each blog,
-// compare its syncedAt time to the current time. If the difference is less
-// than SyncCooldown, skip it. Otherwise, check for and sync new content.
-func (s *SyncService) SyncAllBlogs() error {
+// compare its nextSyncAt time to the current time. If it isn't due yet, skip
+// it. Otherwise, check for and sync new content.
+func (s *SyncService) SyncAllBlogs(ctx context.Context) error {
 	// ensure only one sync happens at a time
 	if !s.mu.TryLock() {
 		slog.Info("sync already in progress")
@@ -210,27 +332,39 @@ func (s *SyncService) SyncAllBlogs() error {
 
 	slog.Info("syncing blogs")
 
-	blogs, err := s.repo.Blog().ListAll()
+	blogs, err := s.repo.Blog().ListAll(ctx)
 	if err != nil {
 		return err
 	}
 
-	// Be sure to only sync blogs that are ready.
+	subscriptions, err := s.repo.WebSubSubscription().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Be sure to only sync blogs that are ready (and aren't being pushed by a hub).
 	now := timeutil.Now()
 	syncableBlogs := FilterSyncableBlogs(blogs, now)
+	syncableBlogs = FilterPushedBlogs(syncableBlogs, subscriptions, now)
 
-	// Update the syncedAt time for each syncable blog before syncing.
+	// Update the sync times for each syncable blog before syncing (this
+	// ensures that failing blogs aren't retried until their next sync is due).
 	for _, blog := range syncableBlogs {
-		blog.SetSyncedAt(now)
-		err = s.repo.Blog().Update(blog)
+		blog.StartSync(now)
+		err = s.repo.Blog().Update(ctx, blog)
 		if err != nil {
 			return err
 		}
 	}
 
-	ParallelForEach(SyncConcurrency, syncableBlogs, func(blog *model.Blog) {
+	ParallelForEach(ctx, SyncConcurrency, syncableBlogs, func(blog *model.Blog) {
+		// Skip the remaining blogs if the sync has been cancelled (like during shutdown).
+		if ctx.Err() != nil {
+			return
+		}
+
 		slog.Info("syncing blog", "title", blog.Title(), "id", blog.ID())
-		_, err := s.SyncBlog(blog.FeedURL())
+		_, err := s.SyncBlog(ctx, blog.FeedURL())
 		if err != nil {
 			slog.Warn(err.Error(), "title", blog.Title(), "id", blog.ID())
 		}
@@ -240,8 +374,8 @@ func (s *SyncService) SyncAllBlogs() error {
 }
 
 // Sync a new or existing Blog based on the provided feed URL.
-func (s *SyncService) SyncBlog(feedURL string) (*model.Blog, error) {
-	blog, err := s.repo.Blog().ReadByFeedURL(feedURL)
+func (s *SyncService) SyncBlog(ctx context.Context, feedURL string) (*model.Blog, error) {
+	blog, err := s.repo.Blog().ReadByFeedURL(ctx, feedURL)
 	if err != nil {
 		if !errors.Is(err, postgres.ErrNotFound) {
 			return nil, err
@@ -250,18 +384,18 @@ func (s *SyncService) SyncBlog(feedURL string) (*model.Blog, error) {
 		// An ErrNotFound is acceptable (and expected) here. The only difference
 		// is that we won't be able to include the ETag and Last-Modified headers
 		// in the request. This is fine for new blogs (an unconditional fetch).
-		return s.syncNewBlog(feedURL)
+		return s.syncNewBlog(ctx, feedURL)
 	}
 
-	return s.syncExistingBlog(blog)
+	return s.syncExistingBlog(ctx, blog)
 }
 
-func (s *SyncService) syncNewBlog(feedURL string) (*model.Blog, error) {
+func (s *SyncService) syncNewBlog(ctx context.Context, feedURL string) (*model.Blog, error) {
 	// Make an unconditional fetch for the blog's feed.
 	req := feed.FetchFeedRequest{
 		URL: feedURL,
 	}
-	resp, err := s.feedFetcher.FetchFeed(req)
+	resp, err := s.feedFetcher.FetchFeed(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -271,6 +405,21 @@ func (s *SyncService) syncNewBlog(feedURL string) (*model.Blog, error) {
 		return nil, feed.ErrUnreachableFeed
 	}
 
+	// If the feed has permanently moved, add it under its new URL (or use the existing blog).
+	movedURL := feed.PermanentRedirectURL(resp.Redirects)
+	if movedURL != "" && movedURL != feedURL {
+		blog, err := s.repo.Blog().ReadByFeedURL(ctx, movedURL)
+		if err == nil {
+			return blog, nil
+		}
+
+		if !errors.Is(err, postgres.ErrNotFound) {
+			return nil, err
+		}
+
+		feedURL = movedURL
+	}
+
 	feedBlog, err := feed.Parse(feedURL, resp.Feed)
 	if err != nil {
 		return nil, err
@@ -289,84 +438,340 @@ func (s *SyncService) syncNewBlog(feedURL string) (*model.Blog, error) {
 		return nil, err
 	}
 
-	err = s.repo.Blog().Create(blog)
+	blog.RecordSyncSuccess(blog.SyncedAt(), resp.StatusCode)
+
+	err = s.repo.Blog().Create(ctx, blog)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.syncPosts(blog, feedBlog.Posts)
+	_, err = s.syncPosts(ctx, blog, feedBlog.Posts)
 	if err != nil {
 		return nil, err
 	}
 
+	err = s.syncHub(ctx, blog, feedBlog)
+	if err != nil {
+		slog.Warn("failed to sync hub", "title", blog.Title(), "id", blog.ID(), "error", err.Error())
+	}
+
 	return blog, nil
 }
 
-func (s *SyncService) syncExistingBlog(blog *model.Blog) (*model.Blog, error) {
+func (s *SyncService) syncExistingBlog(ctx context.Context, blog *model.Blog) (*model.Blog, error) {
 	// Make a conditional fetch for the blog's feed.
 	req := feed.FetchFeedRequest{
 		URL:          blog.FeedURL(),
 		ETag:         blog.ETag(),
 		LastModified: blog.LastModified(),
 	}
-	resp, err := s.feedFetcher.FetchFeed(req)
+	resp, err := s.feedFetcher.FetchFeed(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, s.recordSyncFailure(ctx, blog, 0, err)
 	}
 
-	// Update the blog's cache headers if they have changed.
-	headersChanged := UpdateCacheHeaders(blog, resp)
-	if headersChanged {
-		err = s.repo.Blog().Update(blog)
+	// Follow the feed to its new home if it has permanently moved.
+	movedURL := feed.PermanentRedirectURL(resp.Redirects)
+	if movedURL != "" && movedURL != blog.FeedURL() {
+		movedBlog, err := s.moveBlog(ctx, blog, movedURL)
 		if err != nil {
 			return nil, err
 		}
+
+		// The blog was merged into another one (which will be synced on its own schedule).
+		if movedBlog.ID() != blog.ID() {
+			return movedBlog, nil
+		}
 	}
 
+	// Update the blog's cache headers if they have changed.
+	UpdateCacheHeaders(blog, resp)
+
 	if resp.Feed == "" {
 		slog.Info("skipping blog (no feed content)", "title", blog.Title(), "id", blog.ID())
+
+		// Nothing has changed so the blog can be synced less often.
+		blog.RecordSyncSuccess(timeutil.Now(), resp.StatusCode)
+		blog.ScheduleSync(timeutil.Now(), false, resp.MaxAge)
+		err = s.repo.Blog().Update(ctx, blog)
+		if err != nil {
+			return nil, err
+		}
+
 		return blog, nil
 	}
 
 	feedBlog, err := feed.Parse(blog.FeedURL(), resp.Feed)
+	if err != nil {
+		return nil, s.recordSyncFailure(ctx, blog, resp.StatusCode, err)
+	}
+
+	createdPosts, err := s.syncPosts(ctx, blog, feedBlog.Posts)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.syncPosts(blog, feedBlog.Posts)
+	err = s.syncHub(ctx, blog, feedBlog)
+	if err != nil {
+		slog.Warn("failed to sync hub", "title", blog.Title(), "id", blog.ID(), "error", err.Error())
+	}
+
+	// Sync more often when new posts show up (and less often when they don't).
+	blog.RecordSyncSuccess(timeutil.Now(), resp.StatusCode)
+	blog.ScheduleSync(timeutil.Now(), len(createdPosts) > 0, DetermineMinSyncInterval(resp, feedBlog))
+	err = s.repo.Blog().Update(ctx, blog)
 	if err != nil {
 		return nil, err
 	}
 
+	slog.Info("scheduled next sync",
+		"title", blog.Title(),
+		"id", blog.ID(),
+		"sync_interval", blog.SyncInterval().String(),
+		"next_sync_at", blog.NextSyncAt(),
+	)
+
 	return blog, nil
 }
 
-func (s *SyncService) syncPosts(blog *model.Blog, feedPosts []feed.Post) error {
-	// List all known posts for the current blog.
-	knownPosts, err := s.repo.Post().ListByBlog(blog)
+// Update a blog's feed URL after it has permanently moved. If another blog already has the
+// new URL, the two are merged: followers, posts, and filters move over to the other blog
+// (which is returned) and the old one is deleted. The updated blog is saved by the caller.
+func (s *SyncService) moveBlog(ctx context.Context, blog *model.Blog, feedURL string) (*model.Blog, error) {
+	existingBlog, err := s.repo.Blog().ReadByFeedURL(ctx, feedURL)
+	if err != nil {
+		if !errors.Is(err, postgres.ErrNotFound) {
+			return nil, err
+		}
+
+		slog.Info("blog moved",
+			"title", blog.Title(),
+			"id", blog.ID(),
+			"from", blog.FeedURL(),
+			"to", feedURL,
+		)
+
+		err = blog.SetFeedURL(feedURL)
+		if err != nil {
+			return nil, err
+		}
+
+		return blog, nil
+	}
+
+	err = s.repo.WithTransaction(ctx, func(tx *repository.Repository) error {
+		err := tx.AccountBlog().Reassign(ctx, blog, existingBlog)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Post().Reassign(ctx, blog, existingBlog)
+		if err != nil {
+			return err
+		}
+
+		err = tx.ContentFilter().Reassign(ctx, blog, existingBlog)
+		if err != nil {
+			return err
+		}
+
+		return tx.Blog().Delete(ctx, blog)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("blog merged",
+		"title", blog.Title(),
+		"id", blog.ID(),
+		"into_title", existingBlog.Title(),
+		"into_id", existingBlog.ID(),
+	)
+
+	return existingBlog, nil
+}
+
+// Record a failed sync for a blog (which backs it off) and return the original error.
+// Only problems with the feed itself count as failures (not database errors, etc). The
+// status code is only used if the error doesn't include one of its own.
+func (s *SyncService) recordSyncFailure(ctx context.Context, blog *model.Blog, statusCode int, err error) error {
+	syncErr, errStatusCode := ClassifySyncError(err)
+	if syncErr == model.SyncErrorNone {
+		return err
+	}
+
+	if errStatusCode != 0 {
+		statusCode = errStatusCode
+	}
+
+	now := timeutil.Now()
+	blog.RecordSyncFailure(now, statusCode, syncErr)
+
+	// Respect the server's wishes if it asked us to back off for a while.
+	var retryAfterErr *feed.RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		blog.DelaySync(now, retryAfterErr.RetryAfter)
+	}
+
+	// Stop syncing the blog altogether if its feed has been removed.
+	if errors.Is(err, feed.ErrGoneFeed) {
+		blog.Retire(now)
+	}
+
+	updateErr := s.repo.Blog().Update(ctx, blog)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	slog.Info("recorded sync failure",
+		"title", blog.Title(),
+		"id", blog.ID(),
+		"sync_error", blog.LastSyncError(),
+		"sync_failures", blog.SyncFailures(),
+		"next_sync_at", blog.NextSyncAt(),
+		"retired", blog.IsRetired(),
+	)
+
+	return err
+}
+
+// Sync an existing blog using feed content that was pushed to us by a WebSub hub
+// (instead of being fetched). Pushed content is handled just like polled content.
+func (s *SyncService) SyncBlogContent(ctx context.Context, blog *model.Blog, feedBody string) error {
+	feedBlog, err := feed.Parse(blog.FeedURL(), feedBody)
 	if err != nil {
 		return err
 	}
 
+	_, err = s.syncPosts(ctx, blog, feedBlog.Posts)
+	return err
+}
+
+// Create and update a blog's posts based on the posts in its feed. The newly-created
+// posts are returned (which are used to adapt the blog's sync schedule).
+func (s *SyncService) syncPosts(ctx context.Context, blog *model.Blog, feedPosts []feed.Post) ([]*model.Post, error) {
+	// List all known posts for the current blog.
+	knownPosts, err := s.repo.Post().ListByBlog(ctx, blog)
+	if err != nil {
+		return nil, err
+	}
+
 	// Compare the known posts to the feed posts.
 	result, err := ComparePosts(blog, knownPosts, feedPosts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create any posts that are new.
+	var createdPosts []*model.Post
 	for _, post := range result.PostsToCreate {
-		err = s.repo.Post().Create(post)
+		err = s.repo.Post().Create(ctx, post)
 		if err != nil {
 			slog.Warn("failed to create post", "url", post.URL(), "error", err.Error())
+			continue
+		}
+
+		createdPosts = append(createdPosts, post)
+
+		err = s.repo.PostTag().Replace(ctx, post, model.NormalizeTagNames(post.Categories()))
+		if err != nil {
+			slog.Warn("failed to tag post", "url", post.URL(), "error", err.Error())
+		}
+	}
+
+	// Let any interested webhooks know about the new posts.
+	if len(createdPosts) > 0 {
+		err = s.enqueueWebhookDeliveries(ctx, blog, createdPosts)
+		if err != nil {
+			slog.Warn("failed to enqueue webhook deliveries", "title", blog.Title(), "id", blog.ID(), "error", err.Error())
 		}
 	}
 
 	// Update any posts that have changed.
 	for _, post := range result.PostsToUpdate {
-		err = s.repo.Post().Update(post)
+		err = s.repo.Post().Update(ctx, post)
 		if err != nil {
 			slog.Warn("failed to update post", "url", post.URL(), "error", err.Error())
+			continue
+		}
+
+		err = s.repo.PostTag().Replace(ctx, post, model.NormalizeTagNames(post.Categories()))
+		if err != nil {
+			slog.Warn("failed to tag post", "url", post.URL(), "error", err.Error())
+		}
+	}
+
+	return createdPosts, nil
+}
+
+// Keep track of the WebSub hub (if any) advertised by a blog's feed. The subscription
+// itself gets requested (and renewed) by the WebSubService.
+func (s *SyncService) syncHub(ctx context.Context, blog *model.Blog, feedBlog feed.Blog) error {
+	subscription, err := s.repo.WebSubSubscription().ReadByBlog(ctx, blog)
+	if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+		return err
+	}
+
+	// Prefer the feed's self-declared URL as the topic (hubs expect an exact match).
+	topicURL := feedBlog.SelfURL
+	if topicURL == "" {
+		topicURL = blog.FeedURL()
+	}
+
+	hasSubscription := subscription != nil
+	hasHub := feedBlog.HubURL != ""
+
+	switch {
+	case !hasSubscription && hasHub:
+		subscription, err = model.NewWebSubSubscription(blog, feedBlog.HubURL, topicURL)
+		if err != nil {
+			return err
+		}
+
+		return s.repo.WebSubSubscription().Create(ctx, subscription)
+	case hasSubscription && !hasHub:
+		// The feed stopped advertising a hub so go back to polling.
+		return s.repo.WebSubSubscription().Delete(ctx, subscription)
+	case hasSubscription && hasHub:
+		if subscription.HubURL() == feedBlog.HubURL && subscription.TopicURL() == topicURL {
+			return nil
+		}
+
+		err = subscription.SetHub(feedBlog.HubURL, topicURL)
+		if err != nil {
+			return err
+		}
+
+		return s.repo.WebSubSubscription().Update(ctx, subscription)
+	}
+
+	return nil
+}
+
+// Queue up a "post.created" delivery for each new post to each webhook belonging
+// to an account that follows the blog. The WebhookService takes it from there.
+func (s *SyncService) enqueueWebhookDeliveries(ctx context.Context, blog *model.Blog, posts []*model.Post) error {
+	webhooks, err := s.repo.Webhook().ListByBlog(ctx, blog)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		payload, err := webhook.NewPostCreatedPayload(blog, post)
+		if err != nil {
+			return err
+		}
+
+		for _, w := range webhooks {
+			delivery, err := model.NewWebhookDelivery(w, webhook.EventPostCreated, payload)
+			if err != nil {
+				return err
+			}
+
+			err = s.repo.WebhookDelivery().Create(ctx, delivery)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
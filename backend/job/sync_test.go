@@ -1,6 +1,9 @@
 package job_test
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,8 +13,10 @@ import (
 	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
 	"github.com/theandrew168/bloggulus/backend/job"
 	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/test"
 	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/webhook"
 )
 
 func TestFilterSyncableBlogs(t *testing.T) {
@@ -19,12 +24,13 @@ func TestFilterSyncableBlogs(t *testing.T) {
 
 	now := timeutil.Now()
 
+	// New blogs are next synced after the default interval.
 	pastBlog := test.NewBlog(t)
-	pastBlog.SetSyncedAt(now.Add(-model.SyncCooldown).Add(-1 * time.Minute))
+	pastBlog.StartSync(now.Add(-model.DefaultSyncInterval).Add(-1 * time.Minute))
 	presentBlog := test.NewBlog(t)
-	presentBlog.SetSyncedAt(now)
+	presentBlog.StartSync(now)
 	futureBlog := test.NewBlog(t)
-	futureBlog.SetSyncedAt(now.Add(1 * time.Hour))
+	futureBlog.StartSync(now.Add(1 * time.Hour))
 
 	blogs := []*model.Blog{pastBlog, presentBlog, futureBlog}
 
@@ -39,6 +45,232 @@ func TestFilterSyncableBlogs(t *testing.T) {
 	test.AssertSliceContains(t, syncableBlogIDs, pastBlog.ID())
 }
 
+func TestScheduleSync(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+
+	// The interval grows while nothing changes.
+	blog := test.NewBlog(t)
+	blog.ScheduleSync(now, false, 0)
+	test.AssertEqual(t, blog.SyncInterval(), model.DefaultSyncInterval*3/2)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(blog.SyncInterval()))
+
+	// But never beyond the max.
+	for range 20 {
+		blog.ScheduleSync(now, false, 0)
+	}
+	test.AssertEqual(t, blog.SyncInterval(), model.MaxSyncInterval)
+
+	// And shrinks when new posts are found.
+	blog = test.NewBlog(t)
+	blog.ScheduleSync(now, true, 0)
+	test.AssertEqual(t, blog.SyncInterval(), model.DefaultSyncInterval/2)
+
+	// But never below the min.
+	for range 20 {
+		blog.ScheduleSync(now, true, 0)
+	}
+	test.AssertEqual(t, blog.SyncInterval(), model.MinSyncInterval)
+
+	// Hints from the feed (or server) are respected.
+	blog = test.NewBlog(t)
+	blog.ScheduleSync(now, true, 6*time.Hour)
+	test.AssertEqual(t, blog.SyncInterval(), 6*time.Hour)
+}
+
+func TestDelaySync(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+
+	blog := test.NewBlog(t)
+	blog.StartSync(now)
+
+	// A short delay doesn't move the next sync any sooner.
+	blog.DelaySync(now, 1*time.Minute)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(model.DefaultSyncInterval))
+
+	// But a longer one pushes it back (without changing the interval).
+	blog.DelaySync(now, 5*time.Hour)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(5*time.Hour))
+	test.AssertEqual(t, blog.SyncInterval(), model.DefaultSyncInterval)
+
+	// Up to a limit.
+	blog.DelaySync(now, 365*24*time.Hour)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(model.MaxSyncInterval))
+}
+
+func TestDetermineMinSyncInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		maxAge         time.Duration
+		updateInterval time.Duration
+		want           time.Duration
+	}{
+		{maxAge: 0, updateInterval: 0, want: 0},
+		{maxAge: 1 * time.Hour, updateInterval: 0, want: 1 * time.Hour},
+		{maxAge: 0, updateInterval: 6 * time.Hour, want: 6 * time.Hour},
+		{maxAge: 1 * time.Hour, updateInterval: 6 * time.Hour, want: 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		response := feed.FetchFeedResponse{MaxAge: tt.maxAge}
+		feedBlog := feed.Blog{UpdateInterval: tt.updateInterval}
+
+		got := job.DetermineMinSyncInterval(response, feedBlog)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestClassifySyncError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err        error
+		want       model.SyncError
+		statusCode int
+	}{
+		{err: nil, want: model.SyncErrorNone, statusCode: 0},
+		{err: errors.New("database is down"), want: model.SyncErrorNone, statusCode: 0},
+		{err: feed.ErrUnreachableFeed, want: model.SyncErrorUnreachable, statusCode: 0},
+		{err: feed.ErrTimeout, want: model.SyncErrorTimeout, statusCode: 0},
+		{err: feed.ErrGoneFeed, want: model.SyncErrorUnreachable, statusCode: 410},
+		{err: feed.ErrForbiddenAddress, want: model.SyncErrorUnreachable, statusCode: 0},
+		{err: feed.ErrTooLarge, want: model.SyncErrorParse, statusCode: 0},
+		{err: context.Canceled, want: model.SyncErrorNone, statusCode: 0},
+		{err: feed.ErrInvalidFeed, want: model.SyncErrorParse, statusCode: 0},
+		{err: &feed.StatusError{StatusCode: 404}, want: model.SyncErrorUnreachable, statusCode: 404},
+		{err: &feed.RetryAfterError{StatusCode: 503, RetryAfter: 1 * time.Hour}, want: model.SyncErrorUnreachable, statusCode: 503},
+	}
+	for _, tt := range tests {
+		got, statusCode := job.ClassifySyncError(tt.err)
+		test.AssertEqual(t, got, tt.want)
+		test.AssertEqual(t, statusCode, tt.statusCode)
+	}
+}
+
+func TestRecordSyncFailure(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+
+	blog := test.NewBlog(t)
+	blog.StartSync(now)
+
+	// Each consecutive failure doubles the time until the next attempt.
+	blog.RecordSyncFailure(now, 500, model.SyncErrorUnreachable)
+	test.AssertEqual(t, blog.IsFailing(), true)
+	test.AssertEqual(t, blog.SyncFailures(), 1)
+	test.AssertEqual(t, blog.LastSyncStatusCode(), 500)
+	test.AssertEqual(t, blog.LastSyncError(), model.SyncErrorUnreachable)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(model.DefaultSyncInterval))
+
+	blog.RecordSyncFailure(now, 0, model.SyncErrorTimeout)
+	test.AssertEqual(t, blog.SyncFailures(), 2)
+	test.AssertEqual(t, blog.LastSyncError(), model.SyncErrorTimeout)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(2*model.DefaultSyncInterval))
+
+	blog.RecordSyncFailure(now, 200, model.SyncErrorParse)
+	test.AssertEqual(t, blog.SyncFailures(), 3)
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(4*model.DefaultSyncInterval))
+
+	// Up to a limit.
+	for range 10 {
+		blog.RecordSyncFailure(now, 0, model.SyncErrorTimeout)
+	}
+	test.AssertEqual(t, blog.NextSyncAt(), now.Add(model.MaxSyncBackoff))
+
+	// A successful sync clears the failures.
+	later := now.Add(1 * time.Hour)
+	blog.RecordSyncSuccess(later, 200)
+	test.AssertEqual(t, blog.IsFailing(), false)
+	test.AssertEqual(t, blog.SyncFailures(), 0)
+	test.AssertEqual(t, blog.LastSyncStatusCode(), 200)
+	test.AssertEqual(t, blog.LastSyncError(), model.SyncErrorNone)
+	test.AssertEqual(t, blog.LastSyncSuccessAt(), later)
+}
+
+func TestRetireBlog(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+
+	blog := test.NewBlog(t)
+	blog.StartSync(now.Add(-model.MaxSyncInterval))
+	test.AssertEqual(t, blog.CanBeSynced(now), true)
+
+	// Retired blogs are never due to be synced.
+	blog.Retire(now)
+	test.AssertEqual(t, blog.IsRetired(), true)
+	test.AssertEqual(t, blog.CanBeSynced(now), false)
+
+	// Until they sync successfully again.
+	blog.RecordSyncSuccess(now, 200)
+	test.AssertEqual(t, blog.IsRetired(), false)
+	test.AssertEqual(t, blog.CanBeSynced(now), true)
+}
+
+func TestFilterPushedBlogs(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+
+	pushedBlog := test.NewBlog(t)
+	pushedSubscription := test.NewWebSubSubscription(t, pushedBlog)
+	pushedSubscription.SetLease(now, 1*time.Hour)
+
+	lapsedBlog := test.NewBlog(t)
+	lapsedSubscription := test.NewWebSubSubscription(t, lapsedBlog)
+	lapsedSubscription.SetLease(now, -1*time.Hour)
+
+	polledBlog := test.NewBlog(t)
+
+	blogs := []*model.Blog{pushedBlog, lapsedBlog, polledBlog}
+	subscriptions := []*model.WebSubSubscription{pushedSubscription, lapsedSubscription}
+
+	polledBlogs := job.FilterPushedBlogs(blogs, subscriptions, now)
+	test.AssertEqual(t, len(polledBlogs), 2)
+
+	var polledBlogIDs []uuid.UUID
+	for _, blog := range polledBlogs {
+		polledBlogIDs = append(polledBlogIDs, blog.ID())
+	}
+
+	test.AssertSliceContains(t, polledBlogIDs, lapsedBlog.ID())
+	test.AssertSliceContains(t, polledBlogIDs, polledBlog.ID())
+}
+
+func TestParallelForEach(t *testing.T) {
+	t.Parallel()
+
+	var count atomic.Int64
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	job.ParallelForEach(context.Background(), 3, items, func(item int) {
+		count.Add(int64(item))
+	})
+
+	test.AssertEqual(t, count.Load(), int64(55))
+}
+
+func TestParallelForEachCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel the context while the first item is still running.
+	var count atomic.Int64
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	job.ParallelForEach(ctx, 1, items, func(item int) {
+		count.Add(1)
+		cancel()
+	})
+
+	// No new items should be started after the cancellation.
+	test.AssertEqual(t, count.Load(), int64(1))
+}
+
 func TestUpdateCacheHeaders(t *testing.T) {
 	t.Parallel()
 
@@ -115,6 +347,72 @@ func TestComparePosts(t *testing.T) {
 	test.AssertEqual(t, result.PostsToUpdate[0].PublishedAt(), updatedPost.PublishedAt)
 }
 
+func TestComparePostsMetadata(t *testing.T) {
+	t.Parallel()
+
+	blog := test.NewBlog(t)
+
+	knownPost := test.NewPost(t, blog)
+	unchangedPost := test.NewPost(t, blog)
+	knownPosts := []*model.Post{
+		knownPost,
+		unchangedPost,
+	}
+
+	newPost := feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		Content:     test.RandomString(200),
+		PublishedAt: test.RandomTime(),
+		Authors:     []string{"Alice"},
+		Summary:     test.RandomString(50),
+		Categories:  []string{"foo", "bar"},
+		ImageURL:    test.RandomURL(20),
+		Enclosures: []feed.Enclosure{
+			{URL: test.RandomURL(20), Type: "audio/mpeg", Length: 12345},
+		},
+	}
+	updatedPost := feed.Post{
+		URL:         knownPost.URL(),
+		Title:       knownPost.Title(),
+		Content:     knownPost.Content(),
+		PublishedAt: knownPost.PublishedAt(),
+		Summary:     test.RandomString(50),
+		Categories:  []string{"baz"},
+	}
+	// Posts with no metadata in the feed should be left alone.
+	sameFeedPost := feed.Post{
+		URL:         unchangedPost.URL(),
+		Title:       unchangedPost.Title(),
+		Content:     unchangedPost.Content(),
+		PublishedAt: unchangedPost.PublishedAt(),
+	}
+	feedPosts := []feed.Post{
+		newPost,
+		updatedPost,
+		sameFeedPost,
+	}
+
+	result, err := job.ComparePosts(blog, knownPosts, feedPosts)
+	test.AssertNilError(t, err)
+
+	// Verify that the new post includes all of its metadata.
+	test.AssertEqual(t, len(result.PostsToCreate), 1)
+	test.AssertEqual(t, result.PostsToCreate[0].Authors(), newPost.Authors)
+	test.AssertEqual(t, result.PostsToCreate[0].Summary(), newPost.Summary)
+	test.AssertEqual(t, result.PostsToCreate[0].Categories(), newPost.Categories)
+	test.AssertEqual(t, result.PostsToCreate[0].ImageURL(), newPost.ImageURL)
+	test.AssertEqual(t, result.PostsToCreate[0].Enclosures(), []model.PostEnclosure{
+		{URL: newPost.Enclosures[0].URL, Type: "audio/mpeg", Length: 12345},
+	})
+
+	// Verify that only the post with changed metadata should be updated.
+	test.AssertEqual(t, len(result.PostsToUpdate), 1)
+	test.AssertEqual(t, result.PostsToUpdate[0].URL(), knownPost.URL())
+	test.AssertEqual(t, result.PostsToUpdate[0].Summary(), updatedPost.Summary)
+	test.AssertEqual(t, result.PostsToUpdate[0].Categories(), updatedPost.Categories)
+}
+
 func TestNewBlog(t *testing.T) {
 	t.Parallel()
 
@@ -145,7 +443,7 @@ func TestNewBlog(t *testing.T) {
 	syncService := job.NewSyncService(repo, feedFetcher)
 
 	// sync a new blog
-	blog, err := syncService.SyncBlog(feedBlog.FeedURL)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify blog data
@@ -155,7 +453,7 @@ func TestNewBlog(t *testing.T) {
 	test.AssertEqual(t, blog.FeedURL(), feedBlog.FeedURL)
 
 	// fetch posts and verify count
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -189,7 +487,7 @@ func TestExistingBlog(t *testing.T) {
 	syncService := job.NewSyncService(repo, feedFetcher)
 
 	// sync a new blog
-	blog, err := syncService.SyncBlog(feedBlog.FeedURL)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify blog data
@@ -199,7 +497,7 @@ func TestExistingBlog(t *testing.T) {
 	test.AssertEqual(t, blog.FeedURL(), feedBlog.FeedURL)
 
 	// fetch posts and verify count (should be none)
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 0)
 
@@ -219,11 +517,11 @@ func TestExistingBlog(t *testing.T) {
 	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
 
 	// sync the blog again
-	_, err = syncService.SyncBlog(feedBlog.FeedURL)
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// fetch posts and verify count
-	posts, err = repo.Post().ListByBlog(blog)
+	posts, err = repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -247,8 +545,230 @@ func TestUnreachableFeed(t *testing.T) {
 
 	syncService := job.NewSyncService(repo, feedFetcher)
 
-	_, err := syncService.SyncBlog(feedURL)
+	_, err := syncService.SyncBlog(context.Background(), feedURL)
+	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+}
+
+func TestSyncFailureRecorded(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed, StatusCode: 200},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// add a blog (sync now)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.IsFailing(), false)
+	test.AssertEqual(t, blog.LastSyncStatusCode(), 200)
+
+	// make the feed unreachable and sync again
+	delete(feeds, feedBlog.FeedURL)
+
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertErrorIs(t, err, feed.ErrUnreachableFeed)
+
+	// the failure should be saved
+	got, err := repo.Blog().Read(context.Background(), blog.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.SyncFailures(), 1)
+	test.AssertEqual(t, got.LastSyncError(), model.SyncErrorUnreachable)
+
+	failing, err := repo.Blog().ListFailing(context.Background())
+	test.AssertNilError(t, err)
+
+	var failingIDs []uuid.UUID
+	for _, b := range failing {
+		failingIDs = append(failingIDs, b.ID())
+	}
+	test.AssertSliceContains(t, failingIDs, blog.ID())
+
+	// make the feed reachable again and sync (the failures should be cleared)
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed, StatusCode: 200}
+
+	blog, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.IsFailing(), false)
+	test.AssertEqual(t, blog.LastSyncError(), model.SyncErrorNone)
+}
+
+func TestSyncFeedMoved(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// add a blog (sync now)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// the feed gets temporarily redirected (the feed URL should stay the same)
+	tempURL := test.RandomURL(20)
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{
+		Feed:      atomFeed,
+		Redirects: []feed.Redirect{{StatusCode: 302, URL: tempURL}},
+	}
+
+	blog, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.FeedURL(), feedBlog.FeedURL)
+
+	// the feed permanently moves (the feed URL should be updated)
+	movedURL := test.RandomURL(20)
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{
+		Feed:      atomFeed,
+		Redirects: []feed.Redirect{{StatusCode: 301, URL: movedURL}},
+	}
+
+	movedBlog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, movedBlog.ID(), blog.ID())
+	test.AssertEqual(t, movedBlog.FeedURL(), movedURL)
+
+	got, err := repo.Blog().ReadByFeedURL(context.Background(), movedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.ID(), blog.ID())
+}
+
+func TestSyncFeedMovedMerge(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	oldFeedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+		Posts: []feed.Post{
+			{
+				URL:         test.RandomURL(20),
+				Title:       test.RandomString(20),
+				PublishedAt: time.Now(),
+			},
+		},
+	}
+	newFeedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+
+	oldAtomFeed, err := feedMock.GenerateAtomFeed(oldFeedBlog)
+	test.AssertNilError(t, err)
+
+	newAtomFeed, err := feedMock.GenerateAtomFeed(newFeedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		oldFeedBlog.FeedURL: {Feed: oldAtomFeed},
+		newFeedBlog.FeedURL: {Feed: newAtomFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// add both blogs (and follow the old one)
+	oldBlog, err := syncService.SyncBlog(context.Background(), oldFeedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	newBlog, err := syncService.SyncBlog(context.Background(), newFeedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, oldBlog)
+
+	// the old feed permanently moves to the new one
+	feeds[oldFeedBlog.FeedURL] = feed.FetchFeedResponse{
+		Feed:      newAtomFeed,
+		Redirects: []feed.Redirect{{StatusCode: 308, URL: newFeedBlog.FeedURL}},
+	}
+
+	mergedBlog, err := syncService.SyncBlog(context.Background(), oldFeedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, mergedBlog.ID(), newBlog.ID())
+
+	// the old blog should be gone
+	_, err = repo.Blog().Read(context.Background(), oldBlog.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+
+	// its posts should belong to the new blog
+	post, err := repo.Post().ReadByURL(context.Background(), oldFeedBlog.Posts[0].URL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, post.BlogID(), newBlog.ID())
+
+	// and its followers should follow the new blog
+	err = repo.AccountBlog().Create(context.Background(), account, newBlog)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestSyncNewFeedMoved(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	// the feed is added using its old URL
+	oldURL := test.RandomURL(20)
+	feeds := map[string]feed.FetchFeedResponse{
+		oldURL: {
+			Feed:      atomFeed,
+			Redirects: []feed.Redirect{{StatusCode: 301, URL: feedBlog.FeedURL}},
+		},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// the blog should be added under its new URL
+	blog, err := syncService.SyncBlog(context.Background(), oldURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.FeedURL(), feedBlog.FeedURL)
+
+	// adding it again by the old URL should find the same blog
+	sameBlog, err := syncService.SyncBlog(context.Background(), oldURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, sameBlog.ID(), blog.ID())
 }
 
 func TestSyncCooldown(t *testing.T) {
@@ -274,24 +794,77 @@ func TestSyncCooldown(t *testing.T) {
 	syncService := job.NewSyncService(repo, feedFetcher)
 
 	// add a blog (sync now)
-	blog, err := syncService.SyncBlog(feedBlog.FeedURL)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// capture the blog's current syncedAt time
 	syncedAt := blog.SyncedAt()
 
 	// sync all blogs
-	err = syncService.SyncAllBlogs()
+	err = syncService.SyncAllBlogs(context.Background())
 	test.AssertNilError(t, err)
 
 	// refetch the blog's data
-	blog, err = repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err = repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// syncedAt should not have changed
 	test.AssertEqual(t, blog.SyncedAt(), syncedAt)
 }
 
+func TestSyncSchedule(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// add a blog (sync now)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.SyncInterval(), model.DefaultSyncInterval)
+
+	// sync again with no new posts (the interval should grow)
+	blog, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.SyncInterval(), model.DefaultSyncInterval*3/2)
+
+	// add a new post to the feed and sync again (the interval should shrink)
+	feedBlog.Posts = append(feedBlog.Posts, feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		PublishedAt: time.Now(),
+	})
+	atomFeed, err = feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
+
+	blog, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, blog.SyncInterval(), model.DefaultSyncInterval*3/4)
+
+	// the new schedule should be saved
+	got, err := repo.Blog().Read(context.Background(), blog.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.SyncInterval(), blog.SyncInterval())
+	test.AssertEqual(t, got.NextSyncAt().Equal(blog.NextSyncAt()), true)
+}
+
 func TestUpdatePostContent(t *testing.T) {
 	t.Parallel()
 
@@ -321,11 +894,11 @@ func TestUpdatePostContent(t *testing.T) {
 	syncService := job.NewSyncService(repo, feedFetcher)
 
 	// sync a new blog
-	blog, err := syncService.SyncBlog(feedBlog.FeedURL)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// fetch posts and verify count
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -344,11 +917,11 @@ func TestUpdatePostContent(t *testing.T) {
 	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
 
 	// sync the blog again
-	_, err = syncService.SyncBlog(feedBlog.FeedURL)
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// refetch posts and verify count
-	posts, err = repo.Post().ListByBlog(blog)
+	posts, err = repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, len(posts), 1)
 
@@ -357,6 +930,231 @@ func TestUpdatePostContent(t *testing.T) {
 	test.AssertEqual(t, post.Content(), content)
 }
 
+// Saved posts should remain saved after their blog gets synced again.
+func TestSavedPostSurvivesSync(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	feedPost := feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		PublishedAt: time.Now(),
+	}
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+		Posts:   []feed.Post{feedPost},
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// sync a new blog
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(posts), 1)
+
+	// save the post
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountPost(t, repo, account, posts[0])
+
+	// update the post's title and content
+	feedBlog.Posts[0].Title = test.RandomString(20)
+	feedBlog.Posts[0].Content = "content about foo"
+
+	// regenerate the feed
+	atomFeed, err = feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
+
+	// sync the blog again
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// the post should still be saved (and reflect the updated title)
+	articles, err := qry.ListSavedArticlesByAccount(context.Background(), account, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+	test.AssertEqual(t, articles[0].ID, posts[0].ID())
+	test.AssertEqual(t, articles[0].Title, feedBlog.Posts[0].Title)
+}
+
+func TestNewPostEnqueuesWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// sync a new blog
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// follow the blog (with a webhook) from one account but not another
+	follower := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, follower, blog)
+	followerWebhook := test.CreateWebhook(t, repo, follower)
+
+	otherWebhook := test.CreateWebhook(t, repo, test.CreateAccount(t, repo))
+
+	// add a post to the feed blog
+	feedPost := feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		PublishedAt: time.Now(),
+	}
+	feedBlog.Posts = append(feedBlog.Posts, feedPost)
+
+	// regenerate the feed
+	atomFeed, err = feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
+
+	// sync the blog again
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// the follower's webhook should have a delivery queued up for the new post
+	deliveries, err := repo.WebhookDelivery().ListByWebhook(context.Background(), followerWebhook, 10)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(deliveries), 1)
+	test.AssertEqual(t, deliveries[0].Event(), webhook.EventPostCreated)
+	test.AssertStringContains(t, deliveries[0].Payload(), feedPost.URL)
+
+	// but the other account's webhook should not
+	deliveries, err = repo.WebhookDelivery().ListByWebhook(context.Background(), otherWebhook, 10)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(deliveries), 0)
+
+	// syncing again (with no new posts) shouldn't queue up anything else
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	deliveries, err = repo.WebhookDelivery().ListByWebhook(context.Background(), followerWebhook, 10)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(deliveries), 1)
+}
+
+func TestSyncDiscoversHub(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+		HubURL:  test.RandomURL(20),
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// sync a new blog
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// the blog's hub should be tracked (but not yet active)
+	subscription, err := repo.WebSubSubscription().ReadByBlog(context.Background(), blog)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, subscription.HubURL(), feedBlog.HubURL)
+	test.AssertEqual(t, subscription.TopicURL(), feedBlog.FeedURL)
+	test.AssertEqual(t, subscription.IsActive(timeutil.Now()), false)
+
+	// stop advertising the hub
+	feedBlog.HubURL = ""
+
+	// regenerate the feed
+	atomFeed, err = feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds[feedBlog.FeedURL] = feed.FetchFeedResponse{Feed: atomFeed}
+
+	// sync the blog again
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// the subscription should be gone (so the blog goes back to being polled)
+	_, err = repo.WebSubSubscription().ReadByBlog(context.Background(), blog)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
+
+func TestSyncBlogContent(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+
+	syncService := job.NewSyncService(repo, feedMock.NewFeedFetcher(nil))
+
+	feedPost := feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		PublishedAt: time.Now(),
+	}
+	feedBlog := feed.Blog{
+		Title:   blog.Title(),
+		SiteURL: blog.SiteURL(),
+		FeedURL: blog.FeedURL(),
+		Posts:   []feed.Post{feedPost},
+	}
+
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	// push the feed content (instead of fetching it)
+	err = syncService.SyncBlogContent(context.Background(), blog, atomFeed)
+	test.AssertNilError(t, err)
+
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(posts), 1)
+	test.AssertEqual(t, posts[0].URL(), feedPost.URL)
+}
+
 // Doesn't wipe out existing cache headers if none are returned.
 func TestCacheHeaderOverwrite(t *testing.T) {
 	t.Parallel()
@@ -381,21 +1179,21 @@ func TestCacheHeaderOverwrite(t *testing.T) {
 	syncService := job.NewSyncService(repo, feedFetcher)
 
 	// sync a new blog
-	blog, err := syncService.SyncBlog(feedBlog.FeedURL)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// update the blog's ETag and LastModified to something non-empty
 	blog.SetETag("foo")
 	blog.SetLastModified("bar")
-	err = repo.Blog().Update(blog)
+	err = repo.Blog().Update(context.Background(), blog)
 	test.AssertNilError(t, err)
 
 	// sync the blog again (will see empty ETag and LastModified values)
-	_, err = syncService.SyncBlog(feedBlog.FeedURL)
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// refetch the blog
-	blog, err = repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err = repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify that the existing ETag and LastModified values haven't been wiped out
@@ -431,7 +1229,7 @@ func TestCacheHeaderUpdate(t *testing.T) {
 	syncService := job.NewSyncService(repo, feedFetcher)
 
 	// sync a new blog
-	blog, err := syncService.SyncBlog(feedBlog.FeedURL)
+	blog, err := syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, blog.ETag(), "etag")
 	test.AssertEqual(t, blog.LastModified(), "lastModified")
@@ -448,14 +1246,57 @@ func TestCacheHeaderUpdate(t *testing.T) {
 	syncService = job.NewSyncService(repo, feedFetcher)
 
 	// sync the blog again (will see new ETag and LastModified values)
-	_, err = syncService.SyncBlog(feedBlog.FeedURL)
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// refetch the blog
-	blog, err = repo.Blog().ReadByFeedURL(feedBlog.FeedURL)
+	blog, err = repo.Blog().ReadByFeedURL(context.Background(), feedBlog.FeedURL)
 	test.AssertNilError(t, err)
 
 	// verify that the ETag and LastModified values got updated
 	test.AssertEqual(t, blog.ETag(), "other etag")
 	test.AssertEqual(t, blog.LastModified(), "other lastModified")
 }
+
+func TestSyncPostTags(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedPost := feed.Post{
+		URL:         test.RandomURL(20),
+		Title:       test.RandomString(20),
+		Content:     test.RandomString(200),
+		PublishedAt: time.Now(),
+		Categories:  []string{"Foo", " foo ", "Bar  Baz"},
+	}
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+		Posts:   []feed.Post{feedPost},
+	}
+
+	jsonFeed, err := feedMock.GenerateJSONFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: jsonFeed},
+	}
+	feedFetcher := feedMock.NewFeedFetcher(feeds)
+
+	syncService := job.NewSyncService(repo, feedFetcher)
+
+	// sync a new blog
+	_, err = syncService.SyncBlog(context.Background(), feedBlog.FeedURL)
+	test.AssertNilError(t, err)
+
+	// the post's categories should be stored as (normalized) tags
+	post, err := repo.Post().ReadByURL(context.Background(), feedPost.URL)
+	test.AssertNilError(t, err)
+
+	names, err := repo.PostTag().ListByPost(context.Background(), post)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, names, []string{"bar baz", "foo"})
+}
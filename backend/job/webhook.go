@@ -0,0 +1,137 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/webhook"
+)
+
+const (
+	// Check the queue for pending webhook deliveries every WebhookInterval.
+	WebhookInterval = 1 * time.Minute
+
+	// How many webhook deliveries to attempt at once.
+	WebhookConcurrency = 8
+
+	// Attempt (at most) this many webhook deliveries per run.
+	WebhookBatchSize = 100
+)
+
+type WebhookService struct {
+	mu            sync.Mutex
+	repo          *repository.Repository
+	webhookSender webhook.WebhookSender
+}
+
+func NewWebhookService(repo *repository.Repository, webhookSender webhook.WebhookSender) *WebhookService {
+	s := WebhookService{
+		repo:          repo,
+		webhookSender: webhookSender,
+	}
+	return &s
+}
+
+func (s *WebhookService) Run(ctx context.Context) error {
+	// Attempt any pending deliveries at service startup.
+	err := s.DeliverWebhooks(ctx)
+	if err != nil {
+		slog.Error("error delivering webhooks",
+			"error", err.Error(),
+		)
+	}
+
+	// Then run again every "interval" until stopped (by the context being canceled).
+	ticker := time.NewTicker(WebhookInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping webhook service")
+			slog.Info("stopped webhook service")
+			return nil
+		case <-ticker.C:
+			err := s.DeliverWebhooks(ctx)
+			if err != nil {
+				slog.Error("error delivering webhooks",
+					"error", err.Error(),
+				)
+			}
+		}
+	}
+}
+
+// Attempt every pending delivery that is due. Failed deliveries remain in the
+// queue (with backoff) until they either succeed or run out of attempts.
+func (s *WebhookService) DeliverWebhooks(ctx context.Context) error {
+	// ensure only one run happens at a time
+	if !s.mu.TryLock() {
+		slog.Info("webhook delivery already in progress")
+		return nil
+	}
+	defer s.mu.Unlock()
+
+	now := timeutil.Now()
+	deliveries, err := s.repo.WebhookDelivery().ListDue(ctx, now, WebhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	ParallelForEach(ctx, WebhookConcurrency, deliveries, func(delivery *model.WebhookDelivery) {
+		err := s.DeliverWebhook(ctx, delivery)
+		if err != nil {
+			slog.Warn("error delivering webhook",
+				"error", err.Error(),
+				"webhook_id", delivery.WebhookID(),
+				"webhook_delivery_id", delivery.ID(),
+			)
+		}
+	})
+
+	return nil
+}
+
+// Make a single delivery attempt and record its outcome.
+func (s *WebhookService) DeliverWebhook(ctx context.Context, delivery *model.WebhookDelivery) error {
+	w, err := s.repo.Webhook().Read(ctx, delivery.WebhookID())
+	if err != nil {
+		return err
+	}
+
+	// Each attempt is signed with its own timestamp (so retries aren't rejected as stale).
+	timestamp := timeutil.Now()
+	req := webhook.SendWebhookRequest{
+		URL:        w.URL(),
+		DeliveryID: delivery.ID(),
+		Event:      delivery.Event(),
+		Payload:    delivery.Payload(),
+		Timestamp:  timestamp,
+		Signature:  webhook.Sign(w.Secret(), timestamp, delivery.Payload()),
+	}
+	resp, err := s.webhookSender.SendWebhook(ctx, req)
+
+	now := timeutil.Now()
+	switch {
+	case err != nil:
+		delivery.RecordFailure(now, 0, err.Error())
+	case !resp.IsSuccess():
+		delivery.RecordFailure(now, resp.StatusCode, "unexpected status code")
+	default:
+		delivery.RecordSuccess(resp.StatusCode)
+	}
+
+	slog.Info("webhook delivery attempted",
+		"webhook_id", w.ID(),
+		"webhook_delivery_id", delivery.ID(),
+		"webhook_delivery_status", delivery.Status(),
+		"webhook_delivery_attempts", delivery.Attempts(),
+	)
+
+	return s.repo.WebhookDelivery().Update(ctx, delivery)
+}
@@ -0,0 +1,94 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/webhook"
+	webhookMock "github.com/theandrew168/bloggulus/backend/webhook/mock"
+)
+
+func TestDeliverWebhook(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	w := test.CreateWebhook(t, repo, account)
+	delivery := test.CreateWebhookDelivery(t, repo, w)
+
+	webhookSender := webhookMock.NewWebhookSender(map[string]int{
+		w.URL(): 200,
+	})
+	s := job.NewWebhookService(repo, webhookSender)
+
+	err := s.DeliverWebhook(context.Background(), delivery)
+	test.AssertNilError(t, err)
+
+	// The request should be signed with the webhook's secret.
+	requests := webhookSender.RequestsTo(w.URL())
+	test.AssertEqual(t, len(requests), 1)
+	test.AssertEqual(t, requests[0].DeliveryID, delivery.ID())
+	test.AssertEqual(t, requests[0].Payload, delivery.Payload())
+	test.AssertEqual(t, requests[0].Signature, webhook.Sign(w.Secret(), requests[0].Timestamp, delivery.Payload()))
+
+	got, err := repo.WebhookDelivery().Read(context.Background(), delivery.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.Status(), model.WebhookDeliveryStatusSucceeded)
+	test.AssertEqual(t, got.Attempts(), 1)
+	test.AssertEqual(t, got.LastStatusCode(), 200)
+}
+
+func TestDeliverWebhookRetry(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	w := test.CreateWebhook(t, repo, account)
+	delivery := test.CreateWebhookDelivery(t, repo, w)
+
+	webhookSender := webhookMock.NewWebhookSender(map[string]int{
+		w.URL(): 503,
+	})
+	s := job.NewWebhookService(repo, webhookSender)
+
+	err := s.DeliverWebhook(context.Background(), delivery)
+	test.AssertNilError(t, err)
+
+	// The delivery should stay in the queue (but not be due again right away).
+	got, err := repo.WebhookDelivery().Read(context.Background(), delivery.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.Status(), model.WebhookDeliveryStatusPending)
+	test.AssertEqual(t, got.Attempts(), 1)
+	test.AssertEqual(t, got.LastStatusCode(), 503)
+	test.AssertEqual(t, got.IsDue(got.UpdatedAt()), false)
+}
+
+func TestDeliverWebhookUnreachable(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	w := test.CreateWebhook(t, repo, account)
+	delivery := test.CreateWebhookDelivery(t, repo, w)
+
+	// The mock sender treats unknown URLs as unreachable.
+	webhookSender := webhookMock.NewWebhookSender(nil)
+	s := job.NewWebhookService(repo, webhookSender)
+
+	err := s.DeliverWebhook(context.Background(), delivery)
+	test.AssertNilError(t, err)
+
+	got, err := repo.WebhookDelivery().Read(context.Background(), delivery.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.Status(), model.WebhookDeliveryStatusPending)
+	test.AssertEqual(t, got.LastError(), webhook.ErrUnreachableWebhook.Error())
+}
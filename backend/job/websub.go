@@ -0,0 +1,132 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/websub"
+)
+
+const (
+	// Check for WebSub subscriptions that need to be (re-)requested every WebSubInterval.
+	WebSubInterval = 15 * time.Minute
+)
+
+// FilterRenewableSubscriptions takes a list of subscriptions and returns only those that need to be requested.
+func FilterRenewableSubscriptions(subscriptions []*model.WebSubSubscription, now time.Time) []*model.WebSubSubscription {
+	var renewableSubscriptions []*model.WebSubSubscription
+	for _, subscription := range subscriptions {
+		if subscription.NeedsRenewal(now) {
+			renewableSubscriptions = append(renewableSubscriptions, subscription)
+		}
+	}
+	return renewableSubscriptions
+}
+
+// Build the URL that hubs use to verify a subscription and push content to it.
+func WebSubCallbackURL(siteURL string, subscription *model.WebSubSubscription) string {
+	return siteURL + "/websub/" + subscription.ID().String()
+}
+
+type WebSubService struct {
+	repo       *repository.Repository
+	subscriber websub.Subscriber
+	siteURL    string
+}
+
+func NewWebSubService(repo *repository.Repository, subscriber websub.Subscriber, siteURL string) *WebSubService {
+	s := WebSubService{
+		repo:       repo,
+		subscriber: subscriber,
+		siteURL:    siteURL,
+	}
+	return &s
+}
+
+func (s *WebSubService) Run(ctx context.Context) error {
+	// Request any new or expiring subscriptions at service startup.
+	err := s.RenewSubscriptions(ctx)
+	if err != nil {
+		slog.Error("error renewing websub subscriptions",
+			"error", err.Error(),
+		)
+	}
+
+	// Then run again every "interval" until stopped (by the context being canceled).
+	ticker := time.NewTicker(WebSubInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping websub service")
+			slog.Info("stopped websub service")
+			return nil
+		case <-ticker.C:
+			err := s.RenewSubscriptions(ctx)
+			if err != nil {
+				slog.Error("error renewing websub subscriptions",
+					"error", err.Error(),
+				)
+			}
+		}
+	}
+}
+
+// Ask hubs to (re-)subscribe us to any topics whose leases are missing or about
+// to lapse. The leases themselves get recorded when the hubs verify the requests.
+func (s *WebSubService) RenewSubscriptions(ctx context.Context) error {
+	subscriptions, err := s.repo.WebSubSubscription().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := timeutil.Now()
+	for _, subscription := range FilterRenewableSubscriptions(subscriptions, now) {
+		err := s.RenewSubscription(ctx, subscription, now)
+		if err != nil {
+			slog.Warn("error renewing websub subscription",
+				"error", err.Error(),
+				"websub_subscription_id", subscription.ID(),
+				"hub_url", subscription.HubURL(),
+				"topic_url", subscription.TopicURL(),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *WebSubService) RenewSubscription(ctx context.Context, subscription *model.WebSubSubscription, now time.Time) error {
+	// Record the attempt up front so that an unreachable hub isn't retried until
+	// the cooldown has passed.
+	subscription.SetRequestedAt(now)
+	err := s.repo.WebSubSubscription().Update(ctx, subscription)
+	if err != nil {
+		return err
+	}
+
+	req := websub.SubscribeRequest{
+		HubURL:       subscription.HubURL(),
+		TopicURL:     subscription.TopicURL(),
+		CallbackURL:  WebSubCallbackURL(s.siteURL, subscription),
+		Secret:       subscription.Secret(),
+		LeaseSeconds: int(model.WebSubLeaseDuration.Seconds()),
+	}
+	err = s.subscriber.Subscribe(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("websub subscription requested",
+		"websub_subscription_id", subscription.ID(),
+		"hub_url", subscription.HubURL(),
+		"topic_url", subscription.TopicURL(),
+	)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	websubMock "github.com/theandrew168/bloggulus/backend/websub/mock"
+)
+
+func TestFilterRenewableSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	blog := test.NewBlog(t)
+
+	// never requested (and no lease)
+	newSubscription := test.NewWebSubSubscription(t, blog)
+
+	// requested recently (still waiting on the hub)
+	pendingSubscription := test.NewWebSubSubscription(t, blog)
+	pendingSubscription.SetRequestedAt(now.Add(-1 * time.Minute))
+
+	// lease is about to expire
+	expiringSubscription := test.NewWebSubSubscription(t, blog)
+	expiringSubscription.SetRequestedAt(now.Add(-model.WebSubLeaseDuration))
+	expiringSubscription.SetLease(now, 1*time.Hour)
+
+	// lease has plenty of time left
+	activeSubscription := test.NewWebSubSubscription(t, blog)
+	activeSubscription.SetRequestedAt(now.Add(-1 * time.Hour))
+	activeSubscription.SetLease(now, model.WebSubLeaseDuration)
+
+	subscriptions := []*model.WebSubSubscription{
+		newSubscription,
+		pendingSubscription,
+		expiringSubscription,
+		activeSubscription,
+	}
+
+	renewableSubscriptions := job.FilterRenewableSubscriptions(subscriptions, now)
+	test.AssertEqual(t, len(renewableSubscriptions), 2)
+
+	var renewableSubscriptionIDs []uuid.UUID
+	for _, subscription := range renewableSubscriptions {
+		renewableSubscriptionIDs = append(renewableSubscriptionIDs, subscription.ID())
+	}
+
+	test.AssertSliceContains(t, renewableSubscriptionIDs, newSubscription.ID())
+	test.AssertSliceContains(t, renewableSubscriptionIDs, expiringSubscription.ID())
+}
+
+func TestRenewSubscription(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	subscription := test.CreateWebSubSubscription(t, repo, blog)
+
+	subscriber := websubMock.NewSubscriber()
+	s := job.NewWebSubService(repo, subscriber, "https://bloggulus.com")
+
+	now := timeutil.Now()
+	err := s.RenewSubscription(context.Background(), subscription, now)
+	test.AssertNilError(t, err)
+
+	// The hub should be asked to send updates to the subscription's callback.
+	requests := subscriber.RequestsTo(subscription.HubURL())
+	test.AssertEqual(t, len(requests), 1)
+	test.AssertEqual(t, requests[0].TopicURL, subscription.TopicURL())
+	test.AssertEqual(t, requests[0].CallbackURL, "https://bloggulus.com/websub/"+subscription.ID().String())
+	test.AssertEqual(t, requests[0].Secret, subscription.Secret())
+
+	// And the request shouldn't be repeated until the cooldown has passed.
+	got, err := repo.WebSubSubscription().Read(context.Background(), subscription.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.RequestedAt().Equal(now), true)
+	test.AssertEqual(t, got.NeedsRenewal(now), false)
+}
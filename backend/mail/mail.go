@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidMessage = errors.New("mail: invalid message")
+)
+
+// An email message with both plain text and HTML bodies. Mail clients will
+// pick whichever version they prefer to display.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Mailer interface {
+	SendMail(ctx context.Context, message Message) error
+}
@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/theandrew168/bloggulus/backend/mail"
+)
+
+// ensure Mailer interface is satisfied
+var _ mail.Mailer = (*Mailer)(nil)
+
+// An in-memory mailer that holds onto every message it is asked to send.
+type Mailer struct {
+	mu       sync.Mutex
+	messages []mail.Message
+}
+
+func NewMailer() *Mailer {
+	m := Mailer{}
+	return &m
+}
+
+func (m *Mailer) SendMail(ctx context.Context, message mail.Message) error {
+	if message.To == "" {
+		return mail.ErrInvalidMessage
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+// List all messages sent so far (in the order they were sent).
+func (m *Mailer) Messages() []mail.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]mail.Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// List all messages sent to a specific address.
+func (m *Mailer) MessagesTo(to string) []mail.Message {
+	var messages []mail.Message
+	for _, message := range m.Messages() {
+		if message.To == to {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,100 @@
+package smtp_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"os"
+	"testing"
+	"time"
+
+	bloggulusMail "github.com/theandrew168/bloggulus/backend/mail"
+	"github.com/theandrew168/bloggulus/backend/mail/smtp"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestEncodeMessage(t *testing.T) {
+	t.Parallel()
+
+	message := bloggulusMail.Message{
+		To:      "foo@example.com",
+		Subject: "Your daily digest ✨",
+		Text:    "Hello, world!",
+		HTML:    "<p>Hello, world!</p>",
+	}
+
+	data, err := smtp.EncodeMessage("bloggulus@example.com", message.To, message)
+	test.AssertNilError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, msg.Header.Get("To"), message.To)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, subject, message.Subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, mediaType, "multipart/alternative")
+
+	// The multipart reader transparently decodes quoted-printable parts.
+	var bodies []string
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		test.AssertNilError(t, err)
+
+		body, err := io.ReadAll(part)
+		test.AssertNilError(t, err)
+
+		bodies = append(bodies, string(body))
+	}
+
+	test.AssertEqual(t, bodies, []string{message.Text, message.HTML})
+}
+
+func TestSendMailTimeout(t *testing.T) {
+	t.Parallel()
+
+	// A mail server that accepts a connection but never says anything.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNilError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		io.Copy(io.Discard, conn)
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	test.AssertNilError(t, err)
+
+	mailer := smtp.NewMailer(host, port, "", "", "bloggulus@example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	message := bloggulusMail.Message{
+		To:      "foo@example.com",
+		Subject: "Hello",
+		Text:    "Hello, world!",
+	}
+
+	// The send should give up once the context's deadline passes.
+	err = mailer.SendMail(ctx, message)
+	test.AssertErrorIs(t, err, os.ErrDeadlineExceeded)
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/random"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// Prefix for plaintext API tokens (makes them easier to identify if leaked).
+const APITokenPrefix = "bloggulus_"
+
+type APIToken struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	name      string
+	hash      string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// Generate a random, crypto-safe API token.
+func GenerateAPIToken() (string, error) {
+	token, err := random.BytesBase64(32)
+	if err != nil {
+		return "", err
+	}
+
+	return APITokenPrefix + token, nil
+}
+
+// Generate a SHA-256 hash of a plaintext API token (same scheme as sessions).
+func HashAPIToken(token string) string {
+	hashBytes := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashBytes[:])
+}
+
+func NewAPIToken(account *Account, name string) (*APIToken, string, error) {
+	if name == "" {
+		return nil, "", fmt.Errorf("api token: invalid name")
+	}
+
+	now := timeutil.Now()
+
+	token, err := GenerateAPIToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Only the hash gets stored: the plaintext token is shown to the user once.
+	apiToken := APIToken{
+		id:        uuid.New(),
+		accountID: account.ID(),
+		name:      name,
+		hash:      HashAPIToken(token),
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &apiToken, token, nil
+}
+
+func LoadAPIToken(id, accountID uuid.UUID, name, hash string, createdAt, updatedAt time.Time) *APIToken {
+	apiToken := APIToken{
+		id:        id,
+		accountID: accountID,
+		name:      name,
+		hash:      hash,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &apiToken
+}
+
+func (t *APIToken) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *APIToken) AccountID() uuid.UUID {
+	return t.accountID
+}
+
+func (t *APIToken) Name() string {
+	return t.name
+}
+
+func (t *APIToken) Hash() string {
+	return t.hash
+}
+
+func (t *APIToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *APIToken) UpdatedAt() time.Time {
+	return t.updatedAt
+}
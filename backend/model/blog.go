@@ -9,8 +9,25 @@ import (
 )
 
 const (
-	// Ensure that a post doesn't get synced more than once every SyncCooldown.
-	SyncCooldown = 2 * time.Hour
+	// New blogs start out being synced once every DefaultSyncInterval.
+	DefaultSyncInterval = 2 * time.Hour
+
+	// A blog's sync interval adapts (based on how often it posts) within these bounds.
+	MinSyncInterval = 30 * time.Minute
+	MaxSyncInterval = 24 * time.Hour
+
+	// Blogs that keep failing to sync are backed off (up to MaxSyncBackoff).
+	MaxSyncBackoff = 7 * 24 * time.Hour
+)
+
+// What kind of problem (if any) occurred during a blog's most recent sync.
+type SyncError string
+
+const (
+	SyncErrorNone        SyncError = ""
+	SyncErrorUnreachable SyncError = "unreachable"
+	SyncErrorParse       SyncError = "parse"
+	SyncErrorTimeout     SyncError = "timeout"
 )
 
 type Blog struct {
@@ -22,6 +39,22 @@ type Blog struct {
 	lastModified string
 	syncedAt     time.Time
 
+	// How often the blog gets synced and when it is next due.
+	syncInterval time.Duration
+	nextSyncAt   time.Time
+
+	// The outcome of the most recent sync (and how many in a row have failed).
+	lastSyncStatusCode int
+	lastSyncError      SyncError
+	syncFailures       int
+	lastSyncSuccessAt  time.Time
+
+	// When the blog's feed went away for good (zero if it hasn't).
+	retiredAt time.Time
+
+	// Fetch the full content of new posts (for feeds that only include summaries).
+	fetchFullContent bool
+
 	createdAt time.Time
 	updatedAt time.Time
 }
@@ -37,13 +70,18 @@ func NewBlog(feedURL, siteURL, title, etag, lastModified string, syncedAt time.T
 		lastModified: lastModified,
 		syncedAt:     syncedAt,
 
+		syncInterval: DefaultSyncInterval,
+		nextSyncAt:   syncedAt.Add(DefaultSyncInterval),
+
+		lastSyncSuccessAt: syncedAt,
+
 		createdAt: now,
 		updatedAt: now,
 	}
 	return &blog, nil
 }
 
-func LoadBlog(id uuid.UUID, feedURL, siteURL, title, etag, lastModified string, syncedAt, createdAt, updatedAt time.Time) *Blog {
+func LoadBlog(id uuid.UUID, feedURL, siteURL, title, etag, lastModified string, syncedAt time.Time, syncInterval time.Duration, nextSyncAt time.Time, lastSyncStatusCode int, lastSyncError SyncError, syncFailures int, lastSyncSuccessAt, retiredAt time.Time, fetchFullContent bool, createdAt, updatedAt time.Time) *Blog {
 	blog := Blog{
 		id:           id,
 		feedURL:      feedURL,
@@ -53,6 +91,18 @@ func LoadBlog(id uuid.UUID, feedURL, siteURL, title, etag, lastModified string,
 		lastModified: lastModified,
 		syncedAt:     syncedAt,
 
+		syncInterval: syncInterval,
+		nextSyncAt:   nextSyncAt,
+
+		lastSyncStatusCode: lastSyncStatusCode,
+		lastSyncError:      lastSyncError,
+		syncFailures:       syncFailures,
+		lastSyncSuccessAt:  lastSyncSuccessAt,
+
+		retiredAt: retiredAt,
+
+		fetchFullContent: fetchFullContent,
+
 		createdAt: createdAt,
 		updatedAt: updatedAt,
 	}
@@ -67,6 +117,12 @@ func (b *Blog) FeedURL() string {
 	return b.feedURL
 }
 
+// Update the blog's feed URL (like when its feed has permanently moved).
+func (b *Blog) SetFeedURL(feedURL string) error {
+	b.feedURL = feedURL
+	return nil
+}
+
 func (b *Blog) SiteURL() string {
 	return b.siteURL
 }
@@ -101,8 +157,129 @@ func (b *Blog) SetSyncedAt(syncedAt time.Time) {
 	b.syncedAt = syncedAt
 }
 
+func (b *Blog) SyncInterval() time.Duration {
+	return b.syncInterval
+}
+
+func (b *Blog) NextSyncAt() time.Time {
+	return b.nextSyncAt
+}
+
 func (b *Blog) CanBeSynced(now time.Time) bool {
-	return b.syncedAt.Add(SyncCooldown).Before(now)
+	if b.IsRetired() {
+		return false
+	}
+
+	return !b.nextSyncAt.After(now)
+}
+
+// Record that the blog is being synced. The next sync gets scheduled using the current
+// interval so that a failed (or slow) sync doesn't cause the blog to be retried right away.
+func (b *Blog) StartSync(now time.Time) {
+	b.syncedAt = now
+	b.nextSyncAt = now.Add(b.syncInterval)
+}
+
+// Adjust the blog's sync interval based on the outcome of a sync and schedule the next one.
+// The interval is halved when new posts are found and grows by half when nothing changed.
+// Update hints from the blog's feed (like an RSS <ttl>) or server (like Cache-Control) are
+// respected by never syncing more often than minInterval.
+func (b *Blog) ScheduleSync(now time.Time, foundNewPosts bool, minInterval time.Duration) {
+	interval := b.syncInterval
+	if foundNewPosts {
+		interval = interval / 2
+	} else {
+		interval = interval + interval/2
+	}
+
+	interval = max(interval, minInterval)
+	interval = min(max(interval, MinSyncInterval), MaxSyncInterval)
+
+	b.syncInterval = interval
+	b.nextSyncAt = now.Add(interval)
+}
+
+// Push back the blog's next sync (like when its server responds with a Retry-After header).
+// The delay is capped at MaxSyncInterval so that a blog is never forgotten about.
+func (b *Blog) DelaySync(now time.Time, delay time.Duration) {
+	delay = min(delay, MaxSyncInterval)
+
+	nextSyncAt := now.Add(delay)
+	if nextSyncAt.After(b.nextSyncAt) {
+		b.nextSyncAt = nextSyncAt
+	}
+}
+
+func (b *Blog) LastSyncStatusCode() int {
+	return b.lastSyncStatusCode
+}
+
+func (b *Blog) LastSyncError() SyncError {
+	return b.lastSyncError
+}
+
+func (b *Blog) SyncFailures() int {
+	return b.syncFailures
+}
+
+// When the blog was last synced successfully (zero if it never has been).
+func (b *Blog) LastSyncSuccessAt() time.Time {
+	return b.lastSyncSuccessAt
+}
+
+func (b *Blog) IsFailing() bool {
+	return b.syncFailures > 0
+}
+
+// Record a successful sync (which clears any previous failures and brings a retired blog back).
+func (b *Blog) RecordSyncSuccess(now time.Time, statusCode int) {
+	b.lastSyncStatusCode = statusCode
+	b.lastSyncError = SyncErrorNone
+	b.syncFailures = 0
+	b.lastSyncSuccessAt = now
+	b.retiredAt = time.Time{}
+}
+
+// Record a failed sync and back off: each consecutive failure doubles the time until
+// the next attempt (starting from the blog's sync interval, up to MaxSyncBackoff).
+func (b *Blog) RecordSyncFailure(now time.Time, statusCode int, syncErr SyncError) {
+	b.lastSyncStatusCode = statusCode
+	b.lastSyncError = syncErr
+	b.syncFailures += 1
+
+	backoff := b.syncInterval
+	for i := 1; i < b.syncFailures && backoff < MaxSyncBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, MaxSyncBackoff)
+
+	nextSyncAt := now.Add(backoff)
+	if nextSyncAt.After(b.nextSyncAt) {
+		b.nextSyncAt = nextSyncAt
+	}
+}
+
+func (b *Blog) RetiredAt() time.Time {
+	return b.retiredAt
+}
+
+func (b *Blog) IsRetired() bool {
+	return !b.retiredAt.IsZero()
+}
+
+// Stop syncing the blog because its feed is gone for good. Retired blogs (and their posts)
+// are kept around but only get synced again when asked to explicitly.
+func (b *Blog) Retire(now time.Time) {
+	b.retiredAt = now
+}
+
+func (b *Blog) FetchFullContent() bool {
+	return b.fetchFullContent
+}
+
+func (b *Blog) SetFetchFullContent(fetchFullContent bool) error {
+	b.fetchFullContent = fetchFullContent
+	return nil
 }
 
 func (b *Blog) CreatedAt() time.Time {
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// Limit the length of content filter values (keywords, tags, and title patterns).
+const MaxContentFilterValueLength = 256
+
+type ContentFilterKind string
+
+const (
+	// Hide posts whose content matches a keyword (via full-text search).
+	ContentFilterKindKeyword ContentFilterKind = "keyword"
+	// Hide posts that match a tag (the same way as when listing tagged articles).
+	ContentFilterKindTag ContentFilterKind = "tag"
+	// Hide posts whose title matches a (case-insensitive) regular expression.
+	ContentFilterKindTitle ContentFilterKind = "title"
+	// Hide all posts from a blog (the value is the blog's ID).
+	ContentFilterKindBlog ContentFilterKind = "blog"
+)
+
+func (k ContentFilterKind) IsValid() bool {
+	switch k {
+	case ContentFilterKindKeyword, ContentFilterKindTag, ContentFilterKindTitle, ContentFilterKindBlog:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check if a value is valid for this kind of filter. Title patterns are evaluated by
+// PostgreSQL so they are limited to the syntax that it shares with Go: flags, named
+// groups, and other "(?" extensions (besides non-capturing groups) aren't allowed.
+// Some patterns still slip through (Go accepts a few that PostgreSQL doesn't) so they
+// are checked by PostgreSQL as well when the filter is created.
+func (k ContentFilterKind) IsValidValue(value string) bool {
+	switch k {
+	case ContentFilterKindKeyword, ContentFilterKindTag:
+		return strings.TrimSpace(value) != ""
+	case ContentFilterKindTitle:
+		if value == "" || strings.Contains(strings.ReplaceAll(value, "(?:", ""), "(?") {
+			return false
+		}
+
+		_, err := regexp.Compile(value)
+		return err == nil
+	case ContentFilterKindBlog:
+		_, err := uuid.Parse(value)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// Tidy up a filter's value based on its kind: keywords have their whitespace collapsed
+// and tags are normalized (just like the tags provided by feeds).
+func (k ContentFilterKind) NormalizeValue(value string) string {
+	switch k {
+	case ContentFilterKindKeyword:
+		return strings.Join(strings.Fields(value), " ")
+	case ContentFilterKindTag:
+		return NormalizeTagName(value)
+	case ContentFilterKindBlog:
+		return strings.ToLower(strings.TrimSpace(value))
+	default:
+		return value
+	}
+}
+
+// A content filter is an account's rule for hiding posts that it isn't interested in
+// (even though they come from blogs that it follows).
+type ContentFilter struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	kind      ContentFilterKind
+	value     string
+	blogID    uuid.UUID
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewContentFilter(account *Account, kind ContentFilterKind, value string) (*ContentFilter, error) {
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("content filter: invalid kind")
+	}
+
+	value = kind.NormalizeValue(value)
+	if !kind.IsValidValue(value) {
+		return nil, fmt.Errorf("content filter: invalid value")
+	}
+
+	// Blog filters also keep track of the blog itself.
+	var blogID uuid.UUID
+	if kind == ContentFilterKindBlog {
+		blogID = uuid.MustParse(value)
+	}
+
+	now := timeutil.Now()
+	filter := ContentFilter{
+		id:        uuid.New(),
+		accountID: account.ID(),
+		kind:      kind,
+		value:     value,
+		blogID:    blogID,
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &filter, nil
+}
+
+func LoadContentFilter(id, accountID uuid.UUID, kind ContentFilterKind, value string, blogID uuid.UUID, createdAt, updatedAt time.Time) *ContentFilter {
+	filter := ContentFilter{
+		id:        id,
+		accountID: accountID,
+		kind:      kind,
+		value:     value,
+		blogID:    blogID,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &filter
+}
+
+func (f *ContentFilter) ID() uuid.UUID {
+	return f.id
+}
+
+func (f *ContentFilter) AccountID() uuid.UUID {
+	return f.accountID
+}
+
+func (f *ContentFilter) Kind() ContentFilterKind {
+	return f.kind
+}
+
+func (f *ContentFilter) Value() string {
+	return f.value
+}
+
+// The ID of the blog being filtered (only set for blog filters).
+func (f *ContentFilter) BlogID() uuid.UUID {
+	return f.blogID
+}
+
+func (f *ContentFilter) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+func (f *ContentFilter) UpdatedAt() time.Time {
+	return f.updatedAt
+}
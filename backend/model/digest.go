@@ -0,0 +1,234 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/random"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// How long a digest confirmation link remains valid after being sent.
+const DigestVerificationTTL = 7 * 24 * time.Hour
+
+// Generate a random, crypto-safe digest verification token.
+func GenerateDigestVerificationToken() (string, error) {
+	return random.BytesBase64(32)
+}
+
+// Generate a SHA-256 hash of a plaintext verification token (same scheme as sessions).
+func HashDigestVerificationToken(token string) string {
+	hashBytes := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashBytes[:])
+}
+
+type DigestFrequency string
+
+const (
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+func (f DigestFrequency) IsValid() bool {
+	return f == DigestFrequencyDaily || f == DigestFrequencyWeekly
+}
+
+// How long to wait between digests of a given frequency.
+func (f DigestFrequency) Interval() time.Duration {
+	switch f {
+	case DigestFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// A digest is an account's subscription to periodic summaries of new posts
+// (from the blogs that it follows) delivered by email. Digests are only sent
+// once the email address has been verified (by following an emailed link).
+type Digest struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	email     string
+	frequency DigestFrequency
+	sentAt    time.Time
+
+	// Only the hash of the verification token is stored (it is emailed once).
+	verificationHash   string
+	verificationSentAt time.Time
+	verifiedAt         time.Time
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewDigest(account *Account, email string, frequency DigestFrequency) (*Digest, error) {
+	if email == "" {
+		return nil, fmt.Errorf("digest: invalid email")
+	}
+	if !frequency.IsValid() {
+		return nil, fmt.Errorf("digest: invalid frequency")
+	}
+
+	// Treat the digest as having just been sent so that the first one only
+	// includes posts that show up after subscribing.
+	now := timeutil.Now()
+	digest := Digest{
+		id:        uuid.New(),
+		accountID: account.ID(),
+		email:     email,
+		frequency: frequency,
+		sentAt:    now,
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &digest, nil
+}
+
+func LoadDigest(
+	id, accountID uuid.UUID,
+	email string,
+	frequency DigestFrequency,
+	sentAt time.Time,
+	verificationHash string,
+	verificationSentAt, verifiedAt time.Time,
+	createdAt, updatedAt time.Time,
+) *Digest {
+	digest := Digest{
+		id:        id,
+		accountID: accountID,
+		email:     email,
+		frequency: frequency,
+		sentAt:    sentAt,
+
+		verificationHash:   verificationHash,
+		verificationSentAt: verificationSentAt,
+		verifiedAt:         verifiedAt,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &digest
+}
+
+func (d *Digest) ID() uuid.UUID {
+	return d.id
+}
+
+func (d *Digest) AccountID() uuid.UUID {
+	return d.accountID
+}
+
+func (d *Digest) Email() string {
+	return d.email
+}
+
+func (d *Digest) SetEmail(email string) error {
+	if email == "" {
+		return fmt.Errorf("digest: invalid email")
+	}
+
+	// A new address has to be verified all over again.
+	if email != d.email {
+		d.ResetVerification()
+	}
+
+	d.email = email
+	return nil
+}
+
+func (d *Digest) Frequency() DigestFrequency {
+	return d.frequency
+}
+
+func (d *Digest) SetFrequency(frequency DigestFrequency) error {
+	if !frequency.IsValid() {
+		return fmt.Errorf("digest: invalid frequency")
+	}
+
+	d.frequency = frequency
+	return nil
+}
+
+func (d *Digest) SentAt() time.Time {
+	return d.sentAt
+}
+
+func (d *Digest) SetSentAt(sentAt time.Time) {
+	d.sentAt = sentAt
+}
+
+func (d *Digest) VerificationHash() string {
+	return d.verificationHash
+}
+
+func (d *Digest) VerificationSentAt() time.Time {
+	return d.verificationSentAt
+}
+
+func (d *Digest) VerifiedAt() time.Time {
+	return d.verifiedAt
+}
+
+func (d *Digest) IsVerified() bool {
+	return !d.verifiedAt.IsZero()
+}
+
+// An unverified digest needs a confirmation link unless one has already been sent.
+func (d *Digest) NeedsVerification() bool {
+	return !d.IsVerified() && d.verificationSentAt.IsZero()
+}
+
+// Generate a new verification token (returning the plaintext version to be
+// emailed) and record when it was sent.
+func (d *Digest) StartVerification(now time.Time) (string, error) {
+	token, err := GenerateDigestVerificationToken()
+	if err != nil {
+		return "", err
+	}
+
+	d.verificationHash = HashDigestVerificationToken(token)
+	d.verificationSentAt = now
+	return token, nil
+}
+
+// Forget any previous verification so that a new confirmation link gets sent.
+func (d *Digest) ResetVerification() {
+	d.verificationHash = ""
+	d.verificationSentAt = time.Time{}
+	d.verifiedAt = time.Time{}
+}
+
+// Confirmation links expire after DigestVerificationTTL (a new one can be
+// requested by saving the digest settings again).
+func (d *Digest) IsVerificationExpired(now time.Time) bool {
+	return d.verificationSentAt.Add(DigestVerificationTTL).Before(now)
+}
+
+// Mark the digest's email address as verified.
+func (d *Digest) Verify(now time.Time) {
+	d.verificationHash = ""
+	d.verifiedAt = now
+}
+
+func (d *Digest) IsDue(now time.Time) bool {
+	return !d.sentAt.Add(d.frequency.Interval()).After(now)
+}
+
+func (d *Digest) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *Digest) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+func (d *Digest) SetUpdatedAt(updatedAt time.Time) error {
+	d.updatedAt = updatedAt
+	return nil
+}
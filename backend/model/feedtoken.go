@@ -0,0 +1,85 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/random"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// Grants read-only access to an account's personal feed. Each account has at most one.
+type FeedToken struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	hash      string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// Generate a random, crypto-safe feed token.
+func GenerateFeedToken() (string, error) {
+	return random.BytesBase64(32)
+}
+
+// Generate a SHA-256 hash of a plaintext feed token (same scheme as sessions).
+func HashFeedToken(token string) string {
+	hashBytes := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashBytes[:])
+}
+
+func NewFeedToken(account *Account) (*FeedToken, string, error) {
+	now := timeutil.Now()
+
+	token, err := GenerateFeedToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Only the hash gets stored: the plaintext token is shown to the user once.
+	feedToken := FeedToken{
+		id:        uuid.New(),
+		accountID: account.ID(),
+		hash:      HashFeedToken(token),
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &feedToken, token, nil
+}
+
+func LoadFeedToken(id, accountID uuid.UUID, hash string, createdAt, updatedAt time.Time) *FeedToken {
+	feedToken := FeedToken{
+		id:        id,
+		accountID: accountID,
+		hash:      hash,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &feedToken
+}
+
+func (t *FeedToken) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *FeedToken) AccountID() uuid.UUID {
+	return t.accountID
+}
+
+func (t *FeedToken) Hash() string {
+	return t.hash
+}
+
+func (t *FeedToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *FeedToken) UpdatedAt() time.Time {
+	return t.updatedAt
+}
@@ -8,6 +8,13 @@ import (
 	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
 
+// Media attached to a post (like a podcast episode's audio file).
+type PostEnclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
 type Post struct {
 	id          uuid.UUID
 	blogID      uuid.UUID
@@ -16,6 +23,17 @@ type Post struct {
 	content     string
 	publishedAt time.Time
 
+	// Optional metadata (only present if the post's feed includes it).
+	authors    []string
+	summary    string
+	categories []string
+	imageURL   string
+	enclosures []PostEnclosure
+
+	// Content extracted from the post's page (if its blog fetches full content).
+	fullContent          string
+	fullContentFetchedAt time.Time
+
 	createdAt time.Time
 	updatedAt time.Time
 }
@@ -36,7 +54,19 @@ func NewPost(blog *Blog, url, title, content string, publishedAt time.Time) (*Po
 	return &post, nil
 }
 
-func LoadPost(id, blogID uuid.UUID, url, title, content string, publishedAt, createdAt, updatedAt time.Time) *Post {
+func LoadPost(
+	id, blogID uuid.UUID,
+	url, title, content string,
+	publishedAt time.Time,
+	authors []string,
+	summary string,
+	categories []string,
+	imageURL string,
+	enclosures []PostEnclosure,
+	fullContent string,
+	fullContentFetchedAt time.Time,
+	createdAt, updatedAt time.Time,
+) *Post {
 	post := Post{
 		id:          id,
 		blogID:      blogID,
@@ -45,6 +75,15 @@ func LoadPost(id, blogID uuid.UUID, url, title, content string, publishedAt, cre
 		content:     content,
 		publishedAt: publishedAt,
 
+		authors:    authors,
+		summary:    summary,
+		categories: categories,
+		imageURL:   imageURL,
+		enclosures: enclosures,
+
+		fullContent:          fullContent,
+		fullContentFetchedAt: fullContentFetchedAt,
+
 		createdAt: createdAt,
 		updatedAt: updatedAt,
 	}
@@ -90,6 +129,67 @@ func (p *Post) SetPublishedAt(publishedAt time.Time) error {
 	return nil
 }
 
+func (p *Post) Authors() []string {
+	return p.authors
+}
+
+func (p *Post) SetAuthors(authors []string) error {
+	p.authors = authors
+	return nil
+}
+
+func (p *Post) Summary() string {
+	return p.summary
+}
+
+func (p *Post) SetSummary(summary string) error {
+	p.summary = summary
+	return nil
+}
+
+func (p *Post) Categories() []string {
+	return p.categories
+}
+
+func (p *Post) SetCategories(categories []string) error {
+	p.categories = categories
+	return nil
+}
+
+func (p *Post) ImageURL() string {
+	return p.imageURL
+}
+
+func (p *Post) SetImageURL(imageURL string) error {
+	p.imageURL = imageURL
+	return nil
+}
+
+func (p *Post) Enclosures() []PostEnclosure {
+	return p.enclosures
+}
+
+func (p *Post) SetEnclosures(enclosures []PostEnclosure) error {
+	p.enclosures = enclosures
+	return nil
+}
+
+func (p *Post) FullContent() string {
+	return p.fullContent
+}
+
+// When the post's full content was fetched (zero if it hasn't been attempted yet).
+func (p *Post) FullContentFetchedAt() time.Time {
+	return p.fullContentFetchedAt
+}
+
+// Record an attempt at fetching the post's full content (which may have come up empty).
+func (p *Post) SetFullContent(fullContent string, fetchedAt time.Time) error {
+	p.fullContent = fullContent
+	p.fullContentFetchedAt = fetchedAt
+	return nil
+}
+
 func (p *Post) CreatedAt() time.Time {
 	return p.createdAt
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,6 +9,29 @@ import (
 	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
 
+// Normalize a tag name so that equivalent names (like "Go " and "go") match:
+// whitespace is collapsed and everything is lowercased.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Normalize a list of tag names, skipping any blanks and duplicates.
+func NormalizeTagNames(names []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized
+}
+
 type Tag struct {
 	id   uuid.UUID
 	name string
@@ -47,6 +71,11 @@ func (t *Tag) Name() string {
 	return t.name
 }
 
+func (t *Tag) SetName(name string) error {
+	t.name = name
+	return nil
+}
+
 func (t *Tag) CreatedAt() time.Time {
 	return t.createdAt
 }
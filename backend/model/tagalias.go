@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// A TagAlias maps a feed-provided tag name (like "golang" or "go") onto a canonical
+// tag name (like "Go"). The alias is normalized but the name is kept as-is.
+type TagAlias struct {
+	id    uuid.UUID
+	alias string
+	name  string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewTagAlias(alias, name string) (*TagAlias, error) {
+	now := timeutil.Now()
+	tagAlias := TagAlias{
+		id:    uuid.New(),
+		alias: NormalizeTagName(alias),
+		name:  strings.Join(strings.Fields(name), " "),
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &tagAlias, nil
+}
+
+func LoadTagAlias(id uuid.UUID, alias, name string, createdAt, updatedAt time.Time) *TagAlias {
+	tagAlias := TagAlias{
+		id:    id,
+		alias: alias,
+		name:  name,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &tagAlias
+}
+
+func (t *TagAlias) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *TagAlias) Alias() string {
+	return t.alias
+}
+
+func (t *TagAlias) Name() string {
+	return t.name
+}
+
+func (t *TagAlias) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *TagAlias) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+func (t *TagAlias) SetUpdatedAt(updatedAt time.Time) error {
+	t.updatedAt = updatedAt
+	return nil
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/random"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// Prefix for generated webhook secrets (makes them easier to identify if leaked).
+const WebhookSecretPrefix = "whsec_"
+
+// A webhook is an account's subscription to events (such as new posts from the
+// blogs that it follows) delivered as signed HTTP requests to a URL.
+type Webhook struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	url       string
+	secret    string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// Generate a random, crypto-safe webhook signing secret.
+func GenerateWebhookSecret() (string, error) {
+	secret, err := random.BytesBase64(32)
+	if err != nil {
+		return "", err
+	}
+
+	return WebhookSecretPrefix + secret, nil
+}
+
+func NewWebhook(account *Account, url, secret string) (*Webhook, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook: invalid url")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("webhook: invalid secret")
+	}
+
+	now := timeutil.Now()
+	webhook := Webhook{
+		id:        uuid.New(),
+		accountID: account.ID(),
+		url:       url,
+		secret:    secret,
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &webhook, nil
+}
+
+func LoadWebhook(id, accountID uuid.UUID, url, secret string, createdAt, updatedAt time.Time) *Webhook {
+	webhook := Webhook{
+		id:        id,
+		accountID: accountID,
+		url:       url,
+		secret:    secret,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &webhook
+}
+
+func (w *Webhook) ID() uuid.UUID {
+	return w.id
+}
+
+func (w *Webhook) AccountID() uuid.UUID {
+	return w.accountID
+}
+
+func (w *Webhook) URL() string {
+	return w.url
+}
+
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+func (w *Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+func (w *Webhook) UpdatedAt() time.Time {
+	return w.updatedAt
+}
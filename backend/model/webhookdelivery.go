@@ -0,0 +1,186 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+const (
+	// Give up on a delivery after this many failed attempts.
+	WebhookDeliveryMaxAttempts = 10
+
+	// Wait this long before the first retry (doubling after each failure).
+	WebhookDeliveryBaseBackoff = 1 * time.Minute
+
+	// But never wait longer than this between attempts.
+	WebhookDeliveryMaxBackoff = 6 * time.Hour
+)
+
+// How long to wait before retrying a delivery that has failed "attempts" times.
+func WebhookDeliveryBackoff(attempts int) time.Duration {
+	backoff := WebhookDeliveryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= WebhookDeliveryMaxBackoff {
+			return WebhookDeliveryMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// A webhook delivery is a single event queued up for (and then attempted to be)
+// sent to a webhook. Deliveries are persisted so that they survive restarts and
+// outages on either end. They also double as the webhook's delivery log.
+type WebhookDelivery struct {
+	id             uuid.UUID
+	webhookID      uuid.UUID
+	event          string
+	payload        string
+	status         WebhookDeliveryStatus
+	attempts       int
+	nextAttemptAt  time.Time
+	lastStatusCode int
+	lastError      string
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewWebhookDelivery(webhook *Webhook, event, payload string) (*WebhookDelivery, error) {
+	if event == "" {
+		return nil, fmt.Errorf("webhook delivery: invalid event")
+	}
+	if payload == "" {
+		return nil, fmt.Errorf("webhook delivery: invalid payload")
+	}
+
+	// New deliveries are ready to be attempted right away.
+	now := timeutil.Now()
+	delivery := WebhookDelivery{
+		id:            uuid.New(),
+		webhookID:     webhook.ID(),
+		event:         event,
+		payload:       payload,
+		status:        WebhookDeliveryStatusPending,
+		nextAttemptAt: now,
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &delivery, nil
+}
+
+func LoadWebhookDelivery(
+	id, webhookID uuid.UUID,
+	event, payload string,
+	status WebhookDeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastStatusCode int,
+	lastError string,
+	createdAt, updatedAt time.Time,
+) *WebhookDelivery {
+	delivery := WebhookDelivery{
+		id:             id,
+		webhookID:      webhookID,
+		event:          event,
+		payload:        payload,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		lastStatusCode: lastStatusCode,
+		lastError:      lastError,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &delivery
+}
+
+func (d *WebhookDelivery) ID() uuid.UUID {
+	return d.id
+}
+
+func (d *WebhookDelivery) WebhookID() uuid.UUID {
+	return d.webhookID
+}
+
+func (d *WebhookDelivery) Event() string {
+	return d.event
+}
+
+func (d *WebhookDelivery) Payload() string {
+	return d.payload
+}
+
+func (d *WebhookDelivery) Status() WebhookDeliveryStatus {
+	return d.status
+}
+
+func (d *WebhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+func (d *WebhookDelivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+func (d *WebhookDelivery) LastStatusCode() int {
+	return d.lastStatusCode
+}
+
+func (d *WebhookDelivery) LastError() string {
+	return d.lastError
+}
+
+func (d *WebhookDelivery) IsDue(now time.Time) bool {
+	return d.status == WebhookDeliveryStatusPending && !d.nextAttemptAt.After(now)
+}
+
+// Record a successful delivery attempt.
+func (d *WebhookDelivery) RecordSuccess(statusCode int) {
+	d.attempts += 1
+	d.status = WebhookDeliveryStatusSucceeded
+	d.lastStatusCode = statusCode
+	d.lastError = ""
+}
+
+// Record a failed delivery attempt and schedule a retry (with exponential backoff).
+// Once too many attempts have failed, the delivery is marked as failed for good.
+func (d *WebhookDelivery) RecordFailure(now time.Time, statusCode int, message string) {
+	d.attempts += 1
+	d.lastStatusCode = statusCode
+	d.lastError = message
+
+	if d.attempts >= WebhookDeliveryMaxAttempts {
+		d.status = WebhookDeliveryStatusFailed
+		return
+	}
+
+	d.nextAttemptAt = now.Add(WebhookDeliveryBackoff(d.attempts))
+}
+
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *WebhookDelivery) UpdatedAt() time.Time {
+	return d.updatedAt
+}
+
+func (d *WebhookDelivery) SetUpdatedAt(updatedAt time.Time) error {
+	d.updatedAt = updatedAt
+	return nil
+}
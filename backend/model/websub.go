@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/random"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+const (
+	// Ask hubs for leases of this length (they are free to pick something else).
+	WebSubLeaseDuration = 10 * 24 * time.Hour
+
+	// Renew a subscription once its lease is this close to expiring.
+	WebSubRenewalWindow = 24 * time.Hour
+
+	// Wait this long between subscription requests (while waiting on the hub to verify).
+	WebSubRequestCooldown = 1 * time.Hour
+)
+
+// A WebSub subscription tracks a blog's hub (as advertised by its feed) and the
+// lease granted by that hub. While the lease is active, the hub pushes new content
+// to us and the blog doesn't need to be polled.
+type WebSubSubscription struct {
+	id             uuid.UUID
+	blogID         uuid.UUID
+	hubURL         string
+	topicURL       string
+	secret         string
+	requestedAt    time.Time
+	leaseExpiresAt time.Time
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewWebSubSubscription(blog *Blog, hubURL, topicURL string) (*WebSubSubscription, error) {
+	if hubURL == "" {
+		return nil, fmt.Errorf("websub subscription: invalid hub url")
+	}
+	if topicURL == "" {
+		return nil, fmt.Errorf("websub subscription: invalid topic url")
+	}
+
+	// Each subscription gets its own secret for verifying pushed content.
+	secret, err := random.BytesBase64(32)
+	if err != nil {
+		return nil, err
+	}
+
+	// New subscriptions have never been requested and have no lease.
+	now := timeutil.Now()
+	subscription := WebSubSubscription{
+		id:             uuid.New(),
+		blogID:         blog.ID(),
+		hubURL:         hubURL,
+		topicURL:       topicURL,
+		secret:         secret,
+		requestedAt:    time.Time{},
+		leaseExpiresAt: now,
+
+		createdAt: now,
+		updatedAt: now,
+	}
+	return &subscription, nil
+}
+
+func LoadWebSubSubscription(id, blogID uuid.UUID, hubURL, topicURL, secret string, requestedAt, leaseExpiresAt, createdAt, updatedAt time.Time) *WebSubSubscription {
+	subscription := WebSubSubscription{
+		id:             id,
+		blogID:         blogID,
+		hubURL:         hubURL,
+		topicURL:       topicURL,
+		secret:         secret,
+		requestedAt:    requestedAt,
+		leaseExpiresAt: leaseExpiresAt,
+
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+	return &subscription
+}
+
+func (s *WebSubSubscription) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *WebSubSubscription) BlogID() uuid.UUID {
+	return s.blogID
+}
+
+func (s *WebSubSubscription) HubURL() string {
+	return s.hubURL
+}
+
+func (s *WebSubSubscription) TopicURL() string {
+	return s.topicURL
+}
+
+// Point the subscription at a different hub and / or topic. The current lease (if
+// any) no longer applies so the subscription will need to be requested again.
+func (s *WebSubSubscription) SetHub(hubURL, topicURL string) error {
+	if hubURL == "" {
+		return fmt.Errorf("websub subscription: invalid hub url")
+	}
+	if topicURL == "" {
+		return fmt.Errorf("websub subscription: invalid topic url")
+	}
+
+	s.hubURL = hubURL
+	s.topicURL = topicURL
+	s.requestedAt = time.Time{}
+	s.leaseExpiresAt = timeutil.Now()
+	return nil
+}
+
+func (s *WebSubSubscription) Secret() string {
+	return s.secret
+}
+
+func (s *WebSubSubscription) RequestedAt() time.Time {
+	return s.requestedAt
+}
+
+func (s *WebSubSubscription) SetRequestedAt(requestedAt time.Time) {
+	s.requestedAt = requestedAt
+}
+
+func (s *WebSubSubscription) LeaseExpiresAt() time.Time {
+	return s.leaseExpiresAt
+}
+
+// Record the lease granted by the hub when it verifies the subscription.
+func (s *WebSubSubscription) SetLease(now time.Time, leaseDuration time.Duration) {
+	s.leaseExpiresAt = now.Add(leaseDuration)
+}
+
+// A subscription is active (and its blog doesn't need polling) while its lease lasts.
+func (s *WebSubSubscription) IsActive(now time.Time) bool {
+	return s.leaseExpiresAt.After(now)
+}
+
+// A subscription should be (re-)requested when its lease is about to lapse (or
+// already has) unless a request was made recently and is still pending.
+func (s *WebSubSubscription) NeedsRenewal(now time.Time) bool {
+	if s.requestedAt.Add(WebSubRequestCooldown).After(now) {
+		return false
+	}
+
+	return !s.leaseExpiresAt.After(now.Add(WebSubRenewalWindow))
+}
+
+func (s *WebSubSubscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *WebSubSubscription) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+func (s *WebSubSubscription) SetUpdatedAt(updatedAt time.Time) error {
+	s.updatedAt = updatedAt
+	return nil
+}
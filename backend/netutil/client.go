@@ -0,0 +1,165 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// Returned (wrapped) when a request would connect to an address that isn't on the public internet.
+var ErrForbiddenAddress = errors.New("netutil: forbidden address")
+
+const (
+	// Give up on servers that take too long to connect to (including the TLS handshake).
+	ConnectTimeout = 10 * time.Second
+
+	// Don't let a slow server hold things up (this covers the whole request, reading the body included).
+	Timeout = 30 * time.Second
+)
+
+// Limits that apply to each outbound request. Fields left as zero use the defaults above.
+type ClientConfig struct {
+	ConnectTimeout time.Duration
+	Timeout        time.Duration
+
+	// Networks that would otherwise be refused but are connected to anyway (like a
+	// local server during testing). Public addresses are always allowed.
+	AllowedNetworks []netip.Prefix
+}
+
+func (c ClientConfig) withDefaults() ClientConfig {
+	if c.ConnectTimeout == 0 {
+		c.ConnectTimeout = ConnectTimeout
+	}
+	if c.Timeout == 0 {
+		c.Timeout = Timeout
+	}
+	return c
+}
+
+func (c ClientConfig) isAllowed(addr netip.Addr) bool {
+	if IsPublicAddress(addr) {
+		return true
+	}
+
+	return slices.ContainsFunc(c.AllowedNetworks, func(network netip.Prefix) bool {
+		return network.Contains(addr.Unmap())
+	})
+}
+
+// Special-purpose ranges that aren't covered by the netip.Addr helpers. The IPv6
+// translation prefixes embed an IPv4 address (which could be a private one).
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+}
+
+// IsPublicAddress reports whether an address is on the public internet. URLs can be
+// submitted by anyone (directly or via a feed) so private, loopback, and link-local
+// addresses are off limits (otherwise they could be used to reach internal services).
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	switch {
+	case !addr.IsValid():
+		return false
+	case addr.IsUnspecified():
+		return false
+	case slices.ContainsFunc(nonPublicPrefixes, func(prefix netip.Prefix) bool { return prefix.Contains(addr) }):
+		return false
+	case addr.IsLoopback():
+		return false
+	case addr.IsPrivate():
+		return false
+	case addr.IsLinkLocalUnicast(), addr.IsLinkLocalMulticast(), addr.IsInterfaceLocalMulticast():
+		return false
+	case addr.IsMulticast():
+		return false
+	default:
+		return true
+	}
+}
+
+// NewClient builds an http.Client for fetching untrusted URLs. Every address is checked
+// right before connecting (after DNS resolution and for each redirect) so that a hostname
+// can't be used to sneak past the check.
+func NewClient(config ClientConfig) *http.Client {
+	config = config.withDefaults()
+
+	control := func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+
+		addr, err := netip.ParseAddr(host)
+		if err != nil {
+			return err
+		}
+
+		if !config.isAllowed(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+		}
+
+		return nil
+	}
+
+	dialer := net.Dialer{
+		Timeout: config.ConnectTimeout,
+		Control: control,
+	}
+
+	transport := http.Transport{
+		// Don't use any proxies from the environment (the address check would only see the proxy).
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   config.ConnectTimeout,
+		ResponseHeaderTimeout: config.Timeout,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		ForceAttemptHTTP2:     true,
+	}
+
+	client := http.Client{
+		Transport: &transport,
+		Timeout:   config.Timeout,
+	}
+	return &client
+}
+
+// CheckURL resolves a URL's host and returns ErrForbiddenAddress if any of its addresses
+// aren't on the public internet. This is meant for rejecting URLs up front (when they're
+// submitted): the client from NewClient still checks every connection it makes.
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrForbiddenAddress)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !IsPublicAddress(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package netutil_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/netutil"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.215.14", want: true},
+		{addr: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", want: true},
+		{addr: "0.0.0.0", want: false},
+		{addr: "0.1.2.3", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "100.127.255.254", want: false},
+		{addr: "100.128.0.1", want: true},
+		{addr: "64:ff9b::7f00:1", want: false},
+		{addr: "64:ff9b:1::a00:1", want: false},
+		{addr: "2002:7f00:1::1", want: false},
+		{addr: "127.0.0.1", want: false},
+		{addr: "127.1.2.3", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.0.0.1", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "fd00::1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "224.0.0.1", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "::ffff:10.0.0.1", want: false},
+	}
+	for _, tt := range tests {
+		got := netutil.IsPublicAddress(netip.MustParseAddr(tt.addr))
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestClientForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+	}))
+	defer server.Close()
+
+	// The default client refuses to connect to local servers.
+	client := netutil.NewClient(netutil.ClientConfig{})
+	_, err := client.Get(server.URL)
+	test.AssertErrorIs(t, err, netutil.ErrForbiddenAddress)
+	test.AssertEqual(t, requested.Load(), false)
+
+	// Hostnames are checked based on the addresses that they resolve to.
+	_, err = client.Get(strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	test.AssertErrorIs(t, err, netutil.ErrForbiddenAddress)
+	test.AssertEqual(t, requested.Load(), false)
+}
+
+func TestClientAllowedNetworks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := netutil.NewClient(netutil.ClientConfig{
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+
+	resp, err := client.Get(server.URL)
+	test.AssertNilError(t, err)
+	defer resp.Body.Close()
+
+	test.AssertEqual(t, resp.StatusCode, http.StatusNoContent)
+}
+
+func TestClientForbiddenAddressAfterRedirect(t *testing.T) {
+	t.Parallel()
+
+	// A (reachable) server that redirects to a cloud metadata service.
+	server := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/latest/meta-data/", http.StatusFound))
+	defer server.Close()
+
+	client := netutil.NewClient(netutil.ClientConfig{
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")},
+	})
+
+	_, err := client.Get(server.URL)
+	test.AssertErrorIs(t, err, netutil.ErrForbiddenAddress)
+}
+
+func TestCheckURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url       string
+		forbidden bool
+	}{
+		{url: "https://93.184.215.14/webhook", forbidden: false},
+		{url: "https://[2606:2800:21f:cb07:6820:80da:af6b:8b2c]/webhook", forbidden: false},
+		{url: "http://127.0.0.1:8080/webhook", forbidden: true},
+		{url: "http://[::1]/webhook", forbidden: true},
+		{url: "http://localhost/webhook", forbidden: true},
+		{url: "http://10.0.0.1/webhook", forbidden: true},
+		{url: "http://169.254.169.254/latest/meta-data/", forbidden: true},
+		{url: "/webhook", forbidden: true},
+	}
+	for _, tt := range tests {
+		err := netutil.CheckURL(context.Background(), tt.url)
+		if tt.forbidden {
+			test.AssertErrorIs(t, err, netutil.ErrForbiddenAddress)
+		} else {
+			test.AssertNilError(t, err)
+		}
+	}
+}
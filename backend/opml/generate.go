@@ -0,0 +1,38 @@
+package opml
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Convert a list of feeds into an OPML 2.0 document.
+func Generate(title string, feeds []Feed, now time.Time) (string, error) {
+	var outlines []xmlOutline
+	for _, feed := range feeds {
+		outlines = append(outlines, xmlOutline{
+			Text:    feed.Title,
+			Title:   feed.Title,
+			Type:    "rss",
+			XMLURL:  feed.FeedURL,
+			HTMLURL: feed.SiteURL,
+		})
+	}
+
+	doc := xmlDocument{
+		Version: "2.0",
+		Head: xmlHead{
+			Title:       title,
+			DateCreated: now.Format(time.RFC1123Z),
+		},
+		Body: xmlBody{
+			Outlines: outlines,
+		},
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(out) + "\n", nil
+}
@@ -0,0 +1,57 @@
+package opml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/opml"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	feeds := []opml.Feed{
+		{FeedURL: "https://foo.com/atom.xml", SiteURL: "https://foo.com", Title: "Foo"},
+		{FeedURL: "https://bar.com/rss.xml", SiteURL: "https://bar.com", Title: "Bar & Friends"},
+	}
+
+	doc, err := opml.Generate("Subscriptions", feeds, timeutil.Now())
+	test.AssertNilError(t, err)
+
+	test.AssertStringContains(t, doc, `<opml version="2.0">`)
+	test.AssertStringContains(t, doc, "<title>Subscriptions</title>")
+	test.AssertStringContains(t, doc, `xmlUrl="https://foo.com/atom.xml"`)
+	test.AssertStringContains(t, doc, `htmlUrl="https://foo.com"`)
+	test.AssertStringContains(t, doc, `title="Bar &amp; Friends"`)
+}
+
+func TestGenerateRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	feeds := []opml.Feed{
+		{FeedURL: "https://foo.com/atom.xml", SiteURL: "https://foo.com", Title: "Foo"},
+		{FeedURL: "https://bar.com/rss.xml", SiteURL: "https://bar.com", Title: "Bar & Friends"},
+		{FeedURL: "https://baz.com/feed", SiteURL: "", Title: "Baz"},
+	}
+
+	doc, err := opml.Generate("Subscriptions", feeds, timeutil.Now())
+	test.AssertNilError(t, err)
+
+	// Whatever we export should be accepted (unchanged) by the importer.
+	parsed, err := opml.Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, parsed, feeds)
+}
+
+func TestGenerateEmpty(t *testing.T) {
+	t.Parallel()
+
+	doc, err := opml.Generate("Subscriptions", nil, timeutil.Now())
+	test.AssertNilError(t, err)
+
+	parsed, err := opml.Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsed), 0)
+}
@@ -0,0 +1,94 @@
+package opml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrInvalidDocument = errors.New("opml: invalid document")
+)
+
+// An outline element. Feeds are outlines with an xmlUrl attribute while
+// categories (folders) are outlines that contain other outlines.
+type xmlOutline struct {
+	Text     string       `xml:"text,attr"`
+	Title    string       `xml:"title,attr,omitempty"`
+	Type     string       `xml:"type,attr,omitempty"`
+	XMLURL   string       `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string       `xml:"htmlUrl,attr,omitempty"`
+	Outlines []xmlOutline `xml:"outline"`
+}
+
+type xmlHead struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+type xmlBody struct {
+	Outlines []xmlOutline `xml:"outline"`
+}
+
+type xmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    xmlHead  `xml:"head"`
+	Body    xmlBody  `xml:"body"`
+}
+
+type Feed struct {
+	FeedURL string
+	SiteURL string
+	Title   string
+
+	// Names of the categories (parent outlines) this feed was nested under.
+	Categories []string
+}
+
+// Determine the user-facing name of an outline (title is preferred over text).
+func outlineName(outline xmlOutline) string {
+	if outline.Title != "" {
+		return outline.Title
+	}
+	return outline.Text
+}
+
+// Recursively walk an outline tree, collecting feeds (and their categories) as we go.
+func collectFeeds(outlines []xmlOutline, categories []string, seen map[string]bool) []Feed {
+	var feeds []Feed
+	for _, outline := range outlines {
+		feedURL := strings.TrimSpace(outline.XMLURL)
+		if feedURL != "" && !seen[feedURL] {
+			seen[feedURL] = true
+			feeds = append(feeds, Feed{
+				FeedURL:    feedURL,
+				SiteURL:    strings.TrimSpace(outline.HTMLURL),
+				Title:      outlineName(outline),
+				Categories: categories,
+			})
+		}
+
+		// Outlines can be nested arbitrarily deep, so keep track of the path.
+		if len(outline.Outlines) > 0 {
+			path := append(categories[:len(categories):len(categories)], outlineName(outline))
+			feeds = append(feeds, collectFeeds(outline.Outlines, path, seen)...)
+		}
+	}
+
+	return feeds
+}
+
+// Parse an OPML document into a flat list of (deduplicated) feeds.
+func Parse(r io.Reader) ([]Feed, error) {
+	var doc xmlDocument
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	feeds := collectFeeds(doc.Body.Outlines, nil, make(map[string]bool))
+	return feeds, nil
+}
@@ -0,0 +1,84 @@
+package opml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/opml"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+	<head>
+		<title>Subscriptions</title>
+	</head>
+	<body>
+		<outline text="Foo" title="Foo" type="rss" xmlUrl="https://foo.com/atom.xml" htmlUrl="https://foo.com" />
+		<outline text="Tech">
+			<outline text="Bar" type="rss" xmlUrl="https://bar.com/rss.xml" htmlUrl="https://bar.com" />
+			<outline text="Languages">
+				<outline text="Baz" type="rss" xmlUrl="https://baz.com/feed" />
+			</outline>
+		</outline>
+	</body>
+</opml>`
+
+	feeds, err := opml.Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(feeds), 3)
+
+	test.AssertEqual(t, feeds[0].FeedURL, "https://foo.com/atom.xml")
+	test.AssertEqual(t, feeds[0].SiteURL, "https://foo.com")
+	test.AssertEqual(t, feeds[0].Title, "Foo")
+	test.AssertEqual(t, len(feeds[0].Categories), 0)
+
+	test.AssertEqual(t, feeds[1].FeedURL, "https://bar.com/rss.xml")
+	test.AssertEqual(t, feeds[1].Title, "Bar")
+	test.AssertEqual(t, feeds[1].Categories, []string{"Tech"})
+
+	test.AssertEqual(t, feeds[2].FeedURL, "https://baz.com/feed")
+	test.AssertEqual(t, feeds[2].Categories, []string{"Tech", "Languages"})
+}
+
+func TestParseDuplicateFeeds(t *testing.T) {
+	t.Parallel()
+
+	doc := `<opml version="2.0">
+	<body>
+		<outline text="Foo" xmlUrl="https://foo.com/atom.xml" />
+		<outline text="Tech">
+			<outline text="Foo (again)" xmlUrl="https://foo.com/atom.xml" />
+		</outline>
+	</body>
+</opml>`
+
+	feeds, err := opml.Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(feeds), 1)
+}
+
+func TestParseSkipsEmptyOutlines(t *testing.T) {
+	t.Parallel()
+
+	doc := `<opml version="1.0">
+	<body>
+		<outline text="Empty Category" />
+		<outline text="No Feed URL" htmlUrl="https://foo.com" />
+	</body>
+</opml>`
+
+	feeds, err := opml.Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(feeds), 0)
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := opml.Parse(strings.NewReader("<html><body>not opml</body></html>"))
+	test.AssertErrorIs(t, err, opml.ErrInvalidDocument)
+}
@@ -4,25 +4,118 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
 	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/postgres"
 )
 
-// List: recent, recent by account, search, search by account
-// Count: all, all by account, search, search by account
+// List: recent, recent by account, unread by account, saved by account, new by account, tagged, tagged by account, search, search by account
+// Count: all, all by account, unread by account, saved by account, tagged, tagged by account, search, search by account
+// Filtered: any combination of search, tag, and blog
 
 type Article struct {
+	ID          uuid.UUID `db:"id" json:"id"`
 	Title       string    `db:"title" json:"title"`
 	URL         string    `db:"url" json:"url"`
 	BlogTitle   string    `db:"blog_title" json:"blogTitle"`
 	BlogURL     string    `db:"blog_url" json:"blogURL"`
 	PublishedAt time.Time `db:"published_at" json:"publishedAt"`
 	Tags        []string  `db:"tags" json:"tags"`
+
+	// Optional metadata (empty if the post's feed doesn't include it).
+	Authors    []string           `db:"authors" json:"authors"`
+	Summary    string             `db:"summary" json:"summary"`
+	Categories []string           `db:"categories" json:"categories"`
+	ImageURL   string             `db:"image_url" json:"imageURL"`
+	Enclosures []ArticleEnclosure `db:"enclosures" json:"enclosures"`
+
+	// Read and saved states are only tracked for articles listed for a specific account.
+	IsRead  bool `db:"is_read" json:"isRead"`
+	IsSaved bool `db:"is_saved" json:"isSaved"`
+
+	// Hidden articles match one of the account's content filters (and are only
+	// included when explicitly asked for).
+	IsHidden bool `db:"is_hidden" json:"isHidden"`
+}
+
+type ArticleEnclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type"`
+	Length int64  `json:"length"`
 }
 
-func (qry *Query) ListRecentArticles(limit, offset int) ([]Article, error) {
+// An article's tags are a merge of its explicit tags (provided by its feed and mapped
+// through any aliases) and the known tags that match its content. Explicit tags come
+// first, duplicates (ignoring case) are removed, and only the top three are kept.
+const articleTagsColumn = `ARRAY(
+				SELECT merged.name
+				FROM (
+					SELECT DISTINCT ON (lower(candidate.name))
+						candidate.name,
+						candidate.priority,
+						candidate.rank
+					FROM (
+						SELECT
+							COALESCE(tag_alias.name, tag.name, post_tag.name) AS name,
+							0 AS priority,
+							0::real AS rank
+						FROM post_tag
+						LEFT JOIN tag_alias
+							ON tag_alias.alias = post_tag.name
+						LEFT JOIN tag
+							ON lower(tag.name) = post_tag.name
+						WHERE post_tag.post_id = post.id
+						UNION ALL
+						SELECT
+							tag.name,
+							1 AS priority,
+							ts_rank_cd(post.fts_data, plainto_tsquery('english', tag.name)) AS rank
+						FROM tag
+						WHERE plainto_tsquery('english', tag.name) @@ post.fts_data
+					) candidate
+					ORDER BY lower(candidate.name), candidate.priority
+				) merged
+				ORDER BY merged.priority, merged.rank DESC
+				LIMIT 3
+			) AS tags`
+
+// Articles are hidden from an account if they match any of its content filters. The
+// account's ID is read from the given query parameter (like "$1").
+func articleHiddenMatch(param string) string {
+	return `EXISTS (
+				SELECT 1
+				FROM content_filter
+				WHERE content_filter.account_id = ` + param + `
+					AND ` + contentFilterMatch + `
+			)`
+}
+
+// Articles match a content filter based on its kind. Titles are matched case-insensitively.
+var contentFilterMatch = `CASE content_filter.kind
+						WHEN 'keyword' THEN post.fts_data @@ plainto_tsquery('english', content_filter.value)
+						WHEN 'tag' THEN ` + articleTagMatch("content_filter.value") + `
+						WHEN 'title' THEN post.title ~* content_filter.value
+						WHEN 'blog' THEN post.blog_id = content_filter.blog_id
+						ELSE FALSE
+					END`
+
+// Articles match a tag if either their content or one of their explicit tags (mapped
+// through any aliases) matches. The tag is compared case-insensitively and is read from
+// the given query parameter (like "$1").
+func articleTagMatch(param string) string {
+	return `(post.fts_data @@ plainto_tsquery('english', ` + param + `) OR EXISTS (
+				SELECT 1
+				FROM post_tag
+				LEFT JOIN tag_alias
+					ON tag_alias.alias = post_tag.name
+				WHERE post_tag.post_id = post.id
+					AND lower(COALESCE(tag_alias.name, post_tag.name)) = lower(` + param + `)
+			))`
+}
+
+func (qry *Query) ListRecentArticles(ctx context.Context, limit, offset int) ([]Article, error) {
 	stmt := `
 		WITH latest AS (
 			SELECT
@@ -32,23 +125,30 @@ func (qry *Query) ListRecentArticles(limit, offset int) ([]Article, error) {
 			LIMIT $1 OFFSET $2
 		)
 		SELECT
+			post.id,
 			post.title,
 			post.url,
 			MAX(blog.title) as blog_title,
 			MAX(blog.site_url) as blog_url,
 			post.published_at,
-			(array_remove(array_agg(tag.name ORDER BY ts_rank_cd(post.fts_data, plainto_tsquery('english', tag.name)) DESC), NULL))[1:3] as tags
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			FALSE AS is_read,
+			FALSE AS is_saved,
+			FALSE AS is_hidden,
+			` + articleTagsColumn + `
 		FROM latest
 		INNER JOIN post
 			ON post.id = latest.id
 		INNER JOIN blog
 			ON blog.id = post.blog_id
-		LEFT JOIN tag
-			ON plainto_tsquery('english', tag.name) @@ post.fts_data
 		GROUP BY post.id
 		ORDER BY post.published_at DESC`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, limit, offset)
+	rows, err := qry.conn.Query(ctx, stmt, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +161,8 @@ func (qry *Query) ListRecentArticles(limit, offset int) ([]Article, error) {
 	return articles, nil
 }
 
-func (qry *Query) ListRecentArticlesByAccount(account *model.Account, limit, offset int) ([]Article, error) {
+// Articles hidden by the account's content filters are only included if includeHidden is true.
+func (qry *Query) ListRecentArticlesByAccount(ctx context.Context, account *model.Account, includeHidden bool, limit, offset int) ([]Article, error) {
 	stmt := `
 		WITH latest AS (
 			SELECT
@@ -72,27 +173,340 @@ func (qry *Query) ListRecentArticlesByAccount(account *model.Account, limit, off
 			INNER JOIN account_blog
 				ON account_blog.blog_id = blog.id
 				AND account_blog.account_id = $1
+			WHERE $2 OR NOT ` + articleHiddenMatch("$1") + `
 			ORDER BY post.published_at DESC
+			LIMIT $3 OFFSET $4
+		)
+		SELECT
+			post.id,
+			post.title,
+			post.url,
+			MAX(blog.title) as blog_title,
+			MAX(blog.site_url) as blog_url,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			) AS is_read,
+			EXISTS (
+				SELECT 1
+				FROM account_post
+				WHERE account_post.post_id = post.id
+					AND account_post.account_id = $1
+			) AS is_saved,
+			` + articleHiddenMatch("$1") + ` AS is_hidden,
+			` + articleTagsColumn + `
+		FROM latest
+		INNER JOIN post
+			ON post.id = latest.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		GROUP BY post.id
+		ORDER BY post.published_at DESC`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), includeHidden, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pgx.CollectRows(rows, pgx.RowToStructByName[Article])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return articles, nil
+}
+
+// Articles hidden by the account's content filters are only included if includeHidden is true.
+func (qry *Query) ListUnreadArticlesByAccount(ctx context.Context, account *model.Account, includeHidden bool, limit, offset int) ([]Article, error) {
+	stmt := `
+		WITH latest AS (
+			SELECT
+				post.id
+			FROM post
+			INNER JOIN blog
+				ON blog.id = post.blog_id
+			INNER JOIN account_blog
+				ON account_blog.blog_id = blog.id
+				AND account_blog.account_id = $1
+			WHERE NOT EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			)
+				AND ($2 OR NOT ` + articleHiddenMatch("$1") + `)
+			ORDER BY post.published_at DESC
+			LIMIT $3 OFFSET $4
+		)
+		SELECT
+			post.id,
+			post.title,
+			post.url,
+			MAX(blog.title) as blog_title,
+			MAX(blog.site_url) as blog_url,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			FALSE AS is_read,
+			EXISTS (
+				SELECT 1
+				FROM account_post
+				WHERE account_post.post_id = post.id
+					AND account_post.account_id = $1
+			) AS is_saved,
+			` + articleHiddenMatch("$1") + ` AS is_hidden,
+			` + articleTagsColumn + `
+		FROM latest
+		INNER JOIN post
+			ON post.id = latest.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		GROUP BY post.id
+		ORDER BY post.published_at DESC`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), includeHidden, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pgx.CollectRows(rows, pgx.RowToStructByName[Article])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return articles, nil
+}
+
+func (qry *Query) ListSavedArticlesByAccount(ctx context.Context, account *model.Account, limit, offset int) ([]Article, error) {
+	stmt := `
+		WITH saved AS (
+			SELECT
+				account_post.post_id AS id,
+				account_post.created_at AS saved_at
+			FROM account_post
+			WHERE account_post.account_id = $1
+			ORDER BY account_post.created_at DESC
 			LIMIT $2 OFFSET $3
 		)
 		SELECT
+			post.id,
+			post.title,
+			post.url,
+			MAX(blog.title) as blog_title,
+			MAX(blog.site_url) as blog_url,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			) AS is_read,
+			TRUE AS is_saved,
+			FALSE AS is_hidden,
+			` + articleTagsColumn + `
+		FROM saved
+		INNER JOIN post
+			ON post.id = saved.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		GROUP BY post.id
+		ORDER BY MAX(saved.saved_at) DESC`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pgx.CollectRows(rows, pgx.RowToStructByName[Article])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return articles, nil
+}
+
+// List articles (from blogs followed by the account) that were discovered after
+// a given time. Unlike the other lists, this is based on when each post was first
+// synced (not published) so that back-dated posts don't get missed. Articles hidden
+// by the account's content filters are never included.
+func (qry *Query) ListNewArticlesByAccount(ctx context.Context, account *model.Account, since time.Time, limit int) ([]Article, error) {
+	stmt := `
+		WITH latest AS (
+			SELECT
+				post.id
+			FROM post
+			INNER JOIN blog
+				ON blog.id = post.blog_id
+			INNER JOIN account_blog
+				ON account_blog.blog_id = blog.id
+				AND account_blog.account_id = $1
+			WHERE post.created_at > $2
+				AND NOT ` + articleHiddenMatch("$1") + `
+			ORDER BY post.published_at DESC
+			LIMIT $3
+		)
+		SELECT
+			post.id,
 			post.title,
 			post.url,
 			MAX(blog.title) as blog_title,
 			MAX(blog.site_url) as blog_url,
 			post.published_at,
-			(array_remove(array_agg(tag.name ORDER BY ts_rank_cd(post.fts_data, plainto_tsquery('english', tag.name)) DESC), NULL))[1:3] as tags
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			) AS is_read,
+			EXISTS (
+				SELECT 1
+				FROM account_post
+				WHERE account_post.post_id = post.id
+					AND account_post.account_id = $1
+			) AS is_saved,
+			FALSE AS is_hidden,
+			` + articleTagsColumn + `
 		FROM latest
 		INNER JOIN post
 			ON post.id = latest.id
 		INNER JOIN blog
 			ON blog.id = post.blog_id
-		LEFT JOIN tag
-			ON plainto_tsquery('english', tag.name) @@ post.fts_data
 		GROUP BY post.id
 		ORDER BY post.published_at DESC`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, account.ID(), limit, offset)
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pgx.CollectRows(rows, pgx.RowToStructByName[Article])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return articles, nil
+}
+
+func (qry *Query) ListTaggedArticles(ctx context.Context, tag string, limit, offset int) ([]Article, error) {
+	stmt := `
+		WITH tagged AS (
+			SELECT
+				post.id
+			FROM post
+			WHERE ` + articleTagMatch("$1") + `
+			ORDER BY post.published_at DESC
+			LIMIT $2 OFFSET $3
+		)
+		SELECT
+			post.id,
+			post.title,
+			post.url,
+			MAX(blog.title) as blog_title,
+			MAX(blog.site_url) as blog_url,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			FALSE AS is_read,
+			FALSE AS is_saved,
+			FALSE AS is_hidden,
+			` + articleTagsColumn + `
+		FROM tagged
+		INNER JOIN post
+			ON post.id = tagged.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		GROUP BY post.id
+		ORDER BY post.published_at DESC`
+
+	rows, err := qry.conn.Query(ctx, stmt, tag, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pgx.CollectRows(rows, pgx.RowToStructByName[Article])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return articles, nil
+}
+
+// Articles hidden by the account's content filters are only included if includeHidden is true.
+func (qry *Query) ListTaggedArticlesByAccount(ctx context.Context, account *model.Account, tag string, includeHidden bool, limit, offset int) ([]Article, error) {
+	stmt := `
+		WITH tagged AS (
+			SELECT
+				post.id
+			FROM post
+			INNER JOIN blog
+				ON blog.id = post.blog_id
+			INNER JOIN account_blog
+				ON account_blog.blog_id = blog.id
+				AND account_blog.account_id = $1
+			WHERE ` + articleTagMatch("$2") + `
+				AND ($3 OR NOT ` + articleHiddenMatch("$1") + `)
+			ORDER BY post.published_at DESC
+			LIMIT $4 OFFSET $5
+		)
+		SELECT
+			post.id,
+			post.title,
+			post.url,
+			MAX(blog.title) as blog_title,
+			MAX(blog.site_url) as blog_url,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			) AS is_read,
+			EXISTS (
+				SELECT 1
+				FROM account_post
+				WHERE account_post.post_id = post.id
+					AND account_post.account_id = $1
+			) AS is_saved,
+			` + articleHiddenMatch("$1") + ` AS is_hidden,
+			` + articleTagsColumn + `
+		FROM tagged
+		INNER JOIN post
+			ON post.id = tagged.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		GROUP BY post.id
+		ORDER BY post.published_at DESC`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), tag, includeHidden, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +519,7 @@ func (qry *Query) ListRecentArticlesByAccount(account *model.Account, limit, off
 	return articles, nil
 }
 
-func (qry *Query) ListRelevantArticles(search string, limit, offset int) ([]Article, error) {
+func (qry *Query) ListRelevantArticles(ctx context.Context, search string, limit, offset int) ([]Article, error) {
 	stmt := `
 		WITH relevant AS (
 			SELECT
@@ -115,24 +529,31 @@ func (qry *Query) ListRelevantArticles(search string, limit, offset int) ([]Arti
 			LIMIT $2 OFFSET $3
 		)
 		SELECT
+			post.id,
 			post.title,
 			post.url,
 			MAX(blog.title) as blog_title,
 			MAX(blog.site_url) as blog_url,
 			post.published_at,
-			(array_remove(array_agg(tag.name ORDER BY ts_rank_cd(post.fts_data, plainto_tsquery('english', tag.name)) DESC), NULL))[1:3] as tags
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			FALSE AS is_read,
+			FALSE AS is_saved,
+			FALSE AS is_hidden,
+			` + articleTagsColumn + `
 		FROM relevant
 		INNER JOIN post
 			ON post.id = relevant.id
 		INNER JOIN blog
 			ON blog.id = post.blog_id
-		LEFT JOIN tag
-			ON plainto_tsquery('english', tag.name) @@ post.fts_data
 		WHERE post.fts_data @@ websearch_to_tsquery('english',  $1)
 		GROUP BY post.id
 		ORDER BY ts_rank_cd(post.fts_data, websearch_to_tsquery('english',  $1)) DESC`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, search, limit, offset)
+	rows, err := qry.conn.Query(ctx, stmt, search, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +566,8 @@ func (qry *Query) ListRelevantArticles(search string, limit, offset int) ([]Arti
 	return articles, nil
 }
 
-func (qry *Query) ListRelevantArticlesByAccount(account *model.Account, search string, limit, offset int) ([]Article, error) {
+// Articles hidden by the account's content filters are only included if includeHidden is true.
+func (qry *Query) ListRelevantArticlesByAccount(ctx context.Context, account *model.Account, search string, includeHidden bool, limit, offset int) ([]Article, error) {
 	stmt := `
 		WITH relevant AS (
 			SELECT
@@ -156,28 +578,46 @@ func (qry *Query) ListRelevantArticlesByAccount(account *model.Account, search s
 			INNER JOIN account_blog
 				ON account_blog.blog_id = blog.id
 				AND account_blog.account_id = $1
+			WHERE $3 OR NOT ` + articleHiddenMatch("$1") + `
 			ORDER BY ts_rank_cd(post.fts_data, websearch_to_tsquery('english',  $2)) DESC
-			LIMIT $3 OFFSET $4
+			LIMIT $4 OFFSET $5
 		)
 		SELECT
+			post.id,
 			post.title,
 			post.url,
 			MAX(blog.title) as blog_title,
 			MAX(blog.site_url) as blog_url,
 			post.published_at,
-			(array_remove(array_agg(tag.name ORDER BY ts_rank_cd(post.fts_data, plainto_tsquery('english', tag.name)) DESC), NULL))[1:3] as tags
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			) AS is_read,
+			EXISTS (
+				SELECT 1
+				FROM account_post
+				WHERE account_post.post_id = post.id
+					AND account_post.account_id = $1
+			) AS is_saved,
+			` + articleHiddenMatch("$1") + ` AS is_hidden,
+			` + articleTagsColumn + `
 		FROM relevant
 		INNER JOIN post
 			ON post.id = relevant.id
 		INNER JOIN blog
 			ON blog.id = post.blog_id
-		LEFT JOIN tag
-			ON plainto_tsquery('english', tag.name) @@ post.fts_data
 		WHERE post.fts_data @@ websearch_to_tsquery('english',  $2)
 		GROUP BY post.id
 		ORDER BY ts_rank_cd(post.fts_data, websearch_to_tsquery('english',  $2)) DESC`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, account.ID(), search, limit, offset)
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), search, includeHidden, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -190,12 +630,12 @@ func (qry *Query) ListRelevantArticlesByAccount(account *model.Account, search s
 	return articles, nil
 }
 
-func (qry *Query) CountRecentArticles() (int, error) {
+func (qry *Query) CountRecentArticles(ctx context.Context) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM post`
 
-	rows, err := qry.conn.Query(context.Background(), stmt)
+	rows, err := qry.conn.Query(ctx, stmt)
 	if err != nil {
 		return 0, err
 	}
@@ -208,7 +648,8 @@ func (qry *Query) CountRecentArticles() (int, error) {
 	return count, nil
 }
 
-func (qry *Query) CountRecentArticlesByAccount(account *model.Account) (int, error) {
+// Articles hidden by the account's content filters are only counted if includeHidden is true.
+func (qry *Query) CountRecentArticlesByAccount(ctx context.Context, account *model.Account, includeHidden bool) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM post
@@ -216,9 +657,10 @@ func (qry *Query) CountRecentArticlesByAccount(account *model.Account) (int, err
 			ON blog.id = post.blog_id
 		INNER JOIN account_blog
 			ON account_blog.blog_id = blog.id
-			AND account_blog.account_id = $1`
+			AND account_blog.account_id = $1
+		WHERE $2 OR NOT ` + articleHiddenMatch("$1")
 
-	rows, err := qry.conn.Query(context.Background(), stmt, account.ID())
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), includeHidden)
 	if err != nil {
 		return 0, err
 	}
@@ -231,13 +673,108 @@ func (qry *Query) CountRecentArticlesByAccount(account *model.Account) (int, err
 	return count, nil
 }
 
-func (qry *Query) CountRelevantArticles(search string) (int, error) {
+// Articles hidden by the account's content filters are only counted if includeHidden is true.
+func (qry *Query) CountUnreadArticlesByAccount(ctx context.Context, account *model.Account, includeHidden bool) (int, error) {
+	stmt := `
+		SELECT count(*)
+		FROM post
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		INNER JOIN account_blog
+			ON account_blog.blog_id = blog.id
+			AND account_blog.account_id = $1
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM post_read
+			WHERE post_read.post_id = post.id
+				AND post_read.account_id = $1
+		)
+			AND ($2 OR NOT ` + articleHiddenMatch("$1") + `)`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), includeHidden)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := pgx.CollectOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, postgres.CheckReadError(err)
+	}
+
+	return count, nil
+}
+
+func (qry *Query) CountSavedArticlesByAccount(ctx context.Context, account *model.Account) (int, error) {
+	stmt := `
+		SELECT count(*)
+		FROM account_post
+		WHERE account_post.account_id = $1`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := pgx.CollectOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, postgres.CheckReadError(err)
+	}
+
+	return count, nil
+}
+
+func (qry *Query) CountTaggedArticles(ctx context.Context, tag string) (int, error) {
+	stmt := `
+		SELECT count(*)
+		FROM post
+		WHERE ` + articleTagMatch("$1")
+
+	rows, err := qry.conn.Query(ctx, stmt, tag)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := pgx.CollectOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, postgres.CheckReadError(err)
+	}
+
+	return count, nil
+}
+
+// Articles hidden by the account's content filters are only counted if includeHidden is true.
+func (qry *Query) CountTaggedArticlesByAccount(ctx context.Context, account *model.Account, tag string, includeHidden bool) (int, error) {
+	stmt := `
+		SELECT count(*)
+		FROM post
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		INNER JOIN account_blog
+			ON account_blog.blog_id = blog.id
+			AND account_blog.account_id = $1
+		WHERE ` + articleTagMatch("$2") + `
+			AND ($3 OR NOT ` + articleHiddenMatch("$1") + `)`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), tag, includeHidden)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := pgx.CollectOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, postgres.CheckReadError(err)
+	}
+
+	return count, nil
+}
+
+func (qry *Query) CountRelevantArticles(ctx context.Context, search string) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM post
 		WHERE post.fts_data @@ websearch_to_tsquery('english',  $1)`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, search)
+	rows, err := qry.conn.Query(ctx, stmt, search)
 	if err != nil {
 		return 0, err
 	}
@@ -250,7 +787,8 @@ func (qry *Query) CountRelevantArticles(search string) (int, error) {
 	return count, nil
 }
 
-func (qry *Query) CountRelevantArticlesByAccount(account *model.Account, search string) (int, error) {
+// Articles hidden by the account's content filters are only counted if includeHidden is true.
+func (qry *Query) CountRelevantArticlesByAccount(ctx context.Context, account *model.Account, search string, includeHidden bool) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM post
@@ -259,9 +797,105 @@ func (qry *Query) CountRelevantArticlesByAccount(account *model.Account, search
 		INNER JOIN account_blog
 			ON account_blog.blog_id = blog.id
 			AND account_blog.account_id = $1
-		WHERE post.fts_data @@ websearch_to_tsquery('english',  $2)`
+		WHERE post.fts_data @@ websearch_to_tsquery('english',  $2)
+			AND ($3 OR NOT ` + articleHiddenMatch("$1") + `)`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), search, includeHidden)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := pgx.CollectOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, postgres.CheckReadError(err)
+	}
+
+	return count, nil
+}
+
+// Optional filters for narrowing down a list of articles. Empty (zero) values are ignored.
+type ArticleFilter struct {
+	// Only include articles that match this full-text search (ordered by relevance).
+	Search string
+	// Only include articles that match this tag.
+	Tag string
+	// Only include articles from this blog.
+	BlogID uuid.UUID
+}
+
+// Convert an ArticleFilter into query args: search, tag, and blog ID (NULL if unset).
+func (filter ArticleFilter) args() []any {
+	var blogID *uuid.UUID
+	if filter.BlogID != uuid.Nil {
+		blogID = &filter.BlogID
+	}
+
+	return []any{filter.Search, filter.Tag, blogID}
+}
+
+func (qry *Query) ListFilteredArticles(ctx context.Context, filter ArticleFilter, limit, offset int) ([]Article, error) {
+	stmt := `
+		WITH filtered AS (
+			SELECT
+				post.id
+			FROM post
+			WHERE ($1 = '' OR post.fts_data @@ websearch_to_tsquery('english',  $1))
+				AND ($2 = '' OR ` + articleTagMatch("$2") + `)
+				AND ($3::uuid IS NULL OR post.blog_id = $3::uuid)
+			ORDER BY
+				CASE WHEN $1 = '' THEN 0 ELSE ts_rank_cd(post.fts_data, websearch_to_tsquery('english',  $1)) END DESC,
+				post.published_at DESC
+			LIMIT $4 OFFSET $5
+		)
+		SELECT
+			post.id,
+			post.title,
+			post.url,
+			MAX(blog.title) as blog_title,
+			MAX(blog.site_url) as blog_url,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			FALSE AS is_read,
+			FALSE AS is_saved,
+			FALSE AS is_hidden,
+			` + articleTagsColumn + `
+		FROM filtered
+		INNER JOIN post
+			ON post.id = filtered.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		GROUP BY post.id
+		ORDER BY
+			CASE WHEN $1 = '' THEN 0 ELSE ts_rank_cd(post.fts_data, websearch_to_tsquery('english',  $1)) END DESC,
+			post.published_at DESC`
+
+	args := append(filter.args(), limit, offset)
+	rows, err := qry.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pgx.CollectRows(rows, pgx.RowToStructByName[Article])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return articles, nil
+}
+
+func (qry *Query) CountFilteredArticles(ctx context.Context, filter ArticleFilter) (int, error) {
+	stmt := `
+		SELECT count(*)
+		FROM post
+		WHERE ($1 = '' OR post.fts_data @@ websearch_to_tsquery('english',  $1))
+			AND ($2 = '' OR ` + articleTagMatch("$2") + `)
+			AND ($3::uuid IS NULL OR post.blog_id = $3::uuid)`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, account.ID(), search)
+	rows, err := qry.conn.Query(ctx, stmt, filter.args()...)
 	if err != nil {
 		return 0, err
 	}
@@ -1,9 +1,12 @@
 package query_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/query"
 	"github.com/theandrew168/bloggulus/backend/test"
 	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
@@ -20,7 +23,7 @@ func TestListArticles(t *testing.T) {
 	blog := test.CreateBlog(t, repo)
 	test.CreatePost(t, repo, blog)
 
-	articles, err := find.ListRecentArticles(1, 0)
+	articles, err := find.ListRecentArticles(context.Background(), 1, 0)
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, len(articles), 1)
@@ -49,13 +52,234 @@ func TestListArticlesByAccount(t *testing.T) {
 	test.CreateAccountBlog(t, repo, account, followedBlog)
 
 	// List posts from blogs followed by this account.
-	articles, err := find.ListRecentArticlesByAccount(account, 5, 0)
+	articles, err := find.ListRecentArticlesByAccount(context.Background(), account, false, 5, 0)
 	test.AssertNilError(t, err)
 
 	// We should only get the three posts associated with the followed blog.
 	test.AssertEqual(t, len(articles), 3)
 }
 
+func TestListArticlesByAccountHidden(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	since := timeutil.Now()
+
+	blog := test.CreateBlog(t, repo)
+	test.CreatePost(t, repo, blog)
+
+	// This post will be hidden by its title.
+	title := "Weekly Links #" + test.RandomString(8)
+	titledPost, err := model.NewPost(blog, test.RandomURL(20), title, test.RandomString(200), timeutil.Now())
+	test.AssertNilError(t, err)
+	err = repo.Post().Create(context.Background(), titledPost)
+	test.AssertNilError(t, err)
+
+	// And this one will be hidden by its blog.
+	mutedBlog := test.CreateBlog(t, repo)
+	mutedPost := test.CreatePost(t, repo, mutedBlog)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+	test.CreateAccountBlog(t, repo, account, mutedBlog)
+
+	for _, filter := range []struct {
+		kind  model.ContentFilterKind
+		value string
+	}{
+		{model.ContentFilterKindTitle, "^weekly links"},
+		{model.ContentFilterKindBlog, mutedBlog.ID().String()},
+	} {
+		contentFilter, err := model.NewContentFilter(account, filter.kind, filter.value)
+		test.AssertNilError(t, err)
+
+		err = repo.ContentFilter().Create(context.Background(), contentFilter)
+		test.AssertNilError(t, err)
+	}
+
+	// Hidden posts shouldn't be listed or counted.
+	articles, err := find.ListRecentArticlesByAccount(context.Background(), account, false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+
+	count, err := find.CountRecentArticlesByAccount(context.Background(), account, false)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+
+	// Unless they are explicitly asked for (in which case they are flagged).
+	articles, err = find.ListRecentArticlesByAccount(context.Background(), account, true, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 3)
+	for _, article := range articles {
+		test.AssertEqual(t, article.IsHidden, article.ID == titledPost.ID() || article.ID == mutedPost.ID())
+	}
+
+	count, err = find.CountRecentArticlesByAccount(context.Background(), account, true)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 3)
+
+	// Filters also apply when searching.
+	articles, err = find.ListRelevantArticlesByAccount(context.Background(), account, "weekly links", false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 0)
+
+	count, err = find.CountRelevantArticlesByAccount(context.Background(), account, "weekly links", true)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+
+	// And when filtering by tag.
+	articles, err = find.ListTaggedArticlesByAccount(context.Background(), account, "weekly links", false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 0)
+
+	count, err = find.CountTaggedArticlesByAccount(context.Background(), account, "weekly links", true)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+
+	// And when only listing unread posts.
+	articles, err = find.ListUnreadArticlesByAccount(context.Background(), account, false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+
+	count, err = find.CountUnreadArticlesByAccount(context.Background(), account, true)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 3)
+
+	// Hidden posts are never included in digests.
+	articles, err = find.ListNewArticlesByAccount(context.Background(), account, since, 5)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+	test.AssertEqual(t, articles[0].IsHidden, false)
+
+	// Or in a blog's unread count.
+	blogs, err := find.ListBlogsForAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+	for _, b := range blogs {
+		if b.ID == blog.ID() {
+			test.AssertEqual(t, b.UnreadCount, 1)
+		}
+		if b.ID == mutedBlog.ID() {
+			test.AssertEqual(t, b.UnreadCount, 0)
+		}
+	}
+}
+
+func TestListUnreadArticlesByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	blog := test.CreateBlog(t, repo)
+	readPost := test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	err := repo.PostRead().Create(context.Background(), account, readPost)
+	test.AssertNilError(t, err)
+
+	// The read post should be flagged in the normal list.
+	articles, err := find.ListRecentArticlesByAccount(context.Background(), account, false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 3)
+	for _, article := range articles {
+		test.AssertEqual(t, article.IsRead, article.ID == readPost.ID())
+	}
+
+	// And excluded from the unread list.
+	articles, err = find.ListUnreadArticlesByAccount(context.Background(), account, false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 2)
+	for _, article := range articles {
+		test.AssertNotEqual(t, article.ID, readPost.ID())
+	}
+
+	count, err := find.CountUnreadArticlesByAccount(context.Background(), account, false)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 2)
+}
+
+func TestListSavedArticlesByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	followedBlog := test.CreateBlog(t, repo)
+	savedPost := test.CreatePost(t, repo, followedBlog)
+	test.CreatePost(t, repo, followedBlog)
+
+	// Saved posts don't require the account to follow their blog.
+	unfollowedBlog := test.CreateBlog(t, repo)
+	otherSavedPost := test.CreatePost(t, repo, unfollowedBlog)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, followedBlog)
+	test.CreateAccountPost(t, repo, account, savedPost)
+	test.CreateAccountPost(t, repo, account, otherSavedPost)
+
+	// The saved post should be flagged in the normal list.
+	articles, err := find.ListRecentArticlesByAccount(context.Background(), account, false, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 2)
+	for _, article := range articles {
+		test.AssertEqual(t, article.IsSaved, article.ID == savedPost.ID())
+	}
+
+	// And listed (most recently saved first) in the saved list.
+	articles, err = find.ListSavedArticlesByAccount(context.Background(), account, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 2)
+	test.AssertEqual(t, articles[0].ID, otherSavedPost.ID())
+	test.AssertEqual(t, articles[1].ID, savedPost.ID())
+	for _, article := range articles {
+		test.AssertEqual(t, article.IsSaved, true)
+	}
+
+	count, err := find.CountSavedArticlesByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 2)
+}
+
+func TestListNewArticlesByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	blog := test.CreateBlog(t, repo)
+	test.CreatePost(t, repo, blog)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	// Only posts synced after this point should be considered new.
+	since := timeutil.Now()
+	newPost := test.CreatePost(t, repo, blog)
+
+	articles, err := find.ListNewArticlesByAccount(context.Background(), account, since, 5)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+	test.AssertEqual(t, articles[0].ID, newPost.ID())
+}
+
 func TestSearchArticles(t *testing.T) {
 	t.Parallel()
 
@@ -66,7 +290,7 @@ func TestSearchArticles(t *testing.T) {
 	defer findCloser()
 
 	blog := test.NewBlog(t)
-	err := repo.Blog().Create(blog)
+	err := repo.Blog().Create(context.Background(), blog)
 	test.AssertNilError(t, err)
 
 	// create a post about python
@@ -79,7 +303,7 @@ func TestSearchArticles(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Post().Create(pythonPost)
+	err = repo.Post().Create(context.Background(), pythonPost)
 	test.AssertNilError(t, err)
 
 	// create a post about python
@@ -92,11 +316,11 @@ func TestSearchArticles(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Post().Create(boringPost)
+	err = repo.Post().Create(context.Background(), boringPost)
 	test.AssertNilError(t, err)
 
 	// list articles that relate to python
-	articles, err := find.ListRelevantArticles("python", 1, 0)
+	articles, err := find.ListRelevantArticles(context.Background(), "python", 1, 0)
 	test.AssertNilError(t, err)
 
 	// should find at least one
@@ -124,7 +348,7 @@ func TestSearchArticlesByAccount(t *testing.T) {
 		)
 		test.AssertNilError(t, err)
 
-		err = repo.Post().Create(post)
+		err = repo.Post().Create(context.Background(), post)
 		test.AssertNilError(t, err)
 	}
 
@@ -140,7 +364,7 @@ func TestSearchArticlesByAccount(t *testing.T) {
 		)
 		test.AssertNilError(t, err)
 
-		err = repo.Post().Create(post)
+		err = repo.Post().Create(context.Background(), post)
 		test.AssertNilError(t, err)
 	}
 
@@ -148,7 +372,7 @@ func TestSearchArticlesByAccount(t *testing.T) {
 	test.CreateAccountBlog(t, repo, account, followedBlog)
 
 	// List posts (from followed blogs) that relate to python.
-	articles, err := find.ListRelevantArticlesByAccount(account, "python", 5, 0)
+	articles, err := find.ListRelevantArticlesByAccount(context.Background(), account, "python", false, 5, 0)
 	test.AssertNilError(t, err)
 
 	// Should only return the three posts from followed blogs.
@@ -169,7 +393,7 @@ func TestCountArticles(t *testing.T) {
 	test.CreatePost(t, repo, blog)
 	test.CreatePost(t, repo, blog)
 
-	count, err := find.CountRecentArticles()
+	count, err := find.CountRecentArticles(context.Background())
 	test.AssertNilError(t, err)
 
 	test.AssertAtLeast(t, count, 3)
@@ -198,7 +422,7 @@ func TestCountArticlesByAccount(t *testing.T) {
 	test.CreateAccountBlog(t, repo, account, followedBlog)
 
 	// We should only count the three posts associated with the followed blog.
-	count, err := find.CountRecentArticlesByAccount(account)
+	count, err := find.CountRecentArticlesByAccount(context.Background(), account, false)
 	test.AssertNilError(t, err)
 	test.AssertEqual(t, count, 3)
 }
@@ -224,7 +448,7 @@ func TestCountSearchArticles(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Post().Create(pythonPost)
+	err = repo.Post().Create(context.Background(), pythonPost)
 	test.AssertNilError(t, err)
 
 	// create a post about python
@@ -237,11 +461,11 @@ func TestCountSearchArticles(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Post().Create(boringPost)
+	err = repo.Post().Create(context.Background(), boringPost)
 	test.AssertNilError(t, err)
 
 	// count posts that relate to python
-	count, err := find.CountRelevantArticles("python")
+	count, err := find.CountRelevantArticles(context.Background(), "python")
 	test.AssertNilError(t, err)
 
 	// should find at least one
@@ -269,7 +493,7 @@ func TestCountSearchArticlesByAccount(t *testing.T) {
 		)
 		test.AssertNilError(t, err)
 
-		err = repo.Post().Create(post)
+		err = repo.Post().Create(context.Background(), post)
 		test.AssertNilError(t, err)
 	}
 
@@ -285,7 +509,7 @@ func TestCountSearchArticlesByAccount(t *testing.T) {
 		)
 		test.AssertNilError(t, err)
 
-		err = repo.Post().Create(post)
+		err = repo.Post().Create(context.Background(), post)
 		test.AssertNilError(t, err)
 	}
 
@@ -293,9 +517,92 @@ func TestCountSearchArticlesByAccount(t *testing.T) {
 	test.CreateAccountBlog(t, repo, account, followedBlog)
 
 	// Count posts (from followed blogs) that relate to python.
-	count, err := find.CountRelevantArticlesByAccount(account, "python")
+	count, err := find.CountRelevantArticlesByAccount(context.Background(), account, "python", false)
 	test.AssertNilError(t, err)
 
 	// Should only return the three posts from followed blogs.
 	test.AssertEqual(t, count, 3)
 }
+
+func TestListArticlesMergesPostTags(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	// Tag the post with a feed-provided tag that has an alias.
+	tagAlias := test.CreateTagAlias(t, repo)
+	err := repo.PostTag().Replace(context.Background(), post, []string{tagAlias.Alias()})
+	test.AssertNilError(t, err)
+
+	// Filtering by the alias's canonical name (ignoring case) should find the post.
+	filter := query.ArticleFilter{
+		Tag:    strings.ToUpper(tagAlias.Name()),
+		BlogID: blog.ID(),
+	}
+	articles, err := find.ListFilteredArticles(context.Background(), filter, 5, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 1)
+
+	// The post's tags should include the canonical name (not the alias).
+	test.AssertSliceContains(t, articles[0].Tags, tagAlias.Name())
+	test.AssertSliceDoesNotContain(t, articles[0].Tags, tagAlias.Alias())
+
+	count, err := find.CountFilteredArticles(context.Background(), filter)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 1)
+}
+
+func TestListTaggedArticles(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	tag := test.RandomString(32)
+
+	// Create some posts from a followed blog (only some of which are tagged).
+	followedBlog := test.CreateBlog(t, repo)
+	for i := 0; i < 3; i++ {
+		post := test.CreatePost(t, repo, followedBlog)
+		err := repo.PostTag().Replace(context.Background(), post, []string{tag})
+		test.AssertNilError(t, err)
+	}
+	test.CreatePost(t, repo, followedBlog)
+
+	// Create a tagged post from an unfollowed blog.
+	unfollowedBlog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, unfollowedBlog)
+	err := repo.PostTag().Replace(context.Background(), post, []string{tag})
+	test.AssertNilError(t, err)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, followedBlog)
+
+	// All tagged posts should be listed.
+	articles, err := find.ListTaggedArticles(context.Background(), tag, 10, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 4)
+
+	count, err := find.CountTaggedArticles(context.Background(), tag)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 4)
+
+	// Only tagged posts from followed blogs should be listed for the account.
+	articles, err = find.ListTaggedArticlesByAccount(context.Background(), account, tag, false, 10, 0)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(articles), 3)
+
+	count, err = find.CountTaggedArticlesByAccount(context.Background(), account, tag, false)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, count, 3)
+}
@@ -10,27 +10,44 @@ import (
 )
 
 type BlogForAccount struct {
-	ID          uuid.UUID `db:"id"`
-	Title       string    `db:"title"`
-	SiteURL     string    `db:"site_url"`
-	IsFollowing bool      `db:"is_following"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	Title       string    `db:"title" json:"title"`
+	SiteURL     string    `db:"site_url" json:"siteURL"`
+	IsFollowing bool      `db:"is_following" json:"isFollowing"`
+	UnreadCount int       `db:"unread_count" json:"unreadCount"`
 }
 
+// Unread counts don't include articles hidden by the account's content filters.
 // TODO: Paginate this (will need to add a CountBlogsForAccount method).
-func (qry *Query) ListBlogsForAccount(account *model.Account) ([]BlogForAccount, error) {
+func (qry *Query) ListBlogsForAccount(ctx context.Context, account *model.Account) ([]BlogForAccount, error) {
 	stmt := `
 		SELECT
 			blog.id,
 			blog.title,
 			blog.site_url,
-			account_blog IS NOT NULL AS is_following
+			account_blog IS NOT NULL AS is_following,
+			CASE
+				WHEN account_blog IS NULL THEN 0
+				ELSE (
+					SELECT count(*)
+					FROM post
+					WHERE post.blog_id = blog.id
+						AND NOT EXISTS (
+							SELECT 1
+							FROM post_read
+							WHERE post_read.post_id = post.id
+								AND post_read.account_id = $1
+						)
+						AND NOT ` + articleHiddenMatch("$1") + `
+				)
+			END AS unread_count
 		FROM blog
 		LEFT JOIN account_blog
 			ON account_blog.blog_id = blog.id
 			AND account_blog.account_id = $1
 		ORDER BY blog.created_at DESC`
 
-	rows, err := qry.conn.Query(context.Background(), stmt, account.ID())
+	rows, err := qry.conn.Query(ctx, stmt, account.ID())
 	if err != nil {
 		return nil, err
 	}
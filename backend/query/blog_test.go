@@ -1,6 +1,7 @@
 package query_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/theandrew168/bloggulus/backend/test"
@@ -24,7 +25,7 @@ func TestListBlogsForAccount(t *testing.T) {
 	// Create another blog but don't follow it.
 	test.CreateBlog(t, repo)
 
-	blogs, err := find.ListBlogsForAccount(account)
+	blogs, err := find.ListBlogsForAccount(context.Background(), account)
 	test.AssertNilError(t, err)
 
 	// Count how many blogs are being followed.
@@ -38,3 +39,33 @@ func TestListBlogsForAccount(t *testing.T) {
 	// Should only be one.
 	test.AssertEqual(t, followed, 1)
 }
+
+func TestListBlogsForAccountUnreadCount(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	account := test.CreateAccount(t, repo)
+
+	blog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	readPost := test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+
+	err := repo.PostRead().Create(context.Background(), account, readPost)
+	test.AssertNilError(t, err)
+
+	blogs, err := find.ListBlogsForAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	for _, b := range blogs {
+		if b.ID == blog.ID() {
+			test.AssertEqual(t, b.UnreadCount, 1)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+type ContentFilter struct {
+	ID    uuid.UUID `db:"id" json:"id"`
+	Kind  string    `db:"kind" json:"kind"`
+	Value string    `db:"value" json:"value"`
+
+	// Only set for blog filters.
+	BlogTitle string `db:"blog_title" json:"blogTitle,omitempty"`
+
+	// How many articles (from the blogs that the account follows) this filter hides.
+	HiddenCount int `db:"hidden_count" json:"hiddenCount"`
+}
+
+func (qry *Query) ListContentFiltersByAccount(ctx context.Context, account *model.Account) ([]ContentFilter, error) {
+	stmt := `
+		SELECT
+			content_filter.id,
+			content_filter.kind,
+			content_filter.value,
+			COALESCE(filtered_blog.title, '') AS blog_title,
+			(
+				SELECT count(*)
+				FROM post
+				INNER JOIN account_blog
+					ON account_blog.blog_id = post.blog_id
+					AND account_blog.account_id = content_filter.account_id
+				WHERE ` + contentFilterMatch + `
+			) AS hidden_count
+		FROM content_filter
+		LEFT JOIN blog filtered_blog
+			ON filtered_blog.id = content_filter.blog_id
+		WHERE content_filter.account_id = $1
+		ORDER BY content_filter.created_at ASC`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := pgx.CollectRows(rows, pgx.RowToStructByName[ContentFilter])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,43 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestListContentFiltersByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	blog := test.CreateBlog(t, repo)
+	test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	filter, err := model.NewContentFilter(account, model.ContentFilterKindBlog, blog.ID().String())
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), filter)
+	test.AssertNilError(t, err)
+
+	// filters from other accounts shouldn't be included
+	test.CreateContentFilter(t, repo, test.CreateAccount(t, repo))
+
+	filters, err := find.ListContentFiltersByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(filters), 1)
+
+	test.AssertEqual(t, filters[0].ID, filter.ID())
+	test.AssertEqual(t, filters[0].BlogTitle, blog.Title())
+	test.AssertEqual(t, filters[0].HiddenCount, 2)
+}
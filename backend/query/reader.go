@@ -0,0 +1,98 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+// A single article (with its stored content) as shown in the reader view. The newer and
+// older IDs point to the neighboring articles in the account's timeline (nil at either end).
+type ReaderArticle struct {
+	ID          uuid.UUID  `db:"id"`
+	Title       string     `db:"title"`
+	URL         string     `db:"url"`
+	BlogTitle   string     `db:"blog_title"`
+	BlogURL     string     `db:"blog_url"`
+	PublishedAt time.Time  `db:"published_at"`
+	Authors     []string   `db:"authors"`
+	Content     string     `db:"content"`
+	FullContent string     `db:"full_content"`
+	IsRead      bool       `db:"is_read"`
+	IsSaved     bool       `db:"is_saved"`
+	NewerID     *uuid.UUID `db:"newer_id"`
+	OlderID     *uuid.UUID `db:"older_id"`
+}
+
+// The account's timeline matches the index page: posts from followed blogs (that aren't
+// hidden by a content filter) ordered by when they were published. Post IDs break ties.
+func readerNeighborID(comparison, direction string) string {
+	return `(
+				SELECT post.id
+				FROM post
+				INNER JOIN account_blog
+					ON account_blog.blog_id = post.blog_id
+					AND account_blog.account_id = $1
+				WHERE (post.published_at, post.id) ` + comparison + ` (selected.published_at, selected.id)
+					AND NOT ` + articleHiddenMatch("$1") + `
+				ORDER BY post.published_at ` + direction + `, post.id ` + direction + `
+				LIMIT 1
+			)`
+}
+
+func (qry *Query) ReadReaderArticleByAccount(ctx context.Context, account *model.Account, postID uuid.UUID) (ReaderArticle, error) {
+	stmt := `
+		WITH selected AS (
+			SELECT
+				post.id,
+				post.published_at
+			FROM post
+			WHERE post.id = $2
+		)
+		SELECT
+			post.id,
+			post.title,
+			post.url,
+			blog.title AS blog_title,
+			blog.site_url AS blog_url,
+			post.published_at,
+			post.authors,
+			post.content,
+			post.full_content,
+			EXISTS (
+				SELECT 1
+				FROM post_read
+				WHERE post_read.post_id = post.id
+					AND post_read.account_id = $1
+			) AS is_read,
+			EXISTS (
+				SELECT 1
+				FROM account_post
+				WHERE account_post.post_id = post.id
+					AND account_post.account_id = $1
+			) AS is_saved,
+			` + readerNeighborID(">", "ASC") + ` AS newer_id,
+			` + readerNeighborID("<", "DESC") + ` AS older_id
+		FROM selected
+		INNER JOIN post
+			ON post.id = selected.id
+		INNER JOIN blog
+			ON blog.id = post.blog_id`
+
+	rows, err := qry.conn.Query(ctx, stmt, account.ID(), postID)
+	if err != nil {
+		return ReaderArticle{}, err
+	}
+
+	article, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[ReaderArticle])
+	if err != nil {
+		return ReaderArticle{}, postgres.CheckReadError(err)
+	}
+
+	return article, nil
+}
@@ -0,0 +1,116 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestReadReaderArticleByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	blog := test.CreateBlog(t, repo)
+
+	// Create three posts (published one hour apart) to form a timeline.
+	now := timeutil.Now()
+	var posts []*model.Post
+	for i := range 3 {
+		post, err := model.NewPost(blog, test.RandomURL(20), test.RandomString(20), test.RandomString(200), now.Add(time.Duration(-i)*time.Hour))
+		test.AssertNilError(t, err)
+		err = repo.Post().Create(context.Background(), post)
+		test.AssertNilError(t, err)
+
+		posts = append(posts, post)
+	}
+
+	newest, middle, oldest := posts[0], posts[1], posts[2]
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+	test.CreateAccountPost(t, repo, account, middle)
+
+	article, err := find.ReadReaderArticleByAccount(context.Background(), account, middle.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, article.ID, middle.ID())
+	test.AssertEqual(t, article.Content, middle.Content())
+	test.AssertEqual(t, article.BlogURL, blog.SiteURL())
+	test.AssertEqual(t, article.IsSaved, true)
+	test.AssertEqual(t, *article.NewerID, newest.ID())
+	test.AssertEqual(t, *article.OlderID, oldest.ID())
+
+	// The ends of the timeline don't have anything further to link to.
+	article, err = find.ReadReaderArticleByAccount(context.Background(), account, newest.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, article.NewerID == nil, true)
+	test.AssertEqual(t, *article.OlderID, middle.ID())
+
+	article, err = find.ReadReaderArticleByAccount(context.Background(), account, oldest.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, *article.NewerID, middle.ID())
+	test.AssertEqual(t, article.OlderID == nil, true)
+}
+
+func TestReadReaderArticleByAccountHidden(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	blog := test.CreateBlog(t, repo)
+
+	now := timeutil.Now()
+	var posts []*model.Post
+	for i := range 3 {
+		post, err := model.NewPost(blog, test.RandomURL(20), test.RandomString(20), test.RandomString(200), now.Add(time.Duration(-i)*time.Hour))
+		test.AssertNilError(t, err)
+		err = repo.Post().Create(context.Background(), post)
+		test.AssertNilError(t, err)
+
+		posts = append(posts, post)
+	}
+
+	newest, middle, oldest := posts[0], posts[1], posts[2]
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	// Hide the middle post by its title.
+	filter, err := model.NewContentFilter(account, model.ContentFilterKindTitle, middle.Title())
+	test.AssertNilError(t, err)
+	err = repo.ContentFilter().Create(context.Background(), filter)
+	test.AssertNilError(t, err)
+
+	// Hidden posts are skipped when navigating the timeline.
+	article, err := find.ReadReaderArticleByAccount(context.Background(), account, newest.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, *article.OlderID, oldest.ID())
+}
+
+func TestReadReaderArticleByAccountNotFound(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	account := test.CreateAccount(t, repo)
+
+	_, err := find.ReadReaderArticleByAccount(context.Background(), account, test.NewPost(t, test.NewBlog(t)).ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+type tagArticleCount struct {
+	Name  string `db:"name"`
+	Count int    `db:"count"`
+}
+
+// Count the articles published since a given time for each known tag. Articles are
+// matched the same way as when listing tagged articles (by content or explicit tags).
+// Only the newest "limit" tags are counted (the same ones that TagRepository.List returns).
+func (qry *Query) CountRecentArticlesByTag(ctx context.Context, since time.Time, limit int) (map[string]int, error) {
+	stmt := `
+		WITH listed_tag AS (
+			SELECT
+				tag.name
+			FROM tag
+			ORDER BY tag.created_at DESC
+			LIMIT $2
+		)
+		SELECT
+			listed_tag.name,
+			count(post.id) AS count
+		FROM listed_tag
+		LEFT JOIN post
+			ON post.published_at >= $1
+			AND ` + articleTagMatch("listed_tag.name") + `
+		GROUP BY listed_tag.name`
+
+	rows, err := qry.conn.Query(ctx, stmt, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tagCounts, err := pgx.CollectRows(rows, pgx.RowToStructByName[tagArticleCount])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	counts := make(map[string]int)
+	for _, tagCount := range tagCounts {
+		counts[tagCount.Name] = tagCount.Count
+	}
+
+	return counts, nil
+}
+
+// Count all articles (regardless of when they were published) for each known tag.
+func (qry *Query) CountArticlesByTag(ctx context.Context, limit int) (map[string]int, error) {
+	return qry.CountRecentArticlesByTag(ctx, time.Time{}, limit)
+}
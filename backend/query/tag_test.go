@@ -0,0 +1,64 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestCountRecentArticlesByTag(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	tag := test.CreateTag(t, repo)
+
+	// Create a recent post and an old post that are both tagged.
+	blog := test.CreateBlog(t, repo)
+	for _, publishedAt := range []time.Time{timeutil.Now(), timeutil.Now().AddDate(-1, 0, 0)} {
+		post, err := model.NewPost(blog, test.RandomURL(20), test.RandomString(20), test.RandomString(200), publishedAt)
+		test.AssertNilError(t, err)
+
+		err = repo.Post().Create(context.Background(), post)
+		test.AssertNilError(t, err)
+
+		err = repo.PostTag().Replace(context.Background(), post, []string{model.NormalizeTagName(tag.Name())})
+		test.AssertNilError(t, err)
+	}
+
+	// Only the recent post should be counted.
+	counts, err := find.CountRecentArticlesByTag(context.Background(), timeutil.Now().AddDate(0, -1, 0), 10)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, counts[tag.Name()], 1)
+
+	// Both posts should be counted when ignoring when they were published.
+	counts, err = find.CountArticlesByTag(context.Background(), 10)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, counts[tag.Name()], 2)
+}
+
+func TestCountRecentArticlesByTagLimit(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	find, findCloser := test.NewQuery(t)
+	defer findCloser()
+
+	test.CreateTag(t, repo)
+	test.CreateTag(t, repo)
+
+	// Only the requested number of tags should be counted.
+	counts, err := find.CountArticlesByTag(context.Background(), 1)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(counts), 1)
+}
@@ -0,0 +1,14 @@
+package readability
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrUnreachablePage = errors.New("readability: unreachable page")
+)
+
+type PageFetcher interface {
+	FetchPage(ctx context.Context, url string) (string, error)
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/theandrew168/bloggulus/backend/readability"
+)
+
+// ensure PageFetcher interface is satisfied
+var _ readability.PageFetcher = (*PageFetcher)(nil)
+
+type PageFetcher struct {
+	pages map[string]string
+}
+
+func NewPageFetcher(pages map[string]string) *PageFetcher {
+	f := PageFetcher{pages: pages}
+	return &f
+}
+
+func (f *PageFetcher) FetchPage(ctx context.Context, url string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	page, ok := f.pages[url]
+	if !ok {
+		return "", readability.ErrUnreachablePage
+	}
+
+	return page, nil
+}
@@ -0,0 +1,391 @@
+package readability
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	ErrNoContent = errors.New("readability: no content found")
+)
+
+// Pages whose main content is shorter than this (in characters) aren't worth keeping.
+const MinContentLength = 250
+
+// Elements that never hold an article's main content.
+var removeTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Canvas:   true,
+	atom.Svg:      true,
+	atom.Form:     true,
+	atom.Button:   true,
+	atom.Input:    true,
+	atom.Select:   true,
+	atom.Textarea: true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+}
+
+// Elements that separate blocks of text (used when deciding if a <div> acts like a
+// paragraph and when converting the extracted content back into text).
+var blockTags = map[atom.Atom]bool{
+	atom.Address:    true,
+	atom.Article:    true,
+	atom.Blockquote: true,
+	atom.Br:         true,
+	atom.Dd:         true,
+	atom.Div:        true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Figcaption: true,
+	atom.Figure:     true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Hr:         true,
+	atom.Li:         true,
+	atom.Main:       true,
+	atom.Ol:         true,
+	atom.P:          true,
+	atom.Pre:        true,
+	atom.Section:    true,
+	atom.Table:      true,
+	atom.Tr:         true,
+	atom.Ul:         true,
+}
+
+// Class and ID patterns (borrowed from Mozilla's Readability) that hint at whether
+// an element is part of an article or just page furniture.
+var (
+	unlikelyPattern = regexp.MustCompile(`(?i)-ad-|banner|breadcrumbs|combx|comment|community|disqus|extra|footer|gdpr|header|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|pagination|pager|popup`)
+	maybePattern    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positivePattern = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativePattern = regexp.MustCompile(`(?i)-ad-|hidden|banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+)
+
+// Extract the main content of an HTML page (like a blog post) as plain text. This is a
+// simplified version of the Readability algorithm: page furniture (navigation, sidebars,
+// comments, etc) is removed, paragraphs award points to their containers, and the best
+// container (along with any related siblings) is kept. Paragraphs are separated by blank lines.
+func Extract(page string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return "", ErrNoContent
+	}
+
+	prune(doc)
+
+	scores := make(map[*html.Node]float64)
+	for _, node := range findParagraphs(doc) {
+		text := textContent(node)
+		if len(text) < 25 {
+			continue
+		}
+
+		// Longer paragraphs (with more commas) are more likely to be part of the article.
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text)/100), 3)
+
+		parent := node.Parent
+		if parent == nil || parent.Type != html.ElementNode {
+			continue
+		}
+		if _, ok := scores[parent]; !ok {
+			scores[parent] = initialScore(parent)
+		}
+		scores[parent] += score
+
+		grandparent := parent.Parent
+		if grandparent == nil || grandparent.Type != html.ElementNode {
+			continue
+		}
+		if _, ok := scores[grandparent]; !ok {
+			scores[grandparent] = initialScore(grandparent)
+		}
+		scores[grandparent] += score / 2
+	}
+
+	// Containers that are mostly links (like lists of related posts) are penalized.
+	var top *html.Node
+	for node, score := range scores {
+		score *= 1 - linkDensity(node)
+		scores[node] = score
+
+		if top == nil || score > scores[top] {
+			top = node
+		}
+	}
+
+	if top == nil {
+		return "", ErrNoContent
+	}
+
+	var paragraphs []string
+	for _, node := range relatedSiblings(top, scores) {
+		paragraphs = append(paragraphs, blockText(node)...)
+	}
+
+	content := strings.Join(paragraphs, "\n\n")
+	if len(content) < MinContentLength {
+		return "", ErrNoContent
+	}
+
+	return content, nil
+}
+
+// Remove elements that are unlikely to be part of the main content.
+func prune(node *html.Node) {
+	var next *html.Node
+	for child := node.FirstChild; child != nil; child = next {
+		next = child.NextSibling
+
+		if child.Type == html.CommentNode || (child.Type == html.ElementNode && isUnlikely(child)) {
+			node.RemoveChild(child)
+			continue
+		}
+
+		prune(child)
+	}
+}
+
+func isUnlikely(node *html.Node) bool {
+	if removeTags[node.DataAtom] {
+		return true
+	}
+
+	if hasAttr(node, "hidden") || getAttr(node, "aria-hidden") == "true" {
+		return true
+	}
+
+	// Never remove the elements that likely wrap the entire article.
+	switch node.DataAtom {
+	case atom.Html, atom.Body, atom.Article, atom.Main:
+		return false
+	}
+
+	match := getAttr(node, "class") + " " + getAttr(node, "id")
+	return unlikelyPattern.MatchString(match) && !maybePattern.MatchString(match)
+}
+
+// Find the elements that hold paragraphs of text. A <div> without any block-level
+// children is treated as a paragraph too (since some pages don't use <p> tags).
+func findParagraphs(node *html.Node) []*html.Node {
+	var paragraphs []*html.Node
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch node.DataAtom {
+			case atom.P, atom.Pre, atom.Td:
+				paragraphs = append(paragraphs, node)
+				return
+			case atom.Div:
+				if !hasBlockChildren(node) {
+					paragraphs = append(paragraphs, node)
+					return
+				}
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return paragraphs
+}
+
+func hasBlockChildren(node *html.Node) bool {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && blockTags[child.DataAtom] && child.DataAtom != atom.Br {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Score a container based on its tag and its class / ID names.
+func initialScore(node *html.Node) float64 {
+	var score float64
+	switch node.DataAtom {
+	case atom.Article:
+		score = 10
+	case atom.Div, atom.Main, atom.Section:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+
+	for _, name := range []string{getAttr(node, "class"), getAttr(node, "id")} {
+		if name == "" {
+			continue
+		}
+		if negativePattern.MatchString(name) {
+			score -= 25
+		}
+		if positivePattern.MatchString(name) {
+			score += 25
+		}
+	}
+
+	return score
+}
+
+// Determine how much of a node's text is made up of links (from 0 to 1).
+func linkDensity(node *html.Node) float64 {
+	textLength := len(textContent(node))
+	if textLength == 0 {
+		return 0
+	}
+
+	linkLength := 0
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.DataAtom == atom.A {
+			linkLength += len(textContent(node))
+			return
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return float64(linkLength) / float64(textLength)
+}
+
+// Gather the top candidate along with any siblings that look like they belong to the
+// same article (like an intro paragraph that lives outside of the main container).
+func relatedSiblings(top *html.Node, scores map[*html.Node]float64) []*html.Node {
+	if top.Parent == nil {
+		return []*html.Node{top}
+	}
+
+	threshold := math.Max(10, scores[top]*0.2)
+
+	var nodes []*html.Node
+	for sibling := top.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type != html.ElementNode {
+			continue
+		}
+
+		if sibling == top {
+			nodes = append(nodes, sibling)
+			continue
+		}
+
+		if score, ok := scores[sibling]; ok && score >= threshold {
+			nodes = append(nodes, sibling)
+			continue
+		}
+
+		if sibling.DataAtom == atom.P {
+			text := textContent(sibling)
+			density := linkDensity(sibling)
+			if (len(text) > 80 && density < 0.25) || (density == 0 && strings.Contains(text, ". ")) {
+				nodes = append(nodes, sibling)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// Convert a node into blocks of text (one per paragraph, heading, list item, etc).
+func blockText(node *html.Node) []string {
+	var blocks []string
+	var current strings.Builder
+
+	flush := func() {
+		text := strings.Join(strings.Fields(current.String()), " ")
+		if text != "" {
+			blocks = append(blocks, text)
+		}
+		current.Reset()
+	}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			current.WriteString(node.Data)
+			return
+		case html.ElementNode:
+			if blockTags[node.DataAtom] {
+				flush()
+				defer flush()
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	flush()
+
+	return blocks
+}
+
+// Gather all of a node's text (with whitespace collapsed).
+func textContent(node *html.Node) string {
+	var text strings.Builder
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			text.WriteString(node.Data)
+			text.WriteString(" ")
+			return
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+func hasAttr(node *html.Node, key string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getAttr(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,92 @@
+package readability_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/readability"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fixture string
+		want    []string
+		notWant []string
+	}{
+		{
+			fixture: "article.html",
+			want: []string{
+				"Writing a Tiny Interpreter in Go",
+				"The first piece is the lexer",
+				"Kind string Value string",
+				"Finally, the evaluator walks the tree",
+			},
+			notWant: []string{
+				"window.analytics",
+				"Archive",
+				"Popular Posts",
+				"Great post, thanks for sharing",
+				"All rights reserved",
+			},
+		},
+		{
+			fixture: "divs.html",
+			want: []string{
+				"Spring is the best time to prepare your soil.",
+				"Tomatoes love sunshine",
+				"Pinch off the suckers",
+				"Herbs like basil, parsley, and thyme",
+			},
+			notWant: []string{
+				"Contact",
+				"Everything you ever wanted to know about compost",
+			},
+		},
+	}
+	for _, tt := range tests {
+		page, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+		test.AssertNilError(t, err)
+
+		content, err := readability.Extract(string(page))
+		test.AssertNilError(t, err)
+
+		for _, want := range tt.want {
+			test.AssertStringContains(t, content, want)
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(content, notWant) {
+				t.Errorf("%s: expected content to not contain %q", tt.fixture, notWant)
+			}
+		}
+	}
+}
+
+func TestExtractParagraphs(t *testing.T) {
+	t.Parallel()
+
+	page, err := os.ReadFile(filepath.Join("testdata", "article.html"))
+	test.AssertNilError(t, err)
+
+	content, err := readability.Extract(string(page))
+	test.AssertNilError(t, err)
+
+	// Each paragraph should be separated by a blank line.
+	paragraphs := strings.Split(content, "\n\n")
+	test.AssertEqual(t, len(paragraphs), 6)
+	test.AssertEqual(t, paragraphs[0], "Writing a Tiny Interpreter in Go")
+}
+
+func TestExtractNoContent(t *testing.T) {
+	t.Parallel()
+
+	page, err := os.ReadFile(filepath.Join("testdata", "empty.html"))
+	test.AssertNilError(t, err)
+
+	_, err = readability.Extract(string(page))
+	test.AssertErrorIs(t, err, readability.ErrNoContent)
+}
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/netutil"
+	"github.com/theandrew168/bloggulus/backend/readability"
+)
+
+const UserAgent = "Bloggulus/0.5.2 (+https://bloggulus.com)"
+
+// Don't let a slow site hold up the rest of the queue.
+const FetchTimeout = 10 * time.Second
+
+// Ignore anything larger than this (no reasonable blog post is this big).
+const MaxPageSize = 5 * 1024 * 1024
+
+// ensure PageFetcher interface is satisfied
+var _ readability.PageFetcher = (*PageFetcher)(nil)
+
+type PageFetcher struct {
+	client *http.Client
+}
+
+func NewPageFetcher() *PageFetcher {
+	// Post URLs come from feeds (which anyone can add) so only public addresses can be fetched.
+	f := PageFetcher{
+		client: netutil.NewClient(netutil.ClientConfig{
+			Timeout: FetchTimeout,
+		}),
+	}
+	return &f
+}
+
+func (f *PageFetcher) FetchPage(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", readability.ErrUnreachablePage
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "text/html, application/xhtml+xml;q=0.9, */*;q=0.5")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		// A cancelled fetch says nothing about the page itself.
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return "", readability.ErrUnreachablePage
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", readability.ErrUnreachablePage
+	}
+
+	// Only HTML pages have content worth extracting.
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "" && mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "", readability.ErrUnreachablePage
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPageSize))
+	if err != nil {
+		return "", readability.ErrUnreachablePage
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,45 @@
+package web_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/readability"
+	readabilityWeb "github.com/theandrew168/bloggulus/backend/readability/web"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestFetchPageForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+		w.Write([]byte("<html><body><p>internal</p></body></html>"))
+	}))
+	defer server.Close()
+
+	// Pages are never fetched from local (or otherwise non-public) servers.
+	fetcher := readabilityWeb.NewPageFetcher()
+	_, err := fetcher.FetchPage(context.Background(), server.URL)
+	test.AssertErrorIs(t, err, readability.ErrUnreachablePage)
+	test.AssertEqual(t, requested.Load(), false)
+}
+
+func TestFetchPageContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fetcher := readabilityWeb.NewPageFetcher()
+	_, err := fetcher.FetchPage(ctx, "https://example.com/")
+	test.AssertErrorIs(t, err, context.Canceled)
+
+	// A cancelled fetch says nothing about the page itself.
+	test.AssertEqual(t, errors.Is(err, readability.ErrUnreachablePage), false)
+}
@@ -58,7 +58,7 @@ func NewAccountRepository(conn postgres.Conn) *AccountRepository {
 	return &r
 }
 
-func (r *AccountRepository) Create(account *model.Account) error {
+func (r *AccountRepository) Create(ctx context.Context, account *model.Account) error {
 	stmt := `
 		INSERT INTO account
 			(id, username, created_at, updated_at)
@@ -77,7 +77,7 @@ func (r *AccountRepository) Create(account *model.Account) error {
 		row.UpdatedAt,
 	}
 
-	_, err = r.conn.Exec(context.Background(), stmt, args...)
+	_, err = r.conn.Exec(ctx, stmt, args...)
 	if err != nil {
 		return postgres.CheckCreateError(err)
 	}
@@ -85,7 +85,7 @@ func (r *AccountRepository) Create(account *model.Account) error {
 	return nil
 }
 
-func (r *AccountRepository) Read(id uuid.UUID) (*model.Account, error) {
+func (r *AccountRepository) Read(ctx context.Context, id uuid.UUID) (*model.Account, error) {
 	stmt := `
 		SELECT
 			account.id,
@@ -100,7 +100,7 @@ func (r *AccountRepository) Read(id uuid.UUID) (*model.Account, error) {
 		WHERE account.id = $1
 		GROUP BY account.id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, id)
+	rows, err := r.conn.Query(ctx, stmt, id)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +113,7 @@ func (r *AccountRepository) Read(id uuid.UUID) (*model.Account, error) {
 	return row.unmarshal()
 }
 
-func (r *AccountRepository) ReadByUsername(username string) (*model.Account, error) {
+func (r *AccountRepository) ReadByUsername(ctx context.Context, username string) (*model.Account, error) {
 	stmt := `
 		SELECT
 			account.id,
@@ -128,7 +128,7 @@ func (r *AccountRepository) ReadByUsername(username string) (*model.Account, err
 		WHERE account.username = $1
 		GROUP BY account.id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, username)
+	rows, err := r.conn.Query(ctx, stmt, username)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +141,7 @@ func (r *AccountRepository) ReadByUsername(username string) (*model.Account, err
 	return row.unmarshal()
 }
 
-func (r *AccountRepository) ReadBySessionID(sessionID string) (*model.Account, error) {
+func (r *AccountRepository) ReadBySessionID(ctx context.Context, sessionID string) (*model.Account, error) {
 	stmt := `
 		SELECT
 			account.id,
@@ -161,7 +161,7 @@ func (r *AccountRepository) ReadBySessionID(sessionID string) (*model.Account, e
 	hashBytes := sha256.Sum256([]byte(sessionID))
 	hash := hex.EncodeToString(hashBytes[:])
 
-	rows, err := r.conn.Query(context.Background(), stmt, hash)
+	rows, err := r.conn.Query(ctx, stmt, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +174,39 @@ func (r *AccountRepository) ReadBySessionID(sessionID string) (*model.Account, e
 	return row.unmarshal()
 }
 
-func (r *AccountRepository) List(limit, offset int) ([]*model.Account, error) {
+func (r *AccountRepository) ReadByAPIToken(ctx context.Context, token string) (*model.Account, error) {
+	stmt := `
+		SELECT
+			account.id,
+			account.username,
+			account.is_admin,
+			ARRAY_AGG(account_blog.blog_id) AS followed_blog_ids,
+			account.created_at,
+			account.updated_at
+		FROM account
+		LEFT JOIN account_blog
+			ON account_blog.account_id = account.id
+		INNER JOIN api_token
+			ON api_token.account_id = account.id
+		WHERE api_token.hash = $1
+		GROUP BY account.id`
+
+	hash := model.HashAPIToken(token)
+
+	rows, err := r.conn.Query(ctx, stmt, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbAccount])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *AccountRepository) List(ctx context.Context, limit, offset int) ([]*model.Account, error) {
 	stmt := `
 		SELECT
 			account.id,
@@ -190,7 +222,7 @@ func (r *AccountRepository) List(limit, offset int) ([]*model.Account, error) {
 		ORDER BY account.created_at DESC
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.conn.Query(context.Background(), stmt, limit, offset)
+	rows, err := r.conn.Query(ctx, stmt, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +245,7 @@ func (r *AccountRepository) List(limit, offset int) ([]*model.Account, error) {
 	return accounts, nil
 }
 
-func (r *AccountRepository) Update(account *model.Account) error {
+func (r *AccountRepository) Update(ctx context.Context, account *model.Account) error {
 	// List blogs currently being followed in the database.
 	stmt := `
 		SELECT
@@ -221,7 +253,7 @@ func (r *AccountRepository) Update(account *model.Account) error {
 		FROM account_blog
 		WHERE account_blog.account_id = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, account.ID())
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
 	if err != nil {
 		return err
 	}
@@ -254,7 +286,7 @@ func (r *AccountRepository) Update(account *model.Account) error {
 			(account_id, blog_id)
 		VALUES ($1, $2)`
 	for _, blogID := range blogsToFollow {
-		_, err = r.conn.Exec(context.Background(), stmtFollow, account.ID(), blogID)
+		_, err = r.conn.Exec(ctx, stmtFollow, account.ID(), blogID)
 		if err != nil {
 			return postgres.CheckCreateError(err)
 		}
@@ -264,7 +296,7 @@ func (r *AccountRepository) Update(account *model.Account) error {
 		DELETE FROM account_blog
 		WHERE account_id = $1 AND blog_id = $2`
 	for _, blogID := range blogsToUnfollow {
-		_, err = r.conn.Exec(context.Background(), stmtUnfollow, account.ID(), blogID)
+		_, err = r.conn.Exec(ctx, stmtUnfollow, account.ID(), blogID)
 		if err != nil {
 			return postgres.CheckDeleteError(err)
 		}
@@ -273,13 +305,13 @@ func (r *AccountRepository) Update(account *model.Account) error {
 	return nil
 }
 
-func (r *AccountRepository) Delete(account *model.Account) error {
+func (r *AccountRepository) Delete(ctx context.Context, account *model.Account) error {
 	stmt := `
 		DELETE FROM account
 		WHERE id = $1
 		RETURNING id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, account.ID())
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
 	if err != nil {
 		return err
 	}
@@ -22,7 +22,7 @@ func NewAccountBlogRepository(conn postgres.Conn) *AccountBlogRepository {
 	return &r
 }
 
-func (r *AccountBlogRepository) Create(account *model.Account, blog *model.Blog) error {
+func (r *AccountBlogRepository) Create(ctx context.Context, account *model.Account, blog *model.Blog) error {
 	stmt := `
 		INSERT INTO account_blog
 			(account_id, blog_id, created_at, updated_at)
@@ -37,7 +37,7 @@ func (r *AccountBlogRepository) Create(account *model.Account, blog *model.Blog)
 		now,
 	}
 
-	_, err := r.conn.Exec(context.Background(), stmt, args...)
+	_, err := r.conn.Exec(ctx, stmt, args...)
 	if err != nil {
 		return postgres.CheckCreateError(err)
 	}
@@ -45,7 +45,37 @@ func (r *AccountBlogRepository) Create(account *model.Account, blog *model.Blog)
 	return nil
 }
 
-func (r *AccountBlogRepository) Delete(account *model.Account, blog *model.Blog) error {
+// Make every account that follows one blog also follow another (like when merging two
+// blogs together). Accounts that already follow both are left alone.
+func (r *AccountBlogRepository) Reassign(ctx context.Context, from, to *model.Blog) error {
+	stmt := `
+		INSERT INTO account_blog
+			(account_id, blog_id, created_at, updated_at)
+		SELECT
+			account_blog.account_id,
+			$2,
+			$3,
+			$3
+		FROM account_blog
+		WHERE account_blog.blog_id = $1
+		ON CONFLICT DO NOTHING`
+
+	now := timeutil.Now()
+	args := []any{
+		from.ID(),
+		to.ID(),
+		now,
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *AccountBlogRepository) Delete(ctx context.Context, account *model.Account, blog *model.Blog) error {
 	stmt := `
 		DELETE FROM account_blog
 		WHERE account_id = $1
@@ -57,7 +87,7 @@ func (r *AccountBlogRepository) Delete(account *model.Account, blog *model.Blog)
 		blog.ID(),
 	}
 
-	rows, err := r.conn.Query(context.Background(), stmt, args...)
+	rows, err := r.conn.Query(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
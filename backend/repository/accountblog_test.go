@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/theandrew168/bloggulus/backend/postgres"
@@ -16,7 +17,7 @@ func TestAccountBlogCreate(t *testing.T) {
 	account := test.CreateAccount(t, repo)
 	blog := test.CreateBlog(t, repo)
 
-	err := repo.AccountBlog().Create(account, blog)
+	err := repo.AccountBlog().Create(context.Background(), account, blog)
 	test.AssertNilError(t, err)
 }
 
@@ -29,10 +30,37 @@ func TestAccountBlogCreateAlreadyExists(t *testing.T) {
 	account := test.CreateAccount(t, repo)
 	blog := test.CreateBlog(t, repo)
 
-	err := repo.AccountBlog().Create(account, blog)
+	err := repo.AccountBlog().Create(context.Background(), account, blog)
 	test.AssertNilError(t, err)
 
-	err = repo.AccountBlog().Create(account, blog)
+	err = repo.AccountBlog().Create(context.Background(), account, blog)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestAccountBlogReassign(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	otherAccount := test.CreateAccount(t, repo)
+	from := test.CreateBlog(t, repo)
+	to := test.CreateBlog(t, repo)
+
+	// one account follows the old blog and the other follows both
+	test.CreateAccountBlog(t, repo, account, from)
+	test.CreateAccountBlog(t, repo, otherAccount, from)
+	test.CreateAccountBlog(t, repo, otherAccount, to)
+
+	err := repo.AccountBlog().Reassign(context.Background(), from, to)
+	test.AssertNilError(t, err)
+
+	// both accounts should now follow the new blog
+	err = repo.AccountBlog().Create(context.Background(), account, to)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+
+	err = repo.AccountBlog().Create(context.Background(), otherAccount, to)
 	test.AssertErrorIs(t, err, postgres.ErrConflict)
 }
 
@@ -45,10 +73,10 @@ func TestAccountBlogDelete(t *testing.T) {
 	account := test.CreateAccount(t, repo)
 	blog := test.CreateBlog(t, repo)
 
-	err := repo.AccountBlog().Create(account, blog)
+	err := repo.AccountBlog().Create(context.Background(), account, blog)
 	test.AssertNilError(t, err)
 
-	err = repo.AccountBlog().Delete(account, blog)
+	err = repo.AccountBlog().Delete(context.Background(), account, blog)
 	test.AssertNilError(t, err)
 }
 
@@ -61,6 +89,6 @@ func TestAccountBlogDeleteDoesNotExist(t *testing.T) {
 	account := test.NewAccount(t)
 	blog := test.NewBlog(t)
 
-	err := repo.AccountBlog().Delete(account, blog)
+	err := repo.AccountBlog().Delete(context.Background(), account, blog)
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 }
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type AccountPostRepository struct {
+	conn postgres.Conn
+}
+
+func NewAccountPostRepository(conn postgres.Conn) *AccountPostRepository {
+	r := AccountPostRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *AccountPostRepository) Create(ctx context.Context, account *model.Account, post *model.Post) error {
+	stmt := `
+		INSERT INTO account_post
+			(account_id, post_id, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4)`
+
+	now := timeutil.Now()
+	args := []any{
+		account.ID(),
+		post.ID(),
+		now,
+		now,
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *AccountPostRepository) Delete(ctx context.Context, account *model.Account, post *model.Post) error {
+	stmt := `
+		DELETE FROM account_post
+		WHERE account_id = $1
+			AND post_id = $2
+		RETURNING account_id`
+
+	args := []any{
+		account.ID(),
+		post.ID(),
+	}
+
+	rows, err := r.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
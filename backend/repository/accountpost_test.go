@@ -0,0 +1,71 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestAccountPostCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.AccountPost().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+}
+
+func TestAccountPostCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.AccountPost().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+
+	err = repo.AccountPost().Create(context.Background(), account, post)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestAccountPostDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.AccountPost().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+
+	err = repo.AccountPost().Delete(context.Background(), account, post)
+	test.AssertNilError(t, err)
+}
+
+func TestAccountPostDeleteDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.NewAccount(t)
+	blog := test.NewBlog(t)
+	post := test.NewPost(t, blog)
+
+	err := repo.AccountPost().Delete(context.Background(), account, post)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+type dbAPIToken struct {
+	ID        uuid.UUID `db:"id"`
+	AccountID uuid.UUID `db:"account_id"`
+	Name      string    `db:"name"`
+	Hash      string    `db:"hash"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func marshalAPIToken(apiToken *model.APIToken) (dbAPIToken, error) {
+	t := dbAPIToken{
+		ID:        apiToken.ID(),
+		AccountID: apiToken.AccountID(),
+		Name:      apiToken.Name(),
+		Hash:      apiToken.Hash(),
+		CreatedAt: apiToken.CreatedAt(),
+		UpdatedAt: apiToken.UpdatedAt(),
+	}
+	return t, nil
+}
+
+func (t dbAPIToken) unmarshal() (*model.APIToken, error) {
+	apiToken := model.LoadAPIToken(
+		t.ID,
+		t.AccountID,
+		t.Name,
+		t.Hash,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+	return apiToken, nil
+}
+
+type APITokenRepository struct {
+	conn postgres.Conn
+}
+
+func NewAPITokenRepository(conn postgres.Conn) *APITokenRepository {
+	r := APITokenRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *APITokenRepository) Create(ctx context.Context, apiToken *model.APIToken) error {
+	stmt := `
+		INSERT INTO api_token
+			(id, account_id, name, hash, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`
+
+	row, err := marshalAPIToken(apiToken)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.AccountID,
+		row.Name,
+		row.Hash,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *APITokenRepository) Read(ctx context.Context, id uuid.UUID) (*model.APIToken, error) {
+	stmt := `
+		SELECT
+			api_token.id,
+			api_token.account_id,
+			api_token.name,
+			api_token.hash,
+			api_token.created_at,
+			api_token.updated_at
+		FROM api_token
+		WHERE api_token.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbAPIToken])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *APITokenRepository) ReadByToken(ctx context.Context, token string) (*model.APIToken, error) {
+	stmt := `
+		SELECT
+			api_token.id,
+			api_token.account_id,
+			api_token.name,
+			api_token.hash,
+			api_token.created_at,
+			api_token.updated_at
+		FROM api_token
+		WHERE api_token.hash = $1`
+
+	hash := model.HashAPIToken(token)
+
+	rows, err := r.conn.Query(ctx, stmt, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbAPIToken])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *APITokenRepository) ListByAccount(ctx context.Context, account *model.Account) ([]*model.APIToken, error) {
+	stmt := `
+		SELECT
+			api_token.id,
+			api_token.account_id,
+			api_token.name,
+			api_token.hash,
+			api_token.created_at,
+			api_token.updated_at
+		FROM api_token
+		WHERE api_token.account_id = $1
+		ORDER BY api_token.created_at ASC`
+
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	apiTokenRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbAPIToken])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var apiTokens []*model.APIToken
+	for _, row := range apiTokenRows {
+		apiToken, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+func (r *APITokenRepository) Delete(ctx context.Context, apiToken *model.APIToken) error {
+	stmt := `
+		DELETE FROM api_token
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, apiToken.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestAPITokenCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	apiToken, _ := test.NewAPIToken(t, account)
+	err := repo.APIToken().Create(context.Background(), apiToken)
+	test.AssertNilError(t, err)
+}
+
+func TestAPITokenCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	apiToken, _ := test.CreateAPIToken(t, repo, account)
+
+	// attempt to create the same API token again
+	err := repo.APIToken().Create(context.Background(), apiToken)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestAPITokenCreateDuplicateName(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	apiToken, _ := test.CreateAPIToken(t, repo, account)
+
+	// attempt to create a different API token with the same name
+	duplicate, _, err := model.NewAPIToken(account, apiToken.Name())
+	test.AssertNilError(t, err)
+
+	err = repo.APIToken().Create(context.Background(), duplicate)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestAPITokenRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	apiToken, _ := test.CreateAPIToken(t, repo, account)
+
+	got, err := repo.APIToken().Read(context.Background(), apiToken.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), apiToken.ID())
+	test.AssertEqual(t, got.Name(), apiToken.Name())
+}
+
+func TestAPITokenReadByToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	apiToken, token := test.CreateAPIToken(t, repo, account)
+
+	got, err := repo.APIToken().ReadByToken(context.Background(), token)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), apiToken.ID())
+}
+
+func TestAPITokenListByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	test.CreateAPIToken(t, repo, account)
+	test.CreateAPIToken(t, repo, account)
+
+	// tokens for other accounts shouldn't be included
+	otherAccount := test.CreateAccount(t, repo)
+	test.CreateAPIToken(t, repo, otherAccount)
+
+	apiTokens, err := repo.APIToken().ListByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, len(apiTokens), 2)
+}
+
+func TestAPITokenDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	apiToken, _ := test.CreateAPIToken(t, repo, account)
+
+	err := repo.APIToken().Delete(context.Background(), apiToken)
+	test.AssertNilError(t, err)
+
+	_, err = repo.APIToken().Read(context.Background(), apiToken.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -13,33 +13,73 @@ import (
 )
 
 type dbBlog struct {
-	ID           uuid.UUID `db:"id"`
-	FeedURL      string    `db:"feed_url"`
-	SiteURL      string    `db:"site_url"`
-	Title        string    `db:"title"`
-	ETag         string    `db:"etag"`
-	LastModified string    `db:"last_modified"`
-	SyncedAt     time.Time `db:"synced_at"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID                 uuid.UUID  `db:"id"`
+	FeedURL            string     `db:"feed_url"`
+	SiteURL            string     `db:"site_url"`
+	Title              string     `db:"title"`
+	ETag               string     `db:"etag"`
+	LastModified       string     `db:"last_modified"`
+	SyncedAt           time.Time  `db:"synced_at"`
+	SyncInterval       int        `db:"sync_interval_seconds"`
+	NextSyncAt         time.Time  `db:"next_sync_at"`
+	LastSyncStatusCode int        `db:"last_sync_status_code"`
+	LastSyncError      string     `db:"last_sync_error"`
+	SyncFailures       int        `db:"sync_failures"`
+	LastSyncSuccessAt  *time.Time `db:"last_sync_success_at"`
+	RetiredAt          *time.Time `db:"retired_at"`
+	FetchFullContent   bool       `db:"fetch_full_content"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
 }
 
 func marshalBlog(blog *model.Blog) (dbBlog, error) {
+	// Blogs that have never been synced successfully are stored as NULL.
+	var lastSyncSuccessAt *time.Time
+	if !blog.LastSyncSuccessAt().IsZero() {
+		successAt := blog.LastSyncSuccessAt()
+		lastSyncSuccessAt = &successAt
+	}
+
+	// Blogs that haven't been retired are stored as NULL.
+	var retiredAt *time.Time
+	if blog.IsRetired() {
+		at := blog.RetiredAt()
+		retiredAt = &at
+	}
+
 	b := dbBlog{
-		ID:           blog.ID(),
-		FeedURL:      blog.FeedURL(),
-		SiteURL:      blog.SiteURL(),
-		Title:        blog.Title(),
-		ETag:         blog.ETag(),
-		LastModified: blog.LastModified(),
-		SyncedAt:     blog.SyncedAt(),
-		CreatedAt:    blog.CreatedAt(),
-		UpdatedAt:    blog.UpdatedAt(),
+		ID:                 blog.ID(),
+		FeedURL:            blog.FeedURL(),
+		SiteURL:            blog.SiteURL(),
+		Title:              blog.Title(),
+		ETag:               blog.ETag(),
+		LastModified:       blog.LastModified(),
+		SyncedAt:           blog.SyncedAt(),
+		SyncInterval:       int(blog.SyncInterval().Seconds()),
+		NextSyncAt:         blog.NextSyncAt(),
+		LastSyncStatusCode: blog.LastSyncStatusCode(),
+		LastSyncError:      string(blog.LastSyncError()),
+		SyncFailures:       blog.SyncFailures(),
+		LastSyncSuccessAt:  lastSyncSuccessAt,
+		RetiredAt:          retiredAt,
+		FetchFullContent:   blog.FetchFullContent(),
+		CreatedAt:          blog.CreatedAt(),
+		UpdatedAt:          blog.UpdatedAt(),
 	}
 	return b, nil
 }
 
 func (b dbBlog) unmarshal() (*model.Blog, error) {
+	var lastSyncSuccessAt time.Time
+	if b.LastSyncSuccessAt != nil {
+		lastSyncSuccessAt = *b.LastSyncSuccessAt
+	}
+
+	var retiredAt time.Time
+	if b.RetiredAt != nil {
+		retiredAt = *b.RetiredAt
+	}
+
 	blog := model.LoadBlog(
 		b.ID,
 		b.FeedURL,
@@ -48,6 +88,14 @@ func (b dbBlog) unmarshal() (*model.Blog, error) {
 		b.ETag,
 		b.LastModified,
 		b.SyncedAt,
+		time.Duration(b.SyncInterval)*time.Second,
+		b.NextSyncAt,
+		b.LastSyncStatusCode,
+		model.SyncError(b.LastSyncError),
+		b.SyncFailures,
+		lastSyncSuccessAt,
+		retiredAt,
+		b.FetchFullContent,
 		b.CreatedAt,
 		b.UpdatedAt,
 	)
@@ -65,12 +113,12 @@ func NewBlogRepository(conn postgres.Conn) *BlogRepository {
 	return &r
 }
 
-func (r *BlogRepository) Create(blog *model.Blog) error {
+func (r *BlogRepository) Create(ctx context.Context, blog *model.Blog) error {
 	stmt := `
 		INSERT INTO blog
-			(id, feed_url, site_url, title, etag, last_modified, synced_at, created_at, updated_at)
+			(id, feed_url, site_url, title, etag, last_modified, synced_at, sync_interval_seconds, next_sync_at, last_sync_status_code, last_sync_error, sync_failures, last_sync_success_at, retired_at, fetch_full_content, created_at, updated_at)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 	row, err := marshalBlog(blog)
 	if err != nil {
@@ -85,11 +133,19 @@ func (r *BlogRepository) Create(blog *model.Blog) error {
 		row.ETag,
 		row.LastModified,
 		row.SyncedAt,
+		row.SyncInterval,
+		row.NextSyncAt,
+		row.LastSyncStatusCode,
+		row.LastSyncError,
+		row.SyncFailures,
+		row.LastSyncSuccessAt,
+		row.RetiredAt,
+		row.FetchFullContent,
 		row.CreatedAt,
 		row.UpdatedAt,
 	}
 
-	_, err = r.conn.Exec(context.Background(), stmt, args...)
+	_, err = r.conn.Exec(ctx, stmt, args...)
 	if err != nil {
 		return postgres.CheckCreateError(err)
 	}
@@ -97,7 +153,7 @@ func (r *BlogRepository) Create(blog *model.Blog) error {
 	return nil
 }
 
-func (r *BlogRepository) Read(id uuid.UUID) (*model.Blog, error) {
+func (r *BlogRepository) Read(ctx context.Context, id uuid.UUID) (*model.Blog, error) {
 	stmt := `
 		SELECT
 			blog.id,
@@ -107,12 +163,20 @@ func (r *BlogRepository) Read(id uuid.UUID) (*model.Blog, error) {
 			blog.etag,
 			blog.last_modified,
 			blog.synced_at,
+			blog.sync_interval_seconds,
+			blog.next_sync_at,
+			blog.last_sync_status_code,
+			blog.last_sync_error,
+			blog.sync_failures,
+			blog.last_sync_success_at,
+			blog.retired_at,
+			blog.fetch_full_content,
 			blog.created_at,
 			blog.updated_at
 		FROM blog
 		WHERE id = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, id)
+	rows, err := r.conn.Query(ctx, stmt, id)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +189,7 @@ func (r *BlogRepository) Read(id uuid.UUID) (*model.Blog, error) {
 	return row.unmarshal()
 }
 
-func (r *BlogRepository) ReadByFeedURL(feedURL string) (*model.Blog, error) {
+func (r *BlogRepository) ReadByFeedURL(ctx context.Context, feedURL string) (*model.Blog, error) {
 	stmt := `
 		SELECT
 			blog.id,
@@ -135,12 +199,20 @@ func (r *BlogRepository) ReadByFeedURL(feedURL string) (*model.Blog, error) {
 			blog.etag,
 			blog.last_modified,
 			blog.synced_at,
+			blog.sync_interval_seconds,
+			blog.next_sync_at,
+			blog.last_sync_status_code,
+			blog.last_sync_error,
+			blog.sync_failures,
+			blog.last_sync_success_at,
+			blog.retired_at,
+			blog.fetch_full_content,
 			blog.created_at,
 			blog.updated_at
 		FROM blog
 		WHERE blog.feed_url = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, feedURL)
+	rows, err := r.conn.Query(ctx, stmt, feedURL)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +225,7 @@ func (r *BlogRepository) ReadByFeedURL(feedURL string) (*model.Blog, error) {
 	return row.unmarshal()
 }
 
-func (r *BlogRepository) List(limit, offset int) ([]*model.Blog, error) {
+func (r *BlogRepository) List(ctx context.Context, limit, offset int) ([]*model.Blog, error) {
 	stmt := `
 		SELECT
 			blog.id,
@@ -163,13 +235,70 @@ func (r *BlogRepository) List(limit, offset int) ([]*model.Blog, error) {
 			blog.etag,
 			blog.last_modified,
 			blog.synced_at,
+			blog.sync_interval_seconds,
+			blog.next_sync_at,
+			blog.last_sync_status_code,
+			blog.last_sync_error,
+			blog.sync_failures,
+			blog.last_sync_success_at,
+			blog.retired_at,
+			blog.fetch_full_content,
 			blog.created_at,
 			blog.updated_at
 		FROM blog
 		ORDER BY blog.created_at DESC
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.conn.Query(context.Background(), stmt, limit, offset)
+	rows, err := r.conn.Query(ctx, stmt, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	blogRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbBlog])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var blogs []*model.Blog
+	for _, row := range blogRows {
+		blog, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		blogs = append(blogs, blog)
+	}
+
+	return blogs, nil
+}
+
+func (r *BlogRepository) ListByAccount(ctx context.Context, account *model.Account) ([]*model.Blog, error) {
+	stmt := `
+		SELECT
+			blog.id,
+			blog.feed_url,
+			blog.site_url,
+			blog.title,
+			blog.etag,
+			blog.last_modified,
+			blog.synced_at,
+			blog.sync_interval_seconds,
+			blog.next_sync_at,
+			blog.last_sync_status_code,
+			blog.last_sync_error,
+			blog.sync_failures,
+			blog.last_sync_success_at,
+			blog.retired_at,
+			blog.fetch_full_content,
+			blog.created_at,
+			blog.updated_at
+		FROM blog
+		INNER JOIN account_blog
+			ON account_blog.blog_id = blog.id
+			AND account_blog.account_id = $1
+		ORDER BY blog.title ASC`
+
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +322,7 @@ func (r *BlogRepository) List(limit, offset int) ([]*model.Blog, error) {
 }
 
 // DEPRECATED
-func (r *BlogRepository) ListAll() ([]*model.Blog, error) {
+func (r *BlogRepository) ListAll(ctx context.Context) ([]*model.Blog, error) {
 	stmt := `
 		SELECT
 			blog.id,
@@ -203,12 +332,68 @@ func (r *BlogRepository) ListAll() ([]*model.Blog, error) {
 			blog.etag,
 			blog.last_modified,
 			blog.synced_at,
+			blog.sync_interval_seconds,
+			blog.next_sync_at,
+			blog.last_sync_status_code,
+			blog.last_sync_error,
+			blog.sync_failures,
+			blog.last_sync_success_at,
+			blog.retired_at,
+			blog.fetch_full_content,
 			blog.created_at,
 			blog.updated_at
 		FROM blog
 		ORDER BY blog.created_at DESC`
 
-	rows, err := r.conn.Query(context.Background(), stmt)
+	rows, err := r.conn.Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	blogRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbBlog])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var blogs []*model.Blog
+	for _, row := range blogRows {
+		blog, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		blogs = append(blogs, blog)
+	}
+
+	return blogs, nil
+}
+
+// List blogs whose most recent sync failed (the ones failing the longest come first).
+func (r *BlogRepository) ListFailing(ctx context.Context) ([]*model.Blog, error) {
+	stmt := `
+		SELECT
+			blog.id,
+			blog.feed_url,
+			blog.site_url,
+			blog.title,
+			blog.etag,
+			blog.last_modified,
+			blog.synced_at,
+			blog.sync_interval_seconds,
+			blog.next_sync_at,
+			blog.last_sync_status_code,
+			blog.last_sync_error,
+			blog.sync_failures,
+			blog.last_sync_success_at,
+			blog.retired_at,
+			blog.fetch_full_content,
+			blog.created_at,
+			blog.updated_at
+		FROM blog
+		WHERE blog.sync_failures > 0
+		ORDER BY blog.sync_failures DESC, blog.title ASC`
+
+	rows, err := r.conn.Query(ctx, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -231,12 +416,12 @@ func (r *BlogRepository) ListAll() ([]*model.Blog, error) {
 	return blogs, nil
 }
 
-func (r *BlogRepository) Count() (int, error) {
+func (r *BlogRepository) Count(ctx context.Context) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM blog`
 
-	rows, err := r.conn.Query(context.Background(), stmt)
+	rows, err := r.conn.Query(ctx, stmt)
 	if err != nil {
 		return 0, err
 	}
@@ -249,7 +434,7 @@ func (r *BlogRepository) Count() (int, error) {
 	return count, nil
 }
 
-func (r *BlogRepository) Update(blog *model.Blog) error {
+func (r *BlogRepository) Update(ctx context.Context, blog *model.Blog) error {
 	now := timeutil.Now()
 	stmt := `
 		UPDATE blog
@@ -260,9 +445,17 @@ func (r *BlogRepository) Update(blog *model.Blog) error {
 			etag = $4,
 			last_modified = $5,
 			synced_at = $6,
-			updated_at = $7
-		WHERE id = $8
-			AND updated_at = $9
+			sync_interval_seconds = $7,
+			next_sync_at = $8,
+			last_sync_status_code = $9,
+			last_sync_error = $10,
+			sync_failures = $11,
+			last_sync_success_at = $12,
+			retired_at = $13,
+			fetch_full_content = $14,
+			updated_at = $15
+		WHERE id = $16
+			AND updated_at = $17
 		RETURNING updated_at`
 
 	row, err := marshalBlog(blog)
@@ -277,12 +470,20 @@ func (r *BlogRepository) Update(blog *model.Blog) error {
 		row.ETag,
 		row.LastModified,
 		row.SyncedAt,
+		row.SyncInterval,
+		row.NextSyncAt,
+		row.LastSyncStatusCode,
+		row.LastSyncError,
+		row.SyncFailures,
+		row.LastSyncSuccessAt,
+		row.RetiredAt,
+		row.FetchFullContent,
 		now,
 		row.ID,
 		row.UpdatedAt,
 	}
 
-	rows, err := r.conn.Query(context.Background(), stmt, args...)
+	rows, err := r.conn.Query(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -296,13 +497,13 @@ func (r *BlogRepository) Update(blog *model.Blog) error {
 	return nil
 }
 
-func (r *BlogRepository) Delete(blog *model.Blog) error {
+func (r *BlogRepository) Delete(ctx context.Context, blog *model.Blog) error {
 	stmt := `
 		DELETE FROM blog
 		WHERE id = $1
 		RETURNING id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, blog.ID())
+	rows, err := r.conn.Query(ctx, stmt, blog.ID())
 	if err != nil {
 		return err
 	}
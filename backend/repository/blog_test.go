@@ -1,10 +1,15 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
 
 func TestBlogCreate(t *testing.T) {
@@ -14,7 +19,7 @@ func TestBlogCreate(t *testing.T) {
 	defer closer()
 
 	blog := test.NewBlog(t)
-	err := repo.Blog().Create(blog)
+	err := repo.Blog().Create(context.Background(), blog)
 	test.AssertNilError(t, err)
 }
 
@@ -27,7 +32,7 @@ func TestBlogCreateAlreadyExists(t *testing.T) {
 	blog := test.CreateBlog(t, repo)
 
 	// attempt to create the same blog again
-	err := repo.Blog().Create(blog)
+	err := repo.Blog().Create(context.Background(), blog)
 	test.AssertErrorIs(t, err, postgres.ErrConflict)
 }
 
@@ -38,7 +43,7 @@ func TestBlogRead(t *testing.T) {
 	defer closer()
 
 	blog := test.CreateBlog(t, repo)
-	got, err := repo.Blog().Read(blog.ID())
+	got, err := repo.Blog().Read(context.Background(), blog.ID())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), blog.ID())
@@ -51,7 +56,7 @@ func TestBlogReadByFeedURL(t *testing.T) {
 	defer closer()
 
 	blog := test.CreateBlog(t, repo)
-	got, err := repo.Blog().ReadByFeedURL(blog.FeedURL())
+	got, err := repo.Blog().ReadByFeedURL(context.Background(), blog.FeedURL())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), blog.ID())
@@ -69,12 +74,33 @@ func TestBlogList(t *testing.T) {
 
 	limit := 3
 	offset := 0
-	blogs, err := repo.Blog().List(limit, offset)
+	blogs, err := repo.Blog().List(context.Background(), limit, offset)
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, len(blogs), limit)
 }
 
+func TestBlogListByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	followedBlog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, followedBlog)
+
+	// Create another blog but don't follow it.
+	test.CreateBlog(t, repo)
+
+	blogs, err := repo.Blog().ListByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, len(blogs), 1)
+	test.AssertEqual(t, blogs[0].ID(), followedBlog.ID())
+}
+
 func TestBlogListAll(t *testing.T) {
 	t.Parallel()
 
@@ -85,12 +111,43 @@ func TestBlogListAll(t *testing.T) {
 	test.CreateBlog(t, repo)
 	test.CreateBlog(t, repo)
 
-	blogs, err := repo.Blog().ListAll()
+	blogs, err := repo.Blog().ListAll(context.Background())
 	test.AssertNilError(t, err)
 
 	test.AssertAtLeast(t, len(blogs), 3)
 }
 
+func TestBlogListFailing(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	healthyBlog := test.CreateBlog(t, repo)
+
+	failingBlog := test.CreateBlog(t, repo)
+	failingBlog.RecordSyncFailure(timeutil.Now(), 404, model.SyncErrorUnreachable)
+	err := repo.Blog().Update(context.Background(), failingBlog)
+	test.AssertNilError(t, err)
+
+	blogs, err := repo.Blog().ListFailing(context.Background())
+	test.AssertNilError(t, err)
+
+	var blogIDs []uuid.UUID
+	for _, blog := range blogs {
+		blogIDs = append(blogIDs, blog.ID())
+	}
+
+	test.AssertSliceContains(t, blogIDs, failingBlog.ID())
+	test.AssertSliceDoesNotContain(t, blogIDs, healthyBlog.ID())
+
+	got, err := repo.Blog().Read(context.Background(), failingBlog.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.LastSyncStatusCode(), 404)
+	test.AssertEqual(t, got.LastSyncError(), model.SyncErrorUnreachable)
+	test.AssertEqual(t, got.SyncFailures(), 1)
+}
+
 func TestBlogCount(t *testing.T) {
 	t.Parallel()
 
@@ -101,7 +158,7 @@ func TestBlogCount(t *testing.T) {
 	test.CreateBlog(t, repo)
 	test.CreateBlog(t, repo)
 
-	count, err := repo.Blog().Count()
+	count, err := repo.Blog().Count(context.Background())
 	test.AssertNilError(t, err)
 
 	test.AssertAtLeast(t, count, 3)
@@ -121,14 +178,29 @@ func TestBlogUpdate(t *testing.T) {
 	lastModified := "bar"
 	blog.SetLastModified(lastModified)
 
-	err := repo.Blog().Update(blog)
+	blog.SetFetchFullContent(true)
+
+	feedURL := test.RandomURL(32)
+	blog.SetFeedURL(feedURL)
+
+	now := timeutil.Now()
+	blog.ScheduleSync(now, false, 0)
+	blog.Retire(now)
+
+	err := repo.Blog().Update(context.Background(), blog)
 	test.AssertNilError(t, err)
 
-	got, err := repo.Blog().Read(blog.ID())
+	got, err := repo.Blog().Read(context.Background(), blog.ID())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ETag(), etag)
 	test.AssertEqual(t, got.LastModified(), lastModified)
+	test.AssertEqual(t, got.FeedURL(), feedURL)
+	test.AssertEqual(t, got.FetchFullContent(), true)
+	test.AssertEqual(t, got.IsRetired(), true)
+	test.AssertEqual(t, got.RetiredAt().Equal(blog.RetiredAt()), true)
+	test.AssertEqual(t, got.SyncInterval(), blog.SyncInterval())
+	test.AssertEqual(t, got.NextSyncAt().Equal(blog.NextSyncAt()), true)
 }
 
 func TestBlogDelete(t *testing.T) {
@@ -139,9 +211,9 @@ func TestBlogDelete(t *testing.T) {
 
 	blog := test.CreateBlog(t, repo)
 
-	err := repo.Blog().Delete(blog)
+	err := repo.Blog().Delete(context.Background(), blog)
 	test.AssertNilError(t, err)
 
-	_, err = repo.Blog().Read(blog.ID())
+	_, err = repo.Blog().Read(context.Background(), blog.ID())
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 }
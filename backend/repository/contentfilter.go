@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type dbContentFilter struct {
+	ID        uuid.UUID  `db:"id"`
+	AccountID uuid.UUID  `db:"account_id"`
+	Kind      string     `db:"kind"`
+	Value     string     `db:"value"`
+	BlogID    *uuid.UUID `db:"blog_id"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+func marshalContentFilter(filter *model.ContentFilter) (dbContentFilter, error) {
+	// Only blog filters reference a blog (otherwise the column is NULL).
+	var blogID *uuid.UUID
+	if filter.BlogID() != uuid.Nil {
+		id := filter.BlogID()
+		blogID = &id
+	}
+
+	f := dbContentFilter{
+		ID:        filter.ID(),
+		AccountID: filter.AccountID(),
+		Kind:      string(filter.Kind()),
+		Value:     filter.Value(),
+		BlogID:    blogID,
+		CreatedAt: filter.CreatedAt(),
+		UpdatedAt: filter.UpdatedAt(),
+	}
+	return f, nil
+}
+
+func (f dbContentFilter) unmarshal() (*model.ContentFilter, error) {
+	var blogID uuid.UUID
+	if f.BlogID != nil {
+		blogID = *f.BlogID
+	}
+
+	filter := model.LoadContentFilter(
+		f.ID,
+		f.AccountID,
+		model.ContentFilterKind(f.Kind),
+		f.Value,
+		blogID,
+		f.CreatedAt,
+		f.UpdatedAt,
+	)
+	return filter, nil
+}
+
+type ContentFilterRepository struct {
+	conn postgres.Conn
+}
+
+func NewContentFilterRepository(conn postgres.Conn) *ContentFilterRepository {
+	r := ContentFilterRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *ContentFilterRepository) Create(ctx context.Context, filter *model.ContentFilter) error {
+	stmt := `
+		INSERT INTO content_filter
+			(id, account_id, kind, value, blog_id, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7)`
+
+	row, err := marshalContentFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.AccountID,
+		row.Kind,
+		row.Value,
+		row.BlogID,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *ContentFilterRepository) Read(ctx context.Context, id uuid.UUID) (*model.ContentFilter, error) {
+	stmt := `
+		SELECT
+			content_filter.id,
+			content_filter.account_id,
+			content_filter.kind,
+			content_filter.value,
+			content_filter.blog_id,
+			content_filter.created_at,
+			content_filter.updated_at
+		FROM content_filter
+		WHERE content_filter.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbContentFilter])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *ContentFilterRepository) ListByAccount(ctx context.Context, account *model.Account) ([]*model.ContentFilter, error) {
+	stmt := `
+		SELECT
+			content_filter.id,
+			content_filter.account_id,
+			content_filter.kind,
+			content_filter.value,
+			content_filter.blog_id,
+			content_filter.created_at,
+			content_filter.updated_at
+		FROM content_filter
+		WHERE content_filter.account_id = $1
+		ORDER BY content_filter.created_at ASC`
+
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	filterRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbContentFilter])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var filters []*model.ContentFilter
+	for _, row := range filterRows {
+		filter, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// Point all blog filters for one blog at another blog (like when merging two blogs together).
+// Accounts that already filter both blogs are left alone (their old filter gets deleted along
+// with the old blog).
+func (r *ContentFilterRepository) Reassign(ctx context.Context, from, to *model.Blog) error {
+	stmt := `
+		UPDATE content_filter
+		SET
+			value = $3,
+			blog_id = $2,
+			updated_at = $4
+		WHERE content_filter.blog_id = $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM content_filter existing
+				WHERE existing.account_id = content_filter.account_id
+					AND existing.blog_id = $2
+			)`
+
+	args := []any{
+		from.ID(),
+		to.ID(),
+		to.ID().String(),
+		timeutil.Now(),
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	return nil
+}
+
+// Point all tag filters for one tag name at another (like when a tag gets renamed).
+// Accounts that already filter both names just have their old filter deleted.
+func (r *ContentFilterRepository) RenameTag(ctx context.Context, oldName, newName string) error {
+	oldValue := model.ContentFilterKindTag.NormalizeValue(oldName)
+	newValue := model.ContentFilterKindTag.NormalizeValue(newName)
+	if oldValue == newValue {
+		return nil
+	}
+
+	stmt := `
+		UPDATE content_filter
+		SET
+			value = $2,
+			updated_at = $3
+		WHERE content_filter.kind = 'tag'
+			AND content_filter.value = $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM content_filter existing
+				WHERE existing.account_id = content_filter.account_id
+					AND existing.kind = 'tag'
+					AND existing.value = $2
+			)`
+
+	args := []any{
+		oldValue,
+		newValue,
+		timeutil.Now(),
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	stmt = `
+		DELETE FROM content_filter
+		WHERE content_filter.kind = 'tag'
+			AND content_filter.value = $1`
+
+	_, err = r.conn.Exec(ctx, stmt, oldValue)
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
+
+// Check if a title pattern is a valid regular expression as far as PostgreSQL (which is
+// what evaluates them) is concerned. Go's regexp package accepts some patterns that it
+// doesn't (like "\pL" or repeat counts over 255).
+func (r *ContentFilterRepository) IsValidTitlePattern(ctx context.Context, pattern string) (bool, error) {
+	stmt := `SELECT '' ~* $1`
+
+	var matches bool
+	err := r.conn.QueryRow(ctx, stmt, pattern).Scan(&matches)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidRegularExpression {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *ContentFilterRepository) Delete(ctx context.Context, filter *model.ContentFilter) error {
+	stmt := `
+		DELETE FROM content_filter
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, filter.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
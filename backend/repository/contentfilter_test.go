@@ -0,0 +1,213 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestContentFilterCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	filter := test.NewContentFilter(t, account)
+	err := repo.ContentFilter().Create(context.Background(), filter)
+	test.AssertNilError(t, err)
+}
+
+func TestContentFilterCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	filter := test.CreateContentFilter(t, repo, account)
+
+	// attempt to create a filter with the same kind and value
+	duplicate, err := model.NewContentFilter(account, filter.Kind(), filter.Value())
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), duplicate)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestContentFilterCreateBlog(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+
+	filter, err := model.NewContentFilter(account, model.ContentFilterKindBlog, blog.ID().String())
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), filter)
+	test.AssertNilError(t, err)
+
+	got, err := repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.BlogID(), blog.ID())
+
+	// deleting the blog should delete the filter, too
+	err = repo.Blog().Delete(context.Background(), blog)
+	test.AssertNilError(t, err)
+
+	_, err = repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
+
+func TestContentFilterReassign(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	from := test.CreateBlog(t, repo)
+	to := test.CreateBlog(t, repo)
+
+	filter, err := model.NewContentFilter(account, model.ContentFilterKindBlog, from.ID().String())
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), filter)
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Reassign(context.Background(), from, to)
+	test.AssertNilError(t, err)
+
+	got, err := repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.BlogID(), to.ID())
+	test.AssertEqual(t, got.Value(), to.ID().String())
+}
+
+func TestContentFilterRenameTag(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	oldName := test.RandomString(20)
+	newName := test.RandomString(20)
+
+	// One account only filters the old name.
+	account := test.CreateAccount(t, repo)
+	filter, err := model.NewContentFilter(account, model.ContentFilterKindTag, oldName)
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), filter)
+	test.AssertNilError(t, err)
+
+	// Another already filters both names.
+	otherAccount := test.CreateAccount(t, repo)
+	otherOldFilter, err := model.NewContentFilter(otherAccount, model.ContentFilterKindTag, oldName)
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), otherOldFilter)
+	test.AssertNilError(t, err)
+
+	otherNewFilter, err := model.NewContentFilter(otherAccount, model.ContentFilterKindTag, newName)
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().Create(context.Background(), otherNewFilter)
+	test.AssertNilError(t, err)
+
+	err = repo.ContentFilter().RenameTag(context.Background(), oldName, newName)
+	test.AssertNilError(t, err)
+
+	got, err := repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, got.Value(), model.NormalizeTagName(newName))
+
+	_, err = repo.ContentFilter().Read(context.Background(), otherOldFilter.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+
+	_, err = repo.ContentFilter().Read(context.Background(), otherNewFilter.ID())
+	test.AssertNilError(t, err)
+}
+
+func TestContentFilterRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	filter := test.CreateContentFilter(t, repo, account)
+
+	got, err := repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), filter.ID())
+	test.AssertEqual(t, got.Kind(), filter.Kind())
+	test.AssertEqual(t, got.Value(), filter.Value())
+}
+
+func TestContentFilterListByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	test.CreateContentFilter(t, repo, account)
+	test.CreateContentFilter(t, repo, account)
+
+	// filters from other accounts shouldn't be included
+	test.CreateContentFilter(t, repo, test.CreateAccount(t, repo))
+
+	filters, err := repo.ContentFilter().ListByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, len(filters), 2)
+}
+
+func TestContentFilterIsValidTitlePattern(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{pattern: `^sponsored`, want: true},
+		{pattern: `(?:foo|bar)+`, want: true},
+		{pattern: `\pL`, want: false},
+		{pattern: `a{300}`, want: false},
+	}
+	for _, tt := range tests {
+		got, err := repo.ContentFilter().IsValidTitlePattern(context.Background(), tt.pattern)
+		test.AssertNilError(t, err)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
+
+func TestContentFilterDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	filter := test.CreateContentFilter(t, repo, account)
+
+	err := repo.ContentFilter().Delete(context.Background(), filter)
+	test.AssertNilError(t, err)
+
+	_, err = repo.ContentFilter().Read(context.Background(), filter.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
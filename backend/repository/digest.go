@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type dbDigest struct {
+	ID        uuid.UUID `db:"id"`
+	AccountID uuid.UUID `db:"account_id"`
+	Email     string    `db:"email"`
+	Frequency string    `db:"frequency"`
+	SentAt    time.Time `db:"sent_at"`
+
+	VerificationHash   *string    `db:"verification_hash"`
+	VerificationSentAt *time.Time `db:"verification_sent_at"`
+	VerifiedAt         *time.Time `db:"verified_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func marshalDigest(digest *model.Digest) (dbDigest, error) {
+	// Verification details that don't exist (yet or anymore) are stored as NULL.
+	var verificationHash *string
+	if digest.VerificationHash() != "" {
+		hash := digest.VerificationHash()
+		verificationHash = &hash
+	}
+
+	var verificationSentAt *time.Time
+	if !digest.VerificationSentAt().IsZero() {
+		sentAt := digest.VerificationSentAt()
+		verificationSentAt = &sentAt
+	}
+
+	var verifiedAt *time.Time
+	if digest.IsVerified() {
+		at := digest.VerifiedAt()
+		verifiedAt = &at
+	}
+
+	d := dbDigest{
+		ID:        digest.ID(),
+		AccountID: digest.AccountID(),
+		Email:     digest.Email(),
+		Frequency: string(digest.Frequency()),
+		SentAt:    digest.SentAt(),
+
+		VerificationHash:   verificationHash,
+		VerificationSentAt: verificationSentAt,
+		VerifiedAt:         verifiedAt,
+
+		CreatedAt: digest.CreatedAt(),
+		UpdatedAt: digest.UpdatedAt(),
+	}
+	return d, nil
+}
+
+func (d dbDigest) unmarshal() (*model.Digest, error) {
+	var verificationHash string
+	if d.VerificationHash != nil {
+		verificationHash = *d.VerificationHash
+	}
+
+	var verificationSentAt time.Time
+	if d.VerificationSentAt != nil {
+		verificationSentAt = *d.VerificationSentAt
+	}
+
+	var verifiedAt time.Time
+	if d.VerifiedAt != nil {
+		verifiedAt = *d.VerifiedAt
+	}
+
+	digest := model.LoadDigest(
+		d.ID,
+		d.AccountID,
+		d.Email,
+		model.DigestFrequency(d.Frequency),
+		d.SentAt,
+		verificationHash,
+		verificationSentAt,
+		verifiedAt,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	return digest, nil
+}
+
+type DigestRepository struct {
+	conn postgres.Conn
+}
+
+func NewDigestRepository(conn postgres.Conn) *DigestRepository {
+	r := DigestRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *DigestRepository) Create(ctx context.Context, digest *model.Digest) error {
+	stmt := `
+		INSERT INTO digest
+			(id, account_id, email, frequency, sent_at, verification_hash, verification_sent_at, verified_at, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	row, err := marshalDigest(digest)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.AccountID,
+		row.Email,
+		row.Frequency,
+		row.SentAt,
+		row.VerificationHash,
+		row.VerificationSentAt,
+		row.VerifiedAt,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *DigestRepository) Read(ctx context.Context, id uuid.UUID) (*model.Digest, error) {
+	stmt := `
+		SELECT
+			digest.id,
+			digest.account_id,
+			digest.email,
+			digest.frequency,
+			digest.sent_at,
+			digest.verification_hash,
+			digest.verification_sent_at,
+			digest.verified_at,
+			digest.created_at,
+			digest.updated_at
+		FROM digest
+		WHERE digest.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbDigest])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *DigestRepository) ReadByAccount(ctx context.Context, account *model.Account) (*model.Digest, error) {
+	stmt := `
+		SELECT
+			digest.id,
+			digest.account_id,
+			digest.email,
+			digest.frequency,
+			digest.sent_at,
+			digest.verification_hash,
+			digest.verification_sent_at,
+			digest.verified_at,
+			digest.created_at,
+			digest.updated_at
+		FROM digest
+		WHERE digest.account_id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbDigest])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *DigestRepository) ReadByVerificationToken(ctx context.Context, token string) (*model.Digest, error) {
+	stmt := `
+		SELECT
+			digest.id,
+			digest.account_id,
+			digest.email,
+			digest.frequency,
+			digest.sent_at,
+			digest.verification_hash,
+			digest.verification_sent_at,
+			digest.verified_at,
+			digest.created_at,
+			digest.updated_at
+		FROM digest
+		WHERE digest.verification_hash = $1`
+
+	hash := model.HashDigestVerificationToken(token)
+
+	rows, err := r.conn.Query(ctx, stmt, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbDigest])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *DigestRepository) List(ctx context.Context) ([]*model.Digest, error) {
+	stmt := `
+		SELECT
+			digest.id,
+			digest.account_id,
+			digest.email,
+			digest.frequency,
+			digest.sent_at,
+			digest.verification_hash,
+			digest.verification_sent_at,
+			digest.verified_at,
+			digest.created_at,
+			digest.updated_at
+		FROM digest
+		ORDER BY digest.sent_at ASC`
+
+	rows, err := r.conn.Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	digestRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbDigest])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var digests []*model.Digest
+	for _, row := range digestRows {
+		digest, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		digests = append(digests, digest)
+	}
+
+	return digests, nil
+}
+
+func (r *DigestRepository) Update(ctx context.Context, digest *model.Digest) error {
+	now := timeutil.Now()
+	stmt := `
+		UPDATE digest
+		SET
+			email = $1,
+			frequency = $2,
+			sent_at = $3,
+			verification_hash = $4,
+			verification_sent_at = $5,
+			verified_at = $6,
+			updated_at = $7
+		WHERE id = $8
+			AND updated_at = $9
+		RETURNING updated_at`
+
+	row, err := marshalDigest(digest)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.Email,
+		row.Frequency,
+		row.SentAt,
+		row.VerificationHash,
+		row.VerificationSentAt,
+		row.VerifiedAt,
+		now,
+		row.ID,
+		row.UpdatedAt,
+	}
+
+	rows, err := r.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	digest.SetUpdatedAt(now)
+	return nil
+}
+
+func (r *DigestRepository) Delete(ctx context.Context, digest *model.Digest) error {
+	stmt := `
+		DELETE FROM digest
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, digest.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
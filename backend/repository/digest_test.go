@@ -0,0 +1,158 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestDigestCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	digest := test.NewDigest(t, account)
+	err := repo.Digest().Create(context.Background(), digest)
+	test.AssertNilError(t, err)
+}
+
+func TestDigestCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	test.CreateDigest(t, repo, account)
+
+	// attempt to create a second digest for the same account
+	duplicate := test.NewDigest(t, account)
+	err := repo.Digest().Create(context.Background(), duplicate)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestDigestRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	digest := test.CreateDigest(t, repo, account)
+
+	got, err := repo.Digest().Read(context.Background(), digest.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), digest.ID())
+	test.AssertEqual(t, got.Email(), digest.Email())
+	test.AssertEqual(t, got.Frequency(), digest.Frequency())
+}
+
+func TestDigestReadByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	digest := test.CreateDigest(t, repo, account)
+
+	got, err := repo.Digest().ReadByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), digest.ID())
+}
+
+func TestDigestReadByVerificationToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	digest := test.CreateDigest(t, repo, account)
+
+	token, err := digest.StartVerification(timeutil.Now())
+	test.AssertNilError(t, err)
+
+	err = repo.Digest().Update(context.Background(), digest)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Digest().ReadByVerificationToken(context.Background(), token)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), digest.ID())
+	test.AssertEqual(t, got.NeedsVerification(), false)
+
+	// Once verified, the token no longer works.
+	got.Verify(timeutil.Now())
+	err = repo.Digest().Update(context.Background(), got)
+	test.AssertNilError(t, err)
+
+	_, err = repo.Digest().ReadByVerificationToken(context.Background(), token)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
+
+func TestDigestList(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	test.CreateDigest(t, repo, test.CreateAccount(t, repo))
+	test.CreateDigest(t, repo, test.CreateAccount(t, repo))
+	test.CreateDigest(t, repo, test.CreateAccount(t, repo))
+
+	digests, err := repo.Digest().List(context.Background())
+	test.AssertNilError(t, err)
+
+	test.AssertAtLeast(t, len(digests), 3)
+}
+
+func TestDigestUpdate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	digest := test.CreateDigest(t, repo, account)
+
+	sentAt := timeutil.Now().Add(time.Hour)
+	digest.SetSentAt(sentAt)
+	err := digest.SetFrequency(model.DigestFrequencyWeekly)
+	test.AssertNilError(t, err)
+
+	err = repo.Digest().Update(context.Background(), digest)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Digest().Read(context.Background(), digest.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.Frequency(), model.DigestFrequencyWeekly)
+	test.AssertEqual(t, got.SentAt().Equal(sentAt), true)
+}
+
+func TestDigestDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	digest := test.CreateDigest(t, repo, account)
+
+	err := repo.Digest().Delete(context.Background(), digest)
+	test.AssertNilError(t, err)
+
+	_, err = repo.Digest().Read(context.Background(), digest.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+type dbFeedToken struct {
+	ID        uuid.UUID `db:"id"`
+	AccountID uuid.UUID `db:"account_id"`
+	Hash      string    `db:"hash"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func marshalFeedToken(feedToken *model.FeedToken) (dbFeedToken, error) {
+	t := dbFeedToken{
+		ID:        feedToken.ID(),
+		AccountID: feedToken.AccountID(),
+		Hash:      feedToken.Hash(),
+		CreatedAt: feedToken.CreatedAt(),
+		UpdatedAt: feedToken.UpdatedAt(),
+	}
+	return t, nil
+}
+
+func (t dbFeedToken) unmarshal() (*model.FeedToken, error) {
+	feedToken := model.LoadFeedToken(
+		t.ID,
+		t.AccountID,
+		t.Hash,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+	return feedToken, nil
+}
+
+type FeedTokenRepository struct {
+	conn postgres.Conn
+}
+
+func NewFeedTokenRepository(conn postgres.Conn) *FeedTokenRepository {
+	r := FeedTokenRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *FeedTokenRepository) Create(ctx context.Context, feedToken *model.FeedToken) error {
+	stmt := `
+		INSERT INTO feed_token
+			(id, account_id, hash, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5)`
+
+	row, err := marshalFeedToken(feedToken)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.AccountID,
+		row.Hash,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *FeedTokenRepository) ReadByAccount(ctx context.Context, account *model.Account) (*model.FeedToken, error) {
+	stmt := `
+		SELECT
+			feed_token.id,
+			feed_token.account_id,
+			feed_token.hash,
+			feed_token.created_at,
+			feed_token.updated_at
+		FROM feed_token
+		WHERE feed_token.account_id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbFeedToken])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *FeedTokenRepository) ReadByToken(ctx context.Context, token string) (*model.FeedToken, error) {
+	stmt := `
+		SELECT
+			feed_token.id,
+			feed_token.account_id,
+			feed_token.hash,
+			feed_token.created_at,
+			feed_token.updated_at
+		FROM feed_token
+		WHERE feed_token.hash = $1`
+
+	hash := model.HashFeedToken(token)
+
+	rows, err := r.conn.Query(ctx, stmt, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbFeedToken])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *FeedTokenRepository) Delete(ctx context.Context, feedToken *model.FeedToken) error {
+	stmt := `
+		DELETE FROM feed_token
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, feedToken.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestFeedTokenCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	feedToken, _ := test.NewFeedToken(t, account)
+	err := repo.FeedToken().Create(context.Background(), feedToken)
+	test.AssertNilError(t, err)
+}
+
+func TestFeedTokenCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	test.CreateFeedToken(t, repo, account)
+
+	// attempt to create a second feed token for the same account
+	feedToken, _ := test.NewFeedToken(t, account)
+	err := repo.FeedToken().Create(context.Background(), feedToken)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestFeedTokenReadByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	feedToken, _ := test.CreateFeedToken(t, repo, account)
+
+	got, err := repo.FeedToken().ReadByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), feedToken.ID())
+}
+
+func TestFeedTokenReadByToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	feedToken, token := test.CreateFeedToken(t, repo, account)
+
+	got, err := repo.FeedToken().ReadByToken(context.Background(), token)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), feedToken.ID())
+	test.AssertEqual(t, got.AccountID(), account.ID())
+}
+
+func TestFeedTokenDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	feedToken, token := test.CreateFeedToken(t, repo, account)
+
+	err := repo.FeedToken().Delete(context.Background(), feedToken)
+	test.AssertNilError(t, err)
+
+	_, err = repo.FeedToken().ReadByToken(context.Background(), token)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -12,32 +12,90 @@ import (
 	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
 
+// Enclosures are stored as a JSONB array of objects.
+type dbPostEnclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type"`
+	Length int64  `json:"length"`
+}
+
 type dbPost struct {
-	ID          uuid.UUID `db:"id"`
-	BlogID      uuid.UUID `db:"blog_id"`
-	URL         string    `db:"url"`
-	Title       string    `db:"title"`
-	Content     string    `db:"content"`
-	PublishedAt time.Time `db:"published_at"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID                   uuid.UUID         `db:"id"`
+	BlogID               uuid.UUID         `db:"blog_id"`
+	URL                  string            `db:"url"`
+	Title                string            `db:"title"`
+	Content              string            `db:"content"`
+	PublishedAt          time.Time         `db:"published_at"`
+	Authors              []string          `db:"authors"`
+	Summary              string            `db:"summary"`
+	Categories           []string          `db:"categories"`
+	ImageURL             string            `db:"image_url"`
+	Enclosures           []dbPostEnclosure `db:"enclosures"`
+	FullContent          string            `db:"full_content"`
+	FullContentFetchedAt *time.Time        `db:"full_content_fetched_at"`
+	CreatedAt            time.Time         `db:"created_at"`
+	UpdatedAt            time.Time         `db:"updated_at"`
 }
 
 func marshalPost(post *model.Post) (dbPost, error) {
+	// Array columns can't be NULL so ensure that empty metadata is stored as empty arrays.
+	authors := []string{}
+	authors = append(authors, post.Authors()...)
+
+	categories := []string{}
+	categories = append(categories, post.Categories()...)
+
+	enclosures := []dbPostEnclosure{}
+	for _, enclosure := range post.Enclosures() {
+		enclosures = append(enclosures, dbPostEnclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: enclosure.Length,
+		})
+	}
+
+	// Posts that haven't had their full content fetched yet are stored as NULL.
+	var fullContentFetchedAt *time.Time
+	if !post.FullContentFetchedAt().IsZero() {
+		fetchedAt := post.FullContentFetchedAt()
+		fullContentFetchedAt = &fetchedAt
+	}
+
 	p := dbPost{
-		ID:          post.ID(),
-		BlogID:      post.BlogID(),
-		URL:         post.URL(),
-		Title:       post.Title(),
-		Content:     post.Content(),
-		PublishedAt: post.PublishedAt(),
-		CreatedAt:   post.CreatedAt(),
-		UpdatedAt:   post.UpdatedAt(),
+		ID:                   post.ID(),
+		BlogID:               post.BlogID(),
+		URL:                  post.URL(),
+		Title:                post.Title(),
+		Content:              post.Content(),
+		PublishedAt:          post.PublishedAt(),
+		Authors:              authors,
+		Summary:              post.Summary(),
+		Categories:           categories,
+		ImageURL:             post.ImageURL(),
+		Enclosures:           enclosures,
+		FullContent:          post.FullContent(),
+		FullContentFetchedAt: fullContentFetchedAt,
+		CreatedAt:            post.CreatedAt(),
+		UpdatedAt:            post.UpdatedAt(),
 	}
 	return p, nil
 }
 
 func (p dbPost) unmarshal() (*model.Post, error) {
+	var enclosures []model.PostEnclosure
+	for _, enclosure := range p.Enclosures {
+		enclosures = append(enclosures, model.PostEnclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: enclosure.Length,
+		})
+	}
+
+	var fullContentFetchedAt time.Time
+	if p.FullContentFetchedAt != nil {
+		fullContentFetchedAt = *p.FullContentFetchedAt
+	}
+
 	post := model.LoadPost(
 		p.ID,
 		p.BlogID,
@@ -45,6 +103,13 @@ func (p dbPost) unmarshal() (*model.Post, error) {
 		p.Title,
 		p.Content,
 		p.PublishedAt,
+		p.Authors,
+		p.Summary,
+		p.Categories,
+		p.ImageURL,
+		enclosures,
+		p.FullContent,
+		fullContentFetchedAt,
 		p.CreatedAt,
 		p.UpdatedAt,
 	)
@@ -62,12 +127,12 @@ func NewPostRepository(conn postgres.Conn) *PostRepository {
 	return &r
 }
 
-func (r *PostRepository) Create(post *model.Post) error {
+func (r *PostRepository) Create(ctx context.Context, post *model.Post) error {
 	stmt := `
 		INSERT INTO post
-			(id, blog_id, url, title, content, published_at, created_at, updated_at)
+			(id, blog_id, url, title, content, published_at, authors, summary, categories, image_url, enclosures, full_content, full_content_fetched_at, created_at, updated_at)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8)`
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
 
 	row, err := marshalPost(post)
 	if err != nil {
@@ -81,11 +146,18 @@ func (r *PostRepository) Create(post *model.Post) error {
 		row.Title,
 		row.Content,
 		row.PublishedAt,
+		row.Authors,
+		row.Summary,
+		row.Categories,
+		row.ImageURL,
+		row.Enclosures,
+		row.FullContent,
+		row.FullContentFetchedAt,
 		row.CreatedAt,
 		row.UpdatedAt,
 	}
 
-	_, err = r.conn.Exec(context.Background(), stmt, args...)
+	_, err = r.conn.Exec(ctx, stmt, args...)
 	if err != nil {
 		return postgres.CheckCreateError(err)
 	}
@@ -93,7 +165,7 @@ func (r *PostRepository) Create(post *model.Post) error {
 	return nil
 }
 
-func (r *PostRepository) Read(id uuid.UUID) (*model.Post, error) {
+func (r *PostRepository) Read(ctx context.Context, id uuid.UUID) (*model.Post, error) {
 	stmt := `
 		SELECT
 			post.id,
@@ -102,12 +174,19 @@ func (r *PostRepository) Read(id uuid.UUID) (*model.Post, error) {
 			post.title,
 			post.content,
 			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			post.full_content,
+			post.full_content_fetched_at,
 			post.created_at,
 			post.updated_at
 		FROM post
 		WHERE post.id = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, id)
+	rows, err := r.conn.Query(ctx, stmt, id)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +199,7 @@ func (r *PostRepository) Read(id uuid.UUID) (*model.Post, error) {
 	return row.unmarshal()
 }
 
-func (r *PostRepository) ReadByURL(url string) (*model.Post, error) {
+func (r *PostRepository) ReadByURL(ctx context.Context, url string) (*model.Post, error) {
 	stmt := `
 		SELECT
 			post.id,
@@ -129,12 +208,19 @@ func (r *PostRepository) ReadByURL(url string) (*model.Post, error) {
 			post.title,
 			post.content,
 			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			post.full_content,
+			post.full_content_fetched_at,
 			post.created_at,
 			post.updated_at
 		FROM post
 		WHERE post.url = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, url)
+	rows, err := r.conn.Query(ctx, stmt, url)
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +233,7 @@ func (r *PostRepository) ReadByURL(url string) (*model.Post, error) {
 	return row.unmarshal()
 }
 
-func (r *PostRepository) ListByBlog(blog *model.Blog) ([]*model.Post, error) {
+func (r *PostRepository) ListByBlog(ctx context.Context, blog *model.Blog) ([]*model.Post, error) {
 	stmt := `
 		SELECT
 			post.id,
@@ -156,13 +242,20 @@ func (r *PostRepository) ListByBlog(blog *model.Blog) ([]*model.Post, error) {
 			post.title,
 			post.content,
 			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			post.full_content,
+			post.full_content_fetched_at,
 			post.created_at,
 			post.updated_at
 		FROM post
 		WHERE post.blog_id = $1
 		ORDER BY post.published_at DESC`
 
-	rows, err := r.conn.Query(context.Background(), stmt, blog.ID())
+	rows, err := r.conn.Query(ctx, stmt, blog.ID())
 	if err != nil {
 		return nil, err
 	}
@@ -185,13 +278,65 @@ func (r *PostRepository) ListByBlog(blog *model.Blog) ([]*model.Post, error) {
 	return posts, nil
 }
 
-func (r *PostRepository) CountByBlog(blog *model.Blog) (int, error) {
+// List posts (published since a given time) from blogs that fetch full content
+// but that haven't had their full content fetched yet. Newest posts come first.
+func (r *PostRepository) ListPendingFullContent(ctx context.Context, since time.Time, limit int) ([]*model.Post, error) {
+	stmt := `
+		SELECT
+			post.id,
+			post.blog_id,
+			post.url,
+			post.title,
+			post.content,
+			post.published_at,
+			post.authors,
+			post.summary,
+			post.categories,
+			post.image_url,
+			post.enclosures,
+			post.full_content,
+			post.full_content_fetched_at,
+			post.created_at,
+			post.updated_at
+		FROM post
+		INNER JOIN blog
+			ON blog.id = post.blog_id
+		WHERE blog.fetch_full_content
+			AND post.full_content_fetched_at IS NULL
+			AND post.published_at >= $1
+		ORDER BY post.published_at DESC
+		LIMIT $2`
+
+	rows, err := r.conn.Query(ctx, stmt, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	postRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbPost])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var posts []*model.Post
+	for _, row := range postRows {
+		post, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts, nil
+}
+
+func (r *PostRepository) CountByBlog(ctx context.Context, blog *model.Blog) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM post
 		WHERE post.blog_id = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, blog.ID())
+	rows, err := r.conn.Query(ctx, stmt, blog.ID())
 	if err != nil {
 		return 0, err
 	}
@@ -204,7 +349,7 @@ func (r *PostRepository) CountByBlog(blog *model.Blog) (int, error) {
 	return count, nil
 }
 
-func (r *PostRepository) Update(post *model.Post) error {
+func (r *PostRepository) Update(ctx context.Context, post *model.Post) error {
 	now := timeutil.Now()
 	stmt := `
 		UPDATE post
@@ -213,9 +358,16 @@ func (r *PostRepository) Update(post *model.Post) error {
 			title = $2,
 			content = $3,
 			published_at = $4,
-			updated_at = $5
-		WHERE id = $6
-		  AND updated_at = $7
+			authors = $5,
+			summary = $6,
+			categories = $7,
+			image_url = $8,
+			enclosures = $9,
+			full_content = $10,
+			full_content_fetched_at = $11,
+			updated_at = $12
+		WHERE id = $13
+		  AND updated_at = $14
 		RETURNING updated_at`
 
 	row, err := marshalPost(post)
@@ -228,12 +380,19 @@ func (r *PostRepository) Update(post *model.Post) error {
 		row.Title,
 		row.Content,
 		row.PublishedAt,
+		row.Authors,
+		row.Summary,
+		row.Categories,
+		row.ImageURL,
+		row.Enclosures,
+		row.FullContent,
+		row.FullContentFetchedAt,
 		now,
 		row.ID,
 		row.UpdatedAt,
 	}
 
-	rows, err := r.conn.Query(context.Background(), stmt, args...)
+	rows, err := r.conn.Query(ctx, stmt, args...)
 	if err != nil {
 		return err
 	}
@@ -247,13 +406,36 @@ func (r *PostRepository) Update(post *model.Post) error {
 	return nil
 }
 
-func (r *PostRepository) Delete(post *model.Post) error {
+// Move all of a blog's posts over to another blog (like when merging two blogs together).
+func (r *PostRepository) Reassign(ctx context.Context, from, to *model.Blog) error {
+	stmt := `
+		UPDATE post
+		SET
+			blog_id = $2,
+			updated_at = $3
+		WHERE blog_id = $1`
+
+	args := []any{
+		from.ID(),
+		to.ID(),
+		timeutil.Now(),
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostRepository) Delete(ctx context.Context, post *model.Post) error {
 	stmt := `
 		DELETE FROM post
 		WHERE id = $1
 		RETURNING id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, post.ID())
+	rows, err := r.conn.Query(ctx, stmt, post.ID())
 	if err != nil {
 		return err
 	}
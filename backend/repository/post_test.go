@@ -1,10 +1,16 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
 
 func TestPostCreate(t *testing.T) {
@@ -14,11 +20,11 @@ func TestPostCreate(t *testing.T) {
 	defer closer()
 
 	blog := test.NewBlog(t)
-	err := repo.Blog().Create(blog)
+	err := repo.Blog().Create(context.Background(), blog)
 	test.AssertNilError(t, err)
 
 	post := test.NewPost(t, blog)
-	err = repo.Post().Create(post)
+	err = repo.Post().Create(context.Background(), post)
 	test.AssertNilError(t, err)
 }
 
@@ -32,7 +38,7 @@ func TestPostCreateAlreadyExists(t *testing.T) {
 	post := test.CreatePost(t, repo, blog)
 
 	// attempt to create the same post again
-	err := repo.Post().Create(post)
+	err := repo.Post().Create(context.Background(), post)
 	test.AssertErrorIs(t, err, postgres.ErrConflict)
 }
 
@@ -44,7 +50,7 @@ func TestPostRead(t *testing.T) {
 
 	blog := test.CreateBlog(t, repo)
 	post := test.CreatePost(t, repo, blog)
-	got, err := repo.Post().Read(post.ID())
+	got, err := repo.Post().Read(context.Background(), post.ID())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), post.ID())
@@ -58,7 +64,7 @@ func TestPostReadByURL(t *testing.T) {
 
 	blog := test.CreateBlog(t, repo)
 	post := test.CreatePost(t, repo, blog)
-	got, err := repo.Post().ReadByURL(post.URL())
+	got, err := repo.Post().ReadByURL(context.Background(), post.URL())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), post.ID())
@@ -75,7 +81,7 @@ func TestPostListByBlog(t *testing.T) {
 	test.CreatePost(t, repo, blog)
 	test.CreatePost(t, repo, blog)
 
-	posts, err := repo.Post().ListByBlog(blog)
+	posts, err := repo.Post().ListByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, len(posts), 3)
@@ -92,7 +98,7 @@ func TestPostCountByBlog(t *testing.T) {
 	test.CreatePost(t, repo, blog)
 	test.CreatePost(t, repo, blog)
 
-	count, err := repo.Post().CountByBlog(blog)
+	count, err := repo.Post().CountByBlog(context.Background(), blog)
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, count, 3)
@@ -110,15 +116,142 @@ func TestPostUpdate(t *testing.T) {
 	content := "foobar"
 	post.SetContent(content)
 
-	err := repo.Post().Update(post)
+	err := repo.Post().Update(context.Background(), post)
 	test.AssertNilError(t, err)
 
-	got, err := repo.Post().Read(post.ID())
+	got, err := repo.Post().Read(context.Background(), post.ID())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.Content(), content)
 }
 
+func TestPostUpdateFullContent(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.FullContent(), "")
+	test.AssertEqual(t, got.FullContentFetchedAt().IsZero(), true)
+
+	fullContent := "foobar"
+	fetchedAt := timeutil.Now()
+	post.SetFullContent(fullContent, fetchedAt)
+
+	err = repo.Post().Update(context.Background(), post)
+	test.AssertNilError(t, err)
+
+	got, err = repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.FullContent(), fullContent)
+	test.AssertEqual(t, got.FullContentFetchedAt().Equal(fetchedAt), true)
+}
+
+func TestPostListPendingFullContent(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.NewBlog(t)
+	blog.SetFetchFullContent(true)
+	err := repo.Blog().Create(context.Background(), blog)
+	test.AssertNilError(t, err)
+
+	pendingPost := test.CreatePost(t, repo, blog)
+	fetchedPost := test.CreatePost(t, repo, blog)
+	fetchedPost.SetFullContent("foobar", timeutil.Now())
+	err = repo.Post().Update(context.Background(), fetchedPost)
+	test.AssertNilError(t, err)
+
+	// Posts from blogs that don't fetch full content should never be listed.
+	otherBlog := test.CreateBlog(t, repo)
+	otherPost := test.CreatePost(t, repo, otherBlog)
+
+	posts, err := repo.Post().ListPendingFullContent(context.Background(), time.Time{}, 1000)
+	test.AssertNilError(t, err)
+
+	var postIDs []uuid.UUID
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID())
+	}
+
+	test.AssertSliceContains(t, postIDs, pendingPost.ID())
+	test.AssertSliceDoesNotContain(t, postIDs, fetchedPost.ID())
+	test.AssertSliceDoesNotContain(t, postIDs, otherPost.ID())
+}
+
+func TestPostUpdateMetadata(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	// Posts without metadata should read back as empty.
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(got.Authors()), 0)
+	test.AssertEqual(t, got.Summary(), "")
+	test.AssertEqual(t, len(got.Categories()), 0)
+	test.AssertEqual(t, got.ImageURL(), "")
+	test.AssertEqual(t, len(got.Enclosures()), 0)
+
+	authors := []string{"Alice", "Bob"}
+	summary := "a post about foo"
+	categories := []string{"foo", "bar"}
+	imageURL := test.RandomURL(32)
+	enclosures := []model.PostEnclosure{
+		{URL: test.RandomURL(32), Type: "audio/mpeg", Length: 12345},
+	}
+
+	post.SetAuthors(authors)
+	post.SetSummary(summary)
+	post.SetCategories(categories)
+	post.SetImageURL(imageURL)
+	post.SetEnclosures(enclosures)
+
+	err = repo.Post().Update(context.Background(), post)
+	test.AssertNilError(t, err)
+
+	got, err = repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.Authors(), authors)
+	test.AssertEqual(t, got.Summary(), summary)
+	test.AssertEqual(t, got.Categories(), categories)
+	test.AssertEqual(t, got.ImageURL(), imageURL)
+	test.AssertEqual(t, got.Enclosures(), enclosures)
+}
+
+func TestPostReassign(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	from := test.CreateBlog(t, repo)
+	to := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, from)
+
+	err := repo.Post().Reassign(context.Background(), from, to)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Post().Read(context.Background(), post.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.BlogID(), to.ID())
+}
+
 func TestPostDelete(t *testing.T) {
 	t.Parallel()
 
@@ -128,9 +261,9 @@ func TestPostDelete(t *testing.T) {
 	blog := test.CreateBlog(t, repo)
 	post := test.CreatePost(t, repo, blog)
 
-	err := repo.Post().Delete(post)
+	err := repo.Post().Delete(context.Background(), post)
 	test.AssertNilError(t, err)
 
-	_, err = repo.Post().Read(post.ID())
+	_, err = repo.Post().Read(context.Background(), post.ID())
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 }
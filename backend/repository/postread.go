@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+// Tracks which posts each account has read (posts are unread by default).
+type PostReadRepository struct {
+	conn postgres.Conn
+}
+
+func NewPostReadRepository(conn postgres.Conn) *PostReadRepository {
+	r := PostReadRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+// Mark a single post as read (marking an already-read post is a no-op).
+func (r *PostReadRepository) Create(ctx context.Context, account *model.Account, post *model.Post) error {
+	stmt := `
+		INSERT INTO post_read
+			(account_id, post_id, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`
+
+	now := timeutil.Now()
+	args := []any{
+		account.ID(),
+		post.ID(),
+		now,
+		now,
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+// Mark all posts (from followed blogs) added at or before a given time as read.
+func (r *PostReadRepository) CreateByAccount(ctx context.Context, account *model.Account, before time.Time) error {
+	stmt := `
+		INSERT INTO post_read
+			(account_id, post_id, created_at, updated_at)
+		SELECT
+			account_blog.account_id,
+			post.id,
+			$3,
+			$3
+		FROM post
+		INNER JOIN account_blog
+			ON account_blog.blog_id = post.blog_id
+			AND account_blog.account_id = $1
+		WHERE post.created_at <= $2
+		ON CONFLICT DO NOTHING`
+
+	now := timeutil.Now()
+	args := []any{
+		account.ID(),
+		before,
+		now,
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+// Mark all posts from a single blog as read.
+func (r *PostReadRepository) CreateByBlog(ctx context.Context, account *model.Account, blog *model.Blog) error {
+	stmt := `
+		INSERT INTO post_read
+			(account_id, post_id, created_at, updated_at)
+		SELECT
+			$1,
+			post.id,
+			$3,
+			$3
+		FROM post
+		WHERE post.blog_id = $2
+		ON CONFLICT DO NOTHING`
+
+	now := timeutil.Now()
+	args := []any{
+		account.ID(),
+		blog.ID(),
+		now,
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *PostReadRepository) Delete(ctx context.Context, account *model.Account, post *model.Post) error {
+	stmt := `
+		DELETE FROM post_read
+		WHERE account_id = $1
+			AND post_id = $2
+		RETURNING account_id`
+
+	args := []any{
+		account.ID(),
+		post.ID(),
+	}
+
+	rows, err := r.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestPostReadCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.PostRead().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+}
+
+func TestPostReadCreateIdempotent(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.PostRead().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+
+	// Marking a post as read again should be idempotent.
+	err = repo.PostRead().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+}
+
+func TestPostReadCreateByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	test.CreateAccountBlog(t, repo, account, blog)
+
+	post := test.CreatePost(t, repo, blog)
+
+	// Marking everything as read should be idempotent.
+	err := repo.PostRead().CreateByAccount(context.Background(), account, timeutil.Now())
+	test.AssertNilError(t, err)
+	err = repo.PostRead().CreateByAccount(context.Background(), account, timeutil.Now())
+	test.AssertNilError(t, err)
+
+	// The post should already be marked as read (so it can be unmarked).
+	err = repo.PostRead().Delete(context.Background(), account, post)
+	test.AssertNilError(t, err)
+}
+
+func TestPostReadCreateByBlog(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.PostRead().CreateByBlog(context.Background(), account, blog)
+	test.AssertNilError(t, err)
+
+	// The post should already be marked as read (so it can be unmarked).
+	err = repo.PostRead().Delete(context.Background(), account, post)
+	test.AssertNilError(t, err)
+}
+
+func TestPostReadDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.PostRead().Create(context.Background(), account, post)
+	test.AssertNilError(t, err)
+
+	err = repo.PostRead().Delete(context.Background(), account, post)
+	test.AssertNilError(t, err)
+
+	// Deleting again should fail since the post is no longer read.
+	err = repo.PostRead().Delete(context.Background(), account, post)
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+type PostTagRepository struct {
+	conn postgres.Conn
+}
+
+func NewPostTagRepository(conn postgres.Conn) *PostTagRepository {
+	r := PostTagRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+// List the (normalized) names of a post's tags.
+func (r *PostTagRepository) ListByPost(ctx context.Context, post *model.Post) ([]string, error) {
+	stmt := `
+		SELECT post_tag.name
+		FROM post_tag
+		WHERE post_tag.post_id = $1
+		ORDER BY post_tag.name ASC`
+
+	rows, err := r.conn.Query(ctx, stmt, post.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	return names, nil
+}
+
+// Replace a post's tags with the given (normalized) names. Tags that are no longer
+// present get removed and new ones get added.
+func (r *PostTagRepository) Replace(ctx context.Context, post *model.Post, names []string) error {
+	stmt := `
+		WITH removed AS (
+			DELETE FROM post_tag
+			WHERE post_tag.post_id = $1
+				AND NOT (post_tag.name = ANY($2::text[]))
+		)
+		INSERT INTO post_tag
+			(post_id, name)
+		SELECT $1, name
+		FROM unnest($2::text[]) AS name
+		ON CONFLICT DO NOTHING`
+
+	// Array params can't be NULL so ensure that no tags is an empty array.
+	if names == nil {
+		names = []string{}
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, post.ID(), names)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestPostTagReplace(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.PostTag().Replace(context.Background(), post, []string{"foo", "bar"})
+	test.AssertNilError(t, err)
+
+	names, err := repo.PostTag().ListByPost(context.Background(), post)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, names, []string{"bar", "foo"})
+
+	// Replacing should remove old tags and add new ones.
+	err = repo.PostTag().Replace(context.Background(), post, []string{"foo", "baz"})
+	test.AssertNilError(t, err)
+
+	names, err = repo.PostTag().ListByPost(context.Background(), post)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, names, []string{"baz", "foo"})
+}
+
+func TestPostTagReplaceEmpty(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	err := repo.PostTag().Replace(context.Background(), post, []string{"foo"})
+	test.AssertNilError(t, err)
+
+	err = repo.PostTag().Replace(context.Background(), post, nil)
+	test.AssertNilError(t, err)
+
+	names, err := repo.PostTag().ListByPost(context.Background(), post)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(names), 0)
+}
@@ -18,6 +18,17 @@ type Repository struct {
 	account     *AccountRepository
 	session     *SessionRepository
 	accountBlog *AccountBlogRepository
+	apiToken    *APITokenRepository
+	postRead    *PostReadRepository
+	accountPost *AccountPostRepository
+	digest      *DigestRepository
+	webhook     *WebhookRepository
+	delivery    *WebhookDeliveryRepository
+	websub      *WebSubSubscriptionRepository
+	postTag     *PostTagRepository
+	tagAlias    *TagAliasRepository
+	filter      *ContentFilterRepository
+	feedToken   *FeedTokenRepository
 }
 
 func New(conn postgres.Conn) *Repository {
@@ -30,6 +41,17 @@ func New(conn postgres.Conn) *Repository {
 		account:     NewAccountRepository(conn),
 		session:     NewSessionRepository(conn),
 		accountBlog: NewAccountBlogRepository(conn),
+		apiToken:    NewAPITokenRepository(conn),
+		postRead:    NewPostReadRepository(conn),
+		accountPost: NewAccountPostRepository(conn),
+		digest:      NewDigestRepository(conn),
+		webhook:     NewWebhookRepository(conn),
+		delivery:    NewWebhookDeliveryRepository(conn),
+		websub:      NewWebSubSubscriptionRepository(conn),
+		postTag:     NewPostTagRepository(conn),
+		tagAlias:    NewTagAliasRepository(conn),
+		filter:      NewContentFilterRepository(conn),
+		feedToken:   NewFeedTokenRepository(conn),
 	}
 	return &r
 }
@@ -58,6 +80,50 @@ func (r *Repository) AccountBlog() *AccountBlogRepository {
 	return r.accountBlog
 }
 
+func (r *Repository) APIToken() *APITokenRepository {
+	return r.apiToken
+}
+
+func (r *Repository) PostRead() *PostReadRepository {
+	return r.postRead
+}
+
+func (r *Repository) AccountPost() *AccountPostRepository {
+	return r.accountPost
+}
+
+func (r *Repository) Digest() *DigestRepository {
+	return r.digest
+}
+
+func (r *Repository) Webhook() *WebhookRepository {
+	return r.webhook
+}
+
+func (r *Repository) WebhookDelivery() *WebhookDeliveryRepository {
+	return r.delivery
+}
+
+func (r *Repository) WebSubSubscription() *WebSubSubscriptionRepository {
+	return r.websub
+}
+
+func (r *Repository) PostTag() *PostTagRepository {
+	return r.postTag
+}
+
+func (r *Repository) TagAlias() *TagAliasRepository {
+	return r.tagAlias
+}
+
+func (r *Repository) ContentFilter() *ContentFilterRepository {
+	return r.filter
+}
+
+func (r *Repository) FeedToken() *FeedTokenRepository {
+	return r.feedToken
+}
+
 func (r *Repository) Exec(ctx context.Context, sql string, args ...any) error {
 	_, err := r.conn.Exec(ctx, sql, args...)
 	return err
@@ -65,10 +131,10 @@ func (r *Repository) Exec(ctx context.Context, sql string, args ...any) error {
 
 // Based on:
 // https://pkg.go.dev/github.com/jackc/pgx#hdr-Transactions
-func (r *Repository) WithTransaction(operation func(repo *Repository) error) error {
+func (r *Repository) WithTransaction(ctx context.Context, operation func(repo *Repository) error) error {
 	// Calling the Begin() method on the connection creates a new pgx.Tx
 	// object, which represents the in-progress database transaction.
-	tx, err := r.conn.Begin(context.Background())
+	tx, err := r.conn.Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -78,7 +144,7 @@ func (r *Repository) WithTransaction(operation func(repo *Repository) error) err
 	// committed by the time tx.Rollback() is called, making tx.Rollback() a
 	// no-op. Otherwise, in the event of an error, tx.Rollback() will rollback
 	// the changes before the function returns.
-	defer tx.Rollback(context.Background())
+	defer tx.Rollback(ctx)
 
 	// Create a new Repository struct using the pgx.Tx as its Conn. Note
 	// that this new repo will be backed by single connection and not
@@ -96,7 +162,7 @@ func (r *Repository) WithTransaction(operation func(repo *Repository) error) err
 
 	// If there are no errors, the operation can be committed
 	// to the database with the tx.Commit() method.
-	err = tx.Commit(context.Background())
+	err = tx.Commit(ctx)
 	if err != nil {
 		return err
 	}
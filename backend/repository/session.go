@@ -57,7 +57,7 @@ func NewSessionRepository(conn postgres.Conn) *SessionRepository {
 	return &r
 }
 
-func (r *SessionRepository) Create(session *model.Session) error {
+func (r *SessionRepository) Create(ctx context.Context, session *model.Session) error {
 	stmt := `
 		INSERT INTO session
 			(id, account_id, hash, expires_at, created_at, updated_at)
@@ -78,7 +78,7 @@ func (r *SessionRepository) Create(session *model.Session) error {
 		row.UpdatedAt,
 	}
 
-	_, err = r.conn.Exec(context.Background(), stmt, args...)
+	_, err = r.conn.Exec(ctx, stmt, args...)
 	if err != nil {
 		return postgres.CheckCreateError(err)
 	}
@@ -86,7 +86,7 @@ func (r *SessionRepository) Create(session *model.Session) error {
 	return nil
 }
 
-func (r *SessionRepository) Read(id uuid.UUID) (*model.Session, error) {
+func (r *SessionRepository) Read(ctx context.Context, id uuid.UUID) (*model.Session, error) {
 	stmt := `
 		SELECT
 			session.id,
@@ -98,7 +98,7 @@ func (r *SessionRepository) Read(id uuid.UUID) (*model.Session, error) {
 		FROM session
 		WHERE session.id = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, id)
+	rows, err := r.conn.Query(ctx, stmt, id)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (r *SessionRepository) Read(id uuid.UUID) (*model.Session, error) {
 	return row.unmarshal()
 }
 
-func (r *SessionRepository) ReadBySessionID(sessionID string) (*model.Session, error) {
+func (r *SessionRepository) ReadBySessionID(ctx context.Context, sessionID string) (*model.Session, error) {
 	stmt := `
 		SELECT
 			session.id,
@@ -126,7 +126,7 @@ func (r *SessionRepository) ReadBySessionID(sessionID string) (*model.Session, e
 	hashBytes := sha256.Sum256([]byte(sessionID))
 	hash := hex.EncodeToString(hashBytes[:])
 
-	rows, err := r.conn.Query(context.Background(), stmt, hash)
+	rows, err := r.conn.Query(ctx, stmt, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +139,7 @@ func (r *SessionRepository) ReadBySessionID(sessionID string) (*model.Session, e
 	return row.unmarshal()
 }
 
-func (r *SessionRepository) ListExpired(now time.Time) ([]*model.Session, error) {
+func (r *SessionRepository) ListExpired(ctx context.Context, now time.Time) ([]*model.Session, error) {
 	stmt := `
 		SELECT
 			session.id,
@@ -151,7 +151,7 @@ func (r *SessionRepository) ListExpired(now time.Time) ([]*model.Session, error)
 		FROM session
 		WHERE session.expires_at <= $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, now)
+	rows, err := r.conn.Query(ctx, stmt, now)
 	if err != nil {
 		return nil, err
 	}
@@ -174,13 +174,13 @@ func (r *SessionRepository) ListExpired(now time.Time) ([]*model.Session, error)
 	return sessions, nil
 }
 
-func (r *SessionRepository) Delete(session *model.Session) error {
+func (r *SessionRepository) Delete(ctx context.Context, session *model.Session) error {
 	stmt := `
 		DELETE FROM session
 		WHERE id = $1
 		RETURNING id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, session.ID())
+	rows, err := r.conn.Query(ctx, stmt, session.ID())
 	if err != nil {
 		return err
 	}
@@ -193,12 +193,12 @@ func (r *SessionRepository) Delete(session *model.Session) error {
 	return nil
 }
 
-func (r *SessionRepository) DeleteExpired(now time.Time) error {
+func (r *SessionRepository) DeleteExpired(ctx context.Context, now time.Time) error {
 	stmt := `
 		DELETE FROM session
 		WHERE expires_at <= $1`
 
-	_, err := r.conn.Exec(context.Background(), stmt, now)
+	_, err := r.conn.Exec(ctx, stmt, now)
 	if err != nil {
 		return err
 	}
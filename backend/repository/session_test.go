@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -17,11 +18,11 @@ func TestSessionCreate(t *testing.T) {
 	defer closer()
 
 	account := test.NewAccount(t)
-	err := repo.Account().Create(account)
+	err := repo.Account().Create(context.Background(), account)
 	test.AssertNilError(t, err)
 
 	session, _ := test.NewSession(t, account)
-	err = repo.Session().Create(session)
+	err = repo.Session().Create(context.Background(), session)
 	test.AssertNilError(t, err)
 }
 
@@ -35,7 +36,7 @@ func TestSessionCreateAlreadyExists(t *testing.T) {
 	session, _ := test.CreateSession(t, repo, account)
 
 	// attempt to create the same session again
-	err := repo.Session().Create(session)
+	err := repo.Session().Create(context.Background(), session)
 	test.AssertErrorIs(t, err, postgres.ErrConflict)
 }
 
@@ -48,7 +49,7 @@ func TestSessionRead(t *testing.T) {
 	account := test.CreateAccount(t, repo)
 	session, _ := test.CreateSession(t, repo, account)
 
-	got, err := repo.Session().Read(session.ID())
+	got, err := repo.Session().Read(context.Background(), session.ID())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), session.ID())
@@ -63,7 +64,7 @@ func TestSessionReadBySessionID(t *testing.T) {
 	account := test.CreateAccount(t, repo)
 	session, sessionID := test.CreateSession(t, repo, account)
 
-	got, err := repo.Session().ReadBySessionID(sessionID)
+	got, err := repo.Session().ReadBySessionID(context.Background(), sessionID)
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), session.ID())
@@ -78,10 +79,10 @@ func TestSessionDelete(t *testing.T) {
 	account := test.CreateAccount(t, repo)
 	session, _ := test.CreateSession(t, repo, account)
 
-	err := repo.Session().Delete(session)
+	err := repo.Session().Delete(context.Background(), session)
 	test.AssertNilError(t, err)
 
-	_, err = repo.Session().Read(session.ID())
+	_, err = repo.Session().Read(context.Background(), session.ID())
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 }
 
@@ -99,7 +100,7 @@ func TestSessionDeleteExpired(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Session().Create(sessionOld)
+	err = repo.Session().Create(context.Background(), sessionOld)
 	test.AssertNilError(t, err)
 
 	sessionNew, _, err := model.NewSession(
@@ -108,16 +109,16 @@ func TestSessionDeleteExpired(t *testing.T) {
 	)
 	test.AssertNilError(t, err)
 
-	err = repo.Session().Create(sessionNew)
+	err = repo.Session().Create(context.Background(), sessionNew)
 	test.AssertNilError(t, err)
 
 	now := timeutil.Now()
-	err = repo.Session().DeleteExpired(now)
+	err = repo.Session().DeleteExpired(context.Background(), now)
 	test.AssertNilError(t, err)
 
-	_, err = repo.Session().Read(sessionOld.ID())
+	_, err = repo.Session().Read(context.Background(), sessionOld.ID())
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 
-	_, err = repo.Session().Read(sessionNew.ID())
+	_, err = repo.Session().Read(context.Background(), sessionNew.ID())
 	test.AssertNilError(t, err)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
 )
 
 type dbTag struct {
@@ -49,7 +50,7 @@ func NewTagRepository(conn postgres.Conn) *TagRepository {
 	return &r
 }
 
-func (r *TagRepository) Create(tag *model.Tag) error {
+func (r *TagRepository) Create(ctx context.Context, tag *model.Tag) error {
 	stmt := `
 		INSERT INTO tag
 			(id, name, created_at, updated_at)
@@ -68,7 +69,7 @@ func (r *TagRepository) Create(tag *model.Tag) error {
 		row.UpdatedAt,
 	}
 
-	_, err = r.conn.Exec(context.Background(), stmt, args...)
+	_, err = r.conn.Exec(ctx, stmt, args...)
 	if err != nil {
 		return postgres.CheckCreateError(err)
 	}
@@ -76,7 +77,7 @@ func (r *TagRepository) Create(tag *model.Tag) error {
 	return nil
 }
 
-func (r *TagRepository) Read(id uuid.UUID) (*model.Tag, error) {
+func (r *TagRepository) Read(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
 	stmt := `
 		SELECT
 			tag.id,
@@ -86,7 +87,7 @@ func (r *TagRepository) Read(id uuid.UUID) (*model.Tag, error) {
 		FROM tag
 		WHERE tag.id = $1`
 
-	rows, err := r.conn.Query(context.Background(), stmt, id)
+	rows, err := r.conn.Query(ctx, stmt, id)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +100,7 @@ func (r *TagRepository) Read(id uuid.UUID) (*model.Tag, error) {
 	return row.unmarshal()
 }
 
-func (r *TagRepository) List(limit, offset int) ([]*model.Tag, error) {
+func (r *TagRepository) List(ctx context.Context, limit, offset int) ([]*model.Tag, error) {
 	stmt := `
 		SELECT
 			tag.id,
@@ -110,7 +111,7 @@ func (r *TagRepository) List(limit, offset int) ([]*model.Tag, error) {
 		ORDER BY tag.created_at DESC
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.conn.Query(context.Background(), stmt, limit, offset)
+	rows, err := r.conn.Query(ctx, stmt, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -133,12 +134,12 @@ func (r *TagRepository) List(limit, offset int) ([]*model.Tag, error) {
 	return tags, nil
 }
 
-func (r *TagRepository) Count() (int, error) {
+func (r *TagRepository) Count(ctx context.Context) (int, error) {
 	stmt := `
 		SELECT count(*)
 		FROM tag`
 
-	rows, err := r.conn.Query(context.Background(), stmt)
+	rows, err := r.conn.Query(ctx, stmt)
 	if err != nil {
 		return 0, err
 	}
@@ -151,13 +152,50 @@ func (r *TagRepository) Count() (int, error) {
 	return count, nil
 }
 
-func (r *TagRepository) Delete(tag *model.Tag) error {
+func (r *TagRepository) Update(ctx context.Context, tag *model.Tag) error {
+	now := timeutil.Now()
+	stmt := `
+		UPDATE tag
+		SET
+			name = $1,
+			updated_at = $2
+		WHERE id = $3
+			AND updated_at = $4
+		RETURNING updated_at`
+
+	row, err := marshalTag(tag)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.Name,
+		now,
+		row.ID,
+		row.UpdatedAt,
+	}
+
+	rows, err := r.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	tag.SetUpdatedAt(now)
+	return nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, tag *model.Tag) error {
 	stmt := `
 		DELETE FROM tag
 		WHERE id = $1
 		RETURNING id`
 
-	rows, err := r.conn.Query(context.Background(), stmt, tag.ID())
+	rows, err := r.conn.Query(ctx, stmt, tag.ID())
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/theandrew168/bloggulus/backend/postgres"
@@ -14,7 +15,7 @@ func TestTagCreate(t *testing.T) {
 	defer closer()
 
 	tag := test.NewTag(t)
-	err := repo.Tag().Create(tag)
+	err := repo.Tag().Create(context.Background(), tag)
 	test.AssertNilError(t, err)
 }
 
@@ -27,7 +28,7 @@ func TestTagCreateAlreadyExists(t *testing.T) {
 	tag := test.CreateTag(t, repo)
 
 	// attempt to create the same tag again
-	err := repo.Tag().Create(tag)
+	err := repo.Tag().Create(context.Background(), tag)
 	test.AssertErrorIs(t, err, postgres.ErrConflict)
 }
 
@@ -38,7 +39,7 @@ func TestTagRead(t *testing.T) {
 	defer closer()
 
 	tag := test.CreateTag(t, repo)
-	got, err := repo.Tag().Read(tag.ID())
+	got, err := repo.Tag().Read(context.Background(), tag.ID())
 	test.AssertNilError(t, err)
 
 	test.AssertEqual(t, got.ID(), tag.ID())
@@ -56,7 +57,7 @@ func TestTagList(t *testing.T) {
 
 	limit := 3
 	offset := 0
-	tags, err := repo.Tag().List(limit, offset)
+	tags, err := repo.Tag().List(context.Background(), limit, offset)
 	test.AssertNilError(t, err)
 
 	test.AssertAtLeast(t, len(tags), limit)
@@ -72,12 +73,47 @@ func TestTagCount(t *testing.T) {
 	test.CreateTag(t, repo)
 	test.CreateTag(t, repo)
 
-	count, err := repo.Tag().Count()
+	count, err := repo.Tag().Count(context.Background())
 	test.AssertNilError(t, err)
 
 	test.AssertAtLeast(t, count, 3)
 }
 
+func TestTagUpdate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tag := test.CreateTag(t, repo)
+
+	name := test.RandomString(32)
+	tag.SetName(name)
+
+	err := repo.Tag().Update(context.Background(), tag)
+	test.AssertNilError(t, err)
+
+	got, err := repo.Tag().Read(context.Background(), tag.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.Name(), name)
+}
+
+func TestTagUpdateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tag := test.CreateTag(t, repo)
+	other := test.CreateTag(t, repo)
+
+	// attempt to rename a tag to an existing name
+	tag.SetName(other.Name())
+	err := repo.Tag().Update(context.Background(), tag)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
 func TestTagDelete(t *testing.T) {
 	t.Parallel()
 
@@ -86,9 +122,9 @@ func TestTagDelete(t *testing.T) {
 
 	tag := test.CreateTag(t, repo)
 
-	err := repo.Tag().Delete(tag)
+	err := repo.Tag().Delete(context.Background(), tag)
 	test.AssertNilError(t, err)
 
-	_, err = repo.Tag().Read(tag.ID())
+	_, err = repo.Tag().Read(context.Background(), tag.ID())
 	test.AssertErrorIs(t, err, postgres.ErrNotFound)
 }
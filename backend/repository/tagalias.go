@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type dbTagAlias struct {
+	ID        uuid.UUID `db:"id"`
+	Alias     string    `db:"alias"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func marshalTagAlias(tagAlias *model.TagAlias) (dbTagAlias, error) {
+	t := dbTagAlias{
+		ID:        tagAlias.ID(),
+		Alias:     tagAlias.Alias(),
+		Name:      tagAlias.Name(),
+		CreatedAt: tagAlias.CreatedAt(),
+		UpdatedAt: tagAlias.UpdatedAt(),
+	}
+	return t, nil
+}
+
+func (t dbTagAlias) unmarshal() (*model.TagAlias, error) {
+	tagAlias := model.LoadTagAlias(
+		t.ID,
+		t.Alias,
+		t.Name,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+	return tagAlias, nil
+}
+
+type TagAliasRepository struct {
+	conn postgres.Conn
+}
+
+func NewTagAliasRepository(conn postgres.Conn) *TagAliasRepository {
+	r := TagAliasRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *TagAliasRepository) Create(ctx context.Context, tagAlias *model.TagAlias) error {
+	stmt := `
+		INSERT INTO tag_alias
+			(id, alias, name, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5)`
+
+	row, err := marshalTagAlias(tagAlias)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.Alias,
+		row.Name,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *TagAliasRepository) Read(ctx context.Context, id uuid.UUID) (*model.TagAlias, error) {
+	stmt := `
+		SELECT
+			tag_alias.id,
+			tag_alias.alias,
+			tag_alias.name,
+			tag_alias.created_at,
+			tag_alias.updated_at
+		FROM tag_alias
+		WHERE tag_alias.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbTagAlias])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *TagAliasRepository) List(ctx context.Context) ([]*model.TagAlias, error) {
+	stmt := `
+		SELECT
+			tag_alias.id,
+			tag_alias.alias,
+			tag_alias.name,
+			tag_alias.created_at,
+			tag_alias.updated_at
+		FROM tag_alias
+		ORDER BY tag_alias.name ASC, tag_alias.alias ASC`
+
+	rows, err := r.conn.Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	tagAliasRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbTagAlias])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var tagAliases []*model.TagAlias
+	for _, row := range tagAliasRows {
+		tagAlias, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		tagAliases = append(tagAliases, tagAlias)
+	}
+
+	return tagAliases, nil
+}
+
+// Point all aliases for one tag name at another (like when a tag gets renamed). Names
+// are matched case-insensitively (the same way that aliases are applied).
+func (r *TagAliasRepository) Rename(ctx context.Context, oldName, newName string) error {
+	stmt := `
+		UPDATE tag_alias
+		SET
+			name = $2,
+			updated_at = $3
+		WHERE lower(tag_alias.name) = lower($1)`
+
+	args := []any{
+		oldName,
+		newName,
+		timeutil.Now(),
+	}
+
+	_, err := r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	return nil
+}
+
+func (r *TagAliasRepository) Delete(ctx context.Context, tagAlias *model.TagAlias) error {
+	stmt := `
+		DELETE FROM tag_alias
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, tagAlias.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
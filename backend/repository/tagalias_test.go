@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestTagAliasCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tagAlias := test.NewTagAlias(t)
+	err := repo.TagAlias().Create(context.Background(), tagAlias)
+	test.AssertNilError(t, err)
+}
+
+func TestTagAliasCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tagAlias := test.CreateTagAlias(t, repo)
+
+	// Aliases are unique (after normalization).
+	duplicate, err := model.NewTagAlias(" "+tagAlias.Alias()+" ", test.RandomString(32))
+	test.AssertNilError(t, err)
+
+	err = repo.TagAlias().Create(context.Background(), duplicate)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestTagAliasRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tagAlias := test.CreateTagAlias(t, repo)
+	got, err := repo.TagAlias().Read(context.Background(), tagAlias.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), tagAlias.ID())
+	test.AssertEqual(t, got.Alias(), tagAlias.Alias())
+	test.AssertEqual(t, got.Name(), tagAlias.Name())
+}
+
+func TestTagAliasList(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	test.CreateTagAlias(t, repo)
+	test.CreateTagAlias(t, repo)
+
+	tagAliases, err := repo.TagAlias().List(context.Background())
+	test.AssertNilError(t, err)
+	test.AssertAtLeast(t, len(tagAliases), 2)
+}
+
+func TestTagAliasRename(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tagAlias := test.CreateTagAlias(t, repo)
+	newName := test.RandomString(20)
+
+	err := repo.TagAlias().Rename(context.Background(), tagAlias.Name(), newName)
+	test.AssertNilError(t, err)
+
+	got, err := repo.TagAlias().Read(context.Background(), tagAlias.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.Alias(), tagAlias.Alias())
+	test.AssertEqual(t, got.Name(), newName)
+}
+
+func TestTagAliasDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	tagAlias := test.CreateTagAlias(t, repo)
+
+	err := repo.TagAlias().Delete(context.Background(), tagAlias)
+	test.AssertNilError(t, err)
+
+	_, err = repo.TagAlias().Read(context.Background(), tagAlias.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
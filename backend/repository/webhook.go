@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+)
+
+type dbWebhook struct {
+	ID        uuid.UUID `db:"id"`
+	AccountID uuid.UUID `db:"account_id"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func marshalWebhook(webhook *model.Webhook) (dbWebhook, error) {
+	w := dbWebhook{
+		ID:        webhook.ID(),
+		AccountID: webhook.AccountID(),
+		URL:       webhook.URL(),
+		Secret:    webhook.Secret(),
+		CreatedAt: webhook.CreatedAt(),
+		UpdatedAt: webhook.UpdatedAt(),
+	}
+	return w, nil
+}
+
+func (w dbWebhook) unmarshal() (*model.Webhook, error) {
+	webhook := model.LoadWebhook(
+		w.ID,
+		w.AccountID,
+		w.URL,
+		w.Secret,
+		w.CreatedAt,
+		w.UpdatedAt,
+	)
+	return webhook, nil
+}
+
+type WebhookRepository struct {
+	conn postgres.Conn
+}
+
+func NewWebhookRepository(conn postgres.Conn) *WebhookRepository {
+	r := WebhookRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *model.Webhook) error {
+	stmt := `
+		INSERT INTO webhook
+			(id, account_id, url, secret, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)`
+
+	row, err := marshalWebhook(webhook)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.AccountID,
+		row.URL,
+		row.Secret,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Read(ctx context.Context, id uuid.UUID) (*model.Webhook, error) {
+	stmt := `
+		SELECT
+			webhook.id,
+			webhook.account_id,
+			webhook.url,
+			webhook.secret,
+			webhook.created_at,
+			webhook.updated_at
+		FROM webhook
+		WHERE webhook.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbWebhook])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *WebhookRepository) ListByAccount(ctx context.Context, account *model.Account) ([]*model.Webhook, error) {
+	stmt := `
+		SELECT
+			webhook.id,
+			webhook.account_id,
+			webhook.url,
+			webhook.secret,
+			webhook.created_at,
+			webhook.updated_at
+		FROM webhook
+		WHERE webhook.account_id = $1
+		ORDER BY webhook.created_at ASC`
+
+	rows, err := r.conn.Query(ctx, stmt, account.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return collectWebhooks(rows)
+}
+
+// List the webhooks of every account that follows a given blog.
+func (r *WebhookRepository) ListByBlog(ctx context.Context, blog *model.Blog) ([]*model.Webhook, error) {
+	stmt := `
+		SELECT
+			webhook.id,
+			webhook.account_id,
+			webhook.url,
+			webhook.secret,
+			webhook.created_at,
+			webhook.updated_at
+		FROM webhook
+		INNER JOIN account_blog
+			ON account_blog.account_id = webhook.account_id
+		WHERE account_blog.blog_id = $1
+		ORDER BY webhook.created_at ASC`
+
+	rows, err := r.conn.Query(ctx, stmt, blog.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return collectWebhooks(rows)
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, webhook *model.Webhook) error {
+	stmt := `
+		DELETE FROM webhook
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, webhook.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
+
+func collectWebhooks(rows pgx.Rows) ([]*model.Webhook, error) {
+	webhookRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbWebhook])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var webhooks []*model.Webhook
+	for _, row := range webhookRows {
+		webhook, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
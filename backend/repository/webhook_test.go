@@ -0,0 +1,117 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestWebhookCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+
+	webhook := test.NewWebhook(t, account)
+	err := repo.Webhook().Create(context.Background(), webhook)
+	test.AssertNilError(t, err)
+}
+
+func TestWebhookCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+
+	// attempt to create the same webhook again
+	err := repo.Webhook().Create(context.Background(), webhook)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestWebhookRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+
+	got, err := repo.Webhook().Read(context.Background(), webhook.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), webhook.ID())
+	test.AssertEqual(t, got.URL(), webhook.URL())
+	test.AssertEqual(t, got.Secret(), webhook.Secret())
+}
+
+func TestWebhookListByAccount(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	test.CreateWebhook(t, repo, account)
+	test.CreateWebhook(t, repo, account)
+
+	// webhooks from other accounts shouldn't be included
+	test.CreateWebhook(t, repo, test.CreateAccount(t, repo))
+
+	webhooks, err := repo.Webhook().ListByAccount(context.Background(), account)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, len(webhooks), 2)
+}
+
+func TestWebhookListByBlog(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+
+	follower := test.CreateAccount(t, repo)
+	test.CreateAccountBlog(t, repo, follower, blog)
+	followerWebhook := test.CreateWebhook(t, repo, follower)
+
+	// webhooks from accounts that don't follow the blog shouldn't be included
+	otherWebhook := test.CreateWebhook(t, repo, test.CreateAccount(t, repo))
+
+	webhooks, err := repo.Webhook().ListByBlog(context.Background(), blog)
+	test.AssertNilError(t, err)
+
+	var webhookIDs []uuid.UUID
+	for _, webhook := range webhooks {
+		webhookIDs = append(webhookIDs, webhook.ID())
+	}
+
+	test.AssertSliceContains(t, webhookIDs, followerWebhook.ID())
+	test.AssertSliceDoesNotContain(t, webhookIDs, otherWebhook.ID())
+}
+
+func TestWebhookDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+
+	err := repo.Webhook().Delete(context.Background(), webhook)
+	test.AssertNilError(t, err)
+
+	_, err = repo.Webhook().Read(context.Background(), webhook.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
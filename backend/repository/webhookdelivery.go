@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type dbWebhookDelivery struct {
+	ID             uuid.UUID `db:"id"`
+	WebhookID      uuid.UUID `db:"webhook_id"`
+	Event          string    `db:"event"`
+	Payload        string    `db:"payload"`
+	Status         string    `db:"status"`
+	Attempts       int       `db:"attempts"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+	LastStatusCode int       `db:"last_status_code"`
+	LastError      string    `db:"last_error"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+func marshalWebhookDelivery(delivery *model.WebhookDelivery) (dbWebhookDelivery, error) {
+	d := dbWebhookDelivery{
+		ID:             delivery.ID(),
+		WebhookID:      delivery.WebhookID(),
+		Event:          delivery.Event(),
+		Payload:        delivery.Payload(),
+		Status:         string(delivery.Status()),
+		Attempts:       delivery.Attempts(),
+		NextAttemptAt:  delivery.NextAttemptAt(),
+		LastStatusCode: delivery.LastStatusCode(),
+		LastError:      delivery.LastError(),
+		CreatedAt:      delivery.CreatedAt(),
+		UpdatedAt:      delivery.UpdatedAt(),
+	}
+	return d, nil
+}
+
+func (d dbWebhookDelivery) unmarshal() (*model.WebhookDelivery, error) {
+	delivery := model.LoadWebhookDelivery(
+		d.ID,
+		d.WebhookID,
+		d.Event,
+		d.Payload,
+		model.WebhookDeliveryStatus(d.Status),
+		d.Attempts,
+		d.NextAttemptAt,
+		d.LastStatusCode,
+		d.LastError,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	return delivery, nil
+}
+
+type WebhookDeliveryRepository struct {
+	conn postgres.Conn
+}
+
+func NewWebhookDeliveryRepository(conn postgres.Conn) *WebhookDeliveryRepository {
+	r := WebhookDeliveryRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	stmt := `
+		INSERT INTO webhook_delivery
+			(id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	row, err := marshalWebhookDelivery(delivery)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.WebhookID,
+		row.Event,
+		row.Payload,
+		row.Status,
+		row.Attempts,
+		row.NextAttemptAt,
+		row.LastStatusCode,
+		row.LastError,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) Read(ctx context.Context, id uuid.UUID) (*model.WebhookDelivery, error) {
+	stmt := `
+		SELECT
+			webhook_delivery.id,
+			webhook_delivery.webhook_id,
+			webhook_delivery.event,
+			webhook_delivery.payload,
+			webhook_delivery.status,
+			webhook_delivery.attempts,
+			webhook_delivery.next_attempt_at,
+			webhook_delivery.last_status_code,
+			webhook_delivery.last_error,
+			webhook_delivery.created_at,
+			webhook_delivery.updated_at
+		FROM webhook_delivery
+		WHERE webhook_delivery.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbWebhookDelivery])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+// List a webhook's most recent deliveries (newest first).
+func (r *WebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhook *model.Webhook, limit int) ([]*model.WebhookDelivery, error) {
+	stmt := `
+		SELECT
+			webhook_delivery.id,
+			webhook_delivery.webhook_id,
+			webhook_delivery.event,
+			webhook_delivery.payload,
+			webhook_delivery.status,
+			webhook_delivery.attempts,
+			webhook_delivery.next_attempt_at,
+			webhook_delivery.last_status_code,
+			webhook_delivery.last_error,
+			webhook_delivery.created_at,
+			webhook_delivery.updated_at
+		FROM webhook_delivery
+		WHERE webhook_delivery.webhook_id = $1
+		ORDER BY webhook_delivery.created_at DESC
+		LIMIT $2`
+
+	rows, err := r.conn.Query(ctx, stmt, webhook.ID(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectWebhookDeliveries(rows)
+}
+
+// List pending deliveries that are ready to be attempted (oldest first).
+func (r *WebhookDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	stmt := `
+		SELECT
+			webhook_delivery.id,
+			webhook_delivery.webhook_id,
+			webhook_delivery.event,
+			webhook_delivery.payload,
+			webhook_delivery.status,
+			webhook_delivery.attempts,
+			webhook_delivery.next_attempt_at,
+			webhook_delivery.last_status_code,
+			webhook_delivery.last_error,
+			webhook_delivery.created_at,
+			webhook_delivery.updated_at
+		FROM webhook_delivery
+		WHERE webhook_delivery.status = 'pending'
+			AND webhook_delivery.next_attempt_at <= $1
+		ORDER BY webhook_delivery.next_attempt_at ASC
+		LIMIT $2`
+
+	rows, err := r.conn.Query(ctx, stmt, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectWebhookDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *model.WebhookDelivery) error {
+	now := timeutil.Now()
+	stmt := `
+		UPDATE webhook_delivery
+		SET
+			status = $1,
+			attempts = $2,
+			next_attempt_at = $3,
+			last_status_code = $4,
+			last_error = $5,
+			updated_at = $6
+		WHERE id = $7
+			AND updated_at = $8
+		RETURNING updated_at`
+
+	row, err := marshalWebhookDelivery(delivery)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.Status,
+		row.Attempts,
+		row.NextAttemptAt,
+		row.LastStatusCode,
+		row.LastError,
+		now,
+		row.ID,
+		row.UpdatedAt,
+	}
+
+	rows, err := r.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	delivery.SetUpdatedAt(now)
+	return nil
+}
+
+func collectWebhookDeliveries(rows pgx.Rows) ([]*model.WebhookDelivery, error) {
+	deliveryRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbWebhookDelivery])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var deliveries []*model.WebhookDelivery
+	for _, row := range deliveryRows {
+		delivery, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
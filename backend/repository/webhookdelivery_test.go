@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestWebhookDeliveryCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+
+	delivery := test.NewWebhookDelivery(t, webhook)
+	err := repo.WebhookDelivery().Create(context.Background(), delivery)
+	test.AssertNilError(t, err)
+}
+
+func TestWebhookDeliveryRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+	delivery := test.CreateWebhookDelivery(t, repo, webhook)
+
+	got, err := repo.WebhookDelivery().Read(context.Background(), delivery.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), delivery.ID())
+	test.AssertEqual(t, got.Event(), delivery.Event())
+	test.AssertEqual(t, got.Payload(), delivery.Payload())
+	test.AssertEqual(t, got.Status(), model.WebhookDeliveryStatusPending)
+}
+
+func TestWebhookDeliveryListByWebhook(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+	test.CreateWebhookDelivery(t, repo, webhook)
+	test.CreateWebhookDelivery(t, repo, webhook)
+	test.CreateWebhookDelivery(t, repo, webhook)
+
+	deliveries, err := repo.WebhookDelivery().ListByWebhook(context.Background(), webhook, 2)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, len(deliveries), 2)
+}
+
+func TestWebhookDeliveryListDue(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+
+	dueDelivery := test.CreateWebhookDelivery(t, repo, webhook)
+
+	// deliveries waiting on a retry aren't due yet
+	laterDelivery := test.NewWebhookDelivery(t, webhook)
+	laterDelivery.RecordFailure(timeutil.Now(), 500, "oops")
+	err := repo.WebhookDelivery().Create(context.Background(), laterDelivery)
+	test.AssertNilError(t, err)
+
+	// and deliveries that succeeded are never due again
+	doneDelivery := test.NewWebhookDelivery(t, webhook)
+	doneDelivery.RecordSuccess(200)
+	err = repo.WebhookDelivery().Create(context.Background(), doneDelivery)
+	test.AssertNilError(t, err)
+
+	deliveries, err := repo.WebhookDelivery().ListDue(context.Background(), timeutil.Now().Add(time.Second), 1000)
+	test.AssertNilError(t, err)
+
+	var deliveryIDs []uuid.UUID
+	for _, delivery := range deliveries {
+		deliveryIDs = append(deliveryIDs, delivery.ID())
+	}
+
+	test.AssertSliceContains(t, deliveryIDs, dueDelivery.ID())
+	test.AssertSliceDoesNotContain(t, deliveryIDs, laterDelivery.ID())
+	test.AssertSliceDoesNotContain(t, deliveryIDs, doneDelivery.ID())
+}
+
+func TestWebhookDeliveryUpdate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	webhook := test.CreateWebhook(t, repo, account)
+	delivery := test.CreateWebhookDelivery(t, repo, webhook)
+
+	delivery.RecordFailure(timeutil.Now(), 502, "bad gateway")
+	err := repo.WebhookDelivery().Update(context.Background(), delivery)
+	test.AssertNilError(t, err)
+
+	got, err := repo.WebhookDelivery().Read(context.Background(), delivery.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.Attempts(), 1)
+	test.AssertEqual(t, got.LastStatusCode(), 502)
+	test.AssertEqual(t, got.LastError(), "bad gateway")
+	test.AssertEqual(t, got.NextAttemptAt().Equal(delivery.NextAttemptAt()), true)
+}
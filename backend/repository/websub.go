@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+type dbWebSubSubscription struct {
+	ID             uuid.UUID `db:"id"`
+	BlogID         uuid.UUID `db:"blog_id"`
+	HubURL         string    `db:"hub_url"`
+	TopicURL       string    `db:"topic_url"`
+	Secret         string    `db:"secret"`
+	RequestedAt    time.Time `db:"requested_at"`
+	LeaseExpiresAt time.Time `db:"lease_expires_at"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+func marshalWebSubSubscription(subscription *model.WebSubSubscription) (dbWebSubSubscription, error) {
+	s := dbWebSubSubscription{
+		ID:             subscription.ID(),
+		BlogID:         subscription.BlogID(),
+		HubURL:         subscription.HubURL(),
+		TopicURL:       subscription.TopicURL(),
+		Secret:         subscription.Secret(),
+		RequestedAt:    subscription.RequestedAt(),
+		LeaseExpiresAt: subscription.LeaseExpiresAt(),
+		CreatedAt:      subscription.CreatedAt(),
+		UpdatedAt:      subscription.UpdatedAt(),
+	}
+	return s, nil
+}
+
+func (s dbWebSubSubscription) unmarshal() (*model.WebSubSubscription, error) {
+	subscription := model.LoadWebSubSubscription(
+		s.ID,
+		s.BlogID,
+		s.HubURL,
+		s.TopicURL,
+		s.Secret,
+		s.RequestedAt,
+		s.LeaseExpiresAt,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	return subscription, nil
+}
+
+type WebSubSubscriptionRepository struct {
+	conn postgres.Conn
+}
+
+func NewWebSubSubscriptionRepository(conn postgres.Conn) *WebSubSubscriptionRepository {
+	r := WebSubSubscriptionRepository{
+		conn: conn,
+	}
+	return &r
+}
+
+func (r *WebSubSubscriptionRepository) Create(ctx context.Context, subscription *model.WebSubSubscription) error {
+	stmt := `
+		INSERT INTO websub_subscription
+			(id, blog_id, hub_url, topic_url, secret, requested_at, lease_expires_at, created_at, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	row, err := marshalWebSubSubscription(subscription)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.ID,
+		row.BlogID,
+		row.HubURL,
+		row.TopicURL,
+		row.Secret,
+		row.RequestedAt,
+		row.LeaseExpiresAt,
+		row.CreatedAt,
+		row.UpdatedAt,
+	}
+
+	_, err = r.conn.Exec(ctx, stmt, args...)
+	if err != nil {
+		return postgres.CheckCreateError(err)
+	}
+
+	return nil
+}
+
+func (r *WebSubSubscriptionRepository) Read(ctx context.Context, id uuid.UUID) (*model.WebSubSubscription, error) {
+	stmt := `
+		SELECT
+			websub_subscription.id,
+			websub_subscription.blog_id,
+			websub_subscription.hub_url,
+			websub_subscription.topic_url,
+			websub_subscription.secret,
+			websub_subscription.requested_at,
+			websub_subscription.lease_expires_at,
+			websub_subscription.created_at,
+			websub_subscription.updated_at
+		FROM websub_subscription
+		WHERE websub_subscription.id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbWebSubSubscription])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *WebSubSubscriptionRepository) ReadByBlog(ctx context.Context, blog *model.Blog) (*model.WebSubSubscription, error) {
+	stmt := `
+		SELECT
+			websub_subscription.id,
+			websub_subscription.blog_id,
+			websub_subscription.hub_url,
+			websub_subscription.topic_url,
+			websub_subscription.secret,
+			websub_subscription.requested_at,
+			websub_subscription.lease_expires_at,
+			websub_subscription.created_at,
+			websub_subscription.updated_at
+		FROM websub_subscription
+		WHERE websub_subscription.blog_id = $1`
+
+	rows, err := r.conn.Query(ctx, stmt, blog.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dbWebSubSubscription])
+	if err != nil {
+		return nil, postgres.CheckReadError(err)
+	}
+
+	return row.unmarshal()
+}
+
+func (r *WebSubSubscriptionRepository) List(ctx context.Context) ([]*model.WebSubSubscription, error) {
+	stmt := `
+		SELECT
+			websub_subscription.id,
+			websub_subscription.blog_id,
+			websub_subscription.hub_url,
+			websub_subscription.topic_url,
+			websub_subscription.secret,
+			websub_subscription.requested_at,
+			websub_subscription.lease_expires_at,
+			websub_subscription.created_at,
+			websub_subscription.updated_at
+		FROM websub_subscription
+		ORDER BY websub_subscription.lease_expires_at ASC`
+
+	rows, err := r.conn.Query(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[dbWebSubSubscription])
+	if err != nil {
+		return nil, postgres.CheckListError(err)
+	}
+
+	var subscriptions []*model.WebSubSubscription
+	for _, row := range subscriptionRows {
+		subscription, err := row.unmarshal()
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebSubSubscriptionRepository) Update(ctx context.Context, subscription *model.WebSubSubscription) error {
+	now := timeutil.Now()
+	stmt := `
+		UPDATE websub_subscription
+		SET
+			hub_url = $1,
+			topic_url = $2,
+			requested_at = $3,
+			lease_expires_at = $4,
+			updated_at = $5
+		WHERE id = $6
+			AND updated_at = $7
+		RETURNING updated_at`
+
+	row, err := marshalWebSubSubscription(subscription)
+	if err != nil {
+		return err
+	}
+
+	args := []any{
+		row.HubURL,
+		row.TopicURL,
+		row.RequestedAt,
+		row.LeaseExpiresAt,
+		now,
+		row.ID,
+		row.UpdatedAt,
+	}
+
+	rows, err := r.conn.Query(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return postgres.CheckUpdateError(err)
+	}
+
+	subscription.SetUpdatedAt(now)
+	return nil
+}
+
+func (r *WebSubSubscriptionRepository) Delete(ctx context.Context, subscription *model.WebSubSubscription) error {
+	stmt := `
+		DELETE FROM websub_subscription
+		WHERE id = $1
+		RETURNING id`
+
+	rows, err := r.conn.Query(ctx, stmt, subscription.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return postgres.CheckDeleteError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+)
+
+func TestWebSubSubscriptionCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+
+	subscription := test.NewWebSubSubscription(t, blog)
+	err := repo.WebSubSubscription().Create(context.Background(), subscription)
+	test.AssertNilError(t, err)
+}
+
+func TestWebSubSubscriptionCreateAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	test.CreateWebSubSubscription(t, repo, blog)
+
+	// attempt to create a second subscription for the same blog
+	duplicate := test.NewWebSubSubscription(t, blog)
+	err := repo.WebSubSubscription().Create(context.Background(), duplicate)
+	test.AssertErrorIs(t, err, postgres.ErrConflict)
+}
+
+func TestWebSubSubscriptionRead(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	subscription := test.CreateWebSubSubscription(t, repo, blog)
+
+	got, err := repo.WebSubSubscription().Read(context.Background(), subscription.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), subscription.ID())
+	test.AssertEqual(t, got.HubURL(), subscription.HubURL())
+	test.AssertEqual(t, got.TopicURL(), subscription.TopicURL())
+	test.AssertEqual(t, got.Secret(), subscription.Secret())
+}
+
+func TestWebSubSubscriptionReadByBlog(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	subscription := test.CreateWebSubSubscription(t, repo, blog)
+
+	got, err := repo.WebSubSubscription().ReadByBlog(context.Background(), blog)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.ID(), subscription.ID())
+}
+
+func TestWebSubSubscriptionList(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	test.CreateWebSubSubscription(t, repo, test.CreateBlog(t, repo))
+	test.CreateWebSubSubscription(t, repo, test.CreateBlog(t, repo))
+	test.CreateWebSubSubscription(t, repo, test.CreateBlog(t, repo))
+
+	subscriptions, err := repo.WebSubSubscription().List(context.Background())
+	test.AssertNilError(t, err)
+
+	test.AssertAtLeast(t, len(subscriptions), 3)
+}
+
+func TestWebSubSubscriptionUpdate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	subscription := test.CreateWebSubSubscription(t, repo, blog)
+
+	now := timeutil.Now()
+	subscription.SetRequestedAt(now)
+	subscription.SetLease(now, 24*time.Hour)
+
+	err := repo.WebSubSubscription().Update(context.Background(), subscription)
+	test.AssertNilError(t, err)
+
+	got, err := repo.WebSubSubscription().Read(context.Background(), subscription.ID())
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, got.RequestedAt().Equal(now), true)
+	test.AssertEqual(t, got.LeaseExpiresAt().Equal(now.Add(24*time.Hour)), true)
+}
+
+func TestWebSubSubscriptionDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	blog := test.CreateBlog(t, repo)
+	subscription := test.CreateWebSubSubscription(t, repo, blog)
+
+	err := repo.WebSubSubscription().Delete(context.Background(), subscription)
+	test.AssertNilError(t, err)
+
+	_, err = repo.WebSubSubscription().Read(context.Background(), subscription.ID())
+	test.AssertErrorIs(t, err, postgres.ErrNotFound)
+}
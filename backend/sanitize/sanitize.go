@@ -0,0 +1,236 @@
+// Package sanitize cleans up untrusted HTML (like the content of a feed's posts) so
+// that it can be safely rendered as part of a Bloggulus page.
+package sanitize
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Elements that are kept (along with the attributes they are allowed to have).
+// Any other element is unwrapped: it is removed but its children are kept.
+var allowedTags = map[atom.Atom][]string{
+	atom.A:          {"href", "title"},
+	atom.Abbr:       {"title"},
+	atom.B:          nil,
+	atom.Blockquote: {"cite"},
+	atom.Br:         nil,
+	atom.Caption:    nil,
+	atom.Code:       nil,
+	atom.Dd:         nil,
+	atom.Del:        nil,
+	atom.Dl:         nil,
+	atom.Dt:         nil,
+	atom.Em:         nil,
+	atom.Figcaption: nil,
+	atom.Figure:     nil,
+	atom.H1:         nil,
+	atom.H2:         nil,
+	atom.H3:         nil,
+	atom.H4:         nil,
+	atom.H5:         nil,
+	atom.H6:         nil,
+	atom.Hr:         nil,
+	atom.I:          nil,
+	atom.Img:        {"src", "alt", "title", "width", "height"},
+	atom.Ins:        nil,
+	atom.Kbd:        nil,
+	atom.Li:         nil,
+	atom.Mark:       nil,
+	atom.Ol:         {"start"},
+	atom.P:          nil,
+	atom.Pre:        nil,
+	atom.Q:          {"cite"},
+	atom.S:          nil,
+	atom.Samp:       nil,
+	atom.Small:      nil,
+	atom.Strong:     nil,
+	atom.Sub:        nil,
+	atom.Sup:        nil,
+	atom.Table:      nil,
+	atom.Tbody:      nil,
+	atom.Td:         {"colspan", "rowspan"},
+	atom.Tfoot:      nil,
+	atom.Th:         {"colspan", "rowspan"},
+	atom.Thead:      nil,
+	atom.Tr:         nil,
+	atom.U:          nil,
+	atom.Ul:         nil,
+}
+
+// Elements that are removed entirely (including their children).
+var droppedTags = map[atom.Atom]bool{
+	atom.Button:   true,
+	atom.Canvas:   true,
+	atom.Embed:    true,
+	atom.Form:     true,
+	atom.Head:     true,
+	atom.Iframe:   true,
+	atom.Input:    true,
+	atom.Link:     true,
+	atom.Math:     true,
+	atom.Meta:     true,
+	atom.Noscript: true,
+	atom.Object:   true,
+	atom.Script:   true,
+	atom.Select:   true,
+	atom.Style:    true,
+	atom.Svg:      true,
+	atom.Template: true,
+	atom.Textarea: true,
+	atom.Title:    true,
+}
+
+// Attributes that hold URLs (which are resolved and checked against allowedSchemes).
+var urlAttrs = map[string]bool{
+	"href": true,
+	"src":  true,
+	"cite": true,
+}
+
+var allowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// Sanitize an HTML fragment using an allowlist of elements and attributes. Relative
+// links and images are resolved against the given base URL (usually the blog's site
+// URL) and any URL that doesn't use a safe scheme is removed. Links are made to open
+// in a new tab without passing along the referrer.
+func HTML(content, baseURL string) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		base = nil
+	}
+
+	parent := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "div",
+		DataAtom: atom.Div,
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(content), parent)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, node := range nodes {
+		for _, clean := range sanitize(node, base) {
+			html.Render(&sb, clean)
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// Sanitize a single node (and its children). Since unwrapped elements are replaced by
+// their children, this can return any number of nodes.
+func sanitize(node *html.Node, base *url.URL) []*html.Node {
+	switch node.Type {
+	case html.TextNode:
+		return []*html.Node{{Type: html.TextNode, Data: node.Data}}
+	case html.ElementNode:
+		// handled below
+	default:
+		// comments, doctypes, etc
+		return nil
+	}
+
+	if droppedTags[node.DataAtom] {
+		return nil
+	}
+
+	var children []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, sanitize(child, base)...)
+	}
+
+	allowedAttrs, ok := allowedTags[node.DataAtom]
+	if !ok {
+		return children
+	}
+
+	clean := &html.Node{
+		Type:     html.ElementNode,
+		Data:     node.Data,
+		DataAtom: node.DataAtom,
+	}
+	for _, attr := range node.Attr {
+		if attr.Namespace != "" || !slices.Contains(allowedAttrs, attr.Key) {
+			continue
+		}
+
+		if urlAttrs[attr.Key] {
+			val, ok := resolveURL(attr.Val, base)
+			if !ok {
+				continue
+			}
+			attr.Val = val
+		}
+
+		clean.Attr = append(clean.Attr, html.Attribute{Key: attr.Key, Val: attr.Val})
+	}
+
+	switch node.DataAtom {
+	case atom.A:
+		if hasAttr(clean, "href") {
+			clean.Attr = append(clean.Attr,
+				html.Attribute{Key: "target", Val: "_blank"},
+				html.Attribute{Key: "rel", Val: "noopener noreferrer nofollow"},
+			)
+		}
+	case atom.Img:
+		// Images without a (safe) source aren't worth keeping.
+		if !hasAttr(clean, "src") {
+			return nil
+		}
+		clean.Attr = append(clean.Attr, html.Attribute{Key: "loading", Val: "lazy"})
+	}
+
+	for _, child := range children {
+		clean.AppendChild(child)
+	}
+
+	return []*html.Node{clean}
+}
+
+// Resolve a (possibly relative) URL against the base URL and ensure that it uses a safe scheme.
+func resolveURL(raw string, base *url.URL) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+
+	// Fragment-only links point at elements within the original page (whose IDs are removed).
+	if u.Scheme == "" && u.Host == "" && u.Path == "" && u.RawQuery == "" {
+		return "", false
+	}
+
+	if !u.IsAbs() {
+		if base == nil || !base.IsAbs() {
+			return "", false
+		}
+		u = base.ResolveReference(u)
+	}
+
+	if !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+
+	return u.String(), true
+}
+
+func hasAttr(node *html.Node, key string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package sanitize_test
+
+import (
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/sanitize"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	baseURL := "https://example.com/blog/"
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "plain text",
+			content: "hello world",
+			want:    "hello world",
+		},
+		{
+			name:    "allowed elements",
+			content: "<p>hello <strong>world</strong></p>",
+			want:    "<p>hello <strong>world</strong></p>",
+		},
+		{
+			name:    "dropped elements",
+			content: `<p>hello</p><script>alert("hi")</script><style>p { color: red; }</style>`,
+			want:    "<p>hello</p>",
+		},
+		{
+			name:    "unwrapped elements",
+			content: `<div class="post"><span style="color: red;">hello</span></div>`,
+			want:    "hello",
+		},
+		{
+			name:    "disallowed attributes",
+			content: `<p class="foo" onclick="alert('hi')">hello</p>`,
+			want:    "<p>hello</p>",
+		},
+		{
+			name:    "absolute link",
+			content: `<a href="https://other.com/post">link</a>`,
+			want:    `<a href="https://other.com/post" target="_blank" rel="noopener noreferrer nofollow">link</a>`,
+		},
+		{
+			name:    "relative link",
+			content: `<a href="/about">link</a>`,
+			want:    `<a href="https://example.com/about" target="_blank" rel="noopener noreferrer nofollow">link</a>`,
+		},
+		{
+			name:    "relative image",
+			content: `<img src="images/cat.png" alt="a cat">`,
+			want:    `<img src="https://example.com/blog/images/cat.png" alt="a cat" loading="lazy"/>`,
+		},
+		{
+			name:    "fragment link",
+			content: `<a href="#section">link</a>`,
+			want:    `<a>link</a>`,
+		},
+		{
+			name:    "javascript link",
+			content: `<a href="javascript:alert('hi')">link</a>`,
+			want:    `<a>link</a>`,
+		},
+		{
+			name:    "data image",
+			content: `<img src="data:image/png;base64,AAAA">`,
+			want:    "",
+		},
+		{
+			name:    "comment",
+			content: "<!-- secret --><p>hello</p>",
+			want:    "<p>hello</p>",
+		},
+		{
+			name:    "escaped text",
+			content: "<p>1 &lt; 2</p>",
+			want:    "<p>1 &lt; 2</p>",
+		},
+	}
+	for _, tt := range tests {
+		got := sanitize.HTML(tt.content, baseURL)
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLInvalidBaseURL(t *testing.T) {
+	t.Parallel()
+
+	// Relative URLs can't be resolved without a valid base URL (so they are removed).
+	got := sanitize.HTML(`<a href="/about">link</a>`, "")
+	test.AssertEqual(t, got, `<a>link</a>`)
+}
@@ -0,0 +1,92 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type atomLink struct {
+	HREF string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomSource struct {
+	Title string   `xml:"title"`
+	Link  atomLink `xml:"link"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Source     atomSource     `xml:"source"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// Render a Feed as an Atom (RFC 4287) document.
+func GenerateAtom(feed Feed) (string, error) {
+	var entries []atomEntry
+	for _, entry := range feed.Entries {
+		var categories []atomCategory
+		for _, tag := range entry.Tags {
+			categories = append(categories, atomCategory{Term: tag})
+		}
+
+		publishedAt := entry.PublishedAt.UTC().Format(time.RFC3339)
+		entries = append(entries, atomEntry{
+			ID:        entry.URL,
+			Title:     entry.Title,
+			Link:      atomLink{HREF: entry.URL, Rel: "alternate"},
+			Published: publishedAt,
+			Updated:   publishedAt,
+			Author: atomAuthor{
+				Name: entry.BlogTitle,
+				URI:  entry.BlogURL,
+			},
+			Source: atomSource{
+				Title: entry.BlogTitle,
+				Link:  atomLink{HREF: entry.BlogURL},
+			},
+			Categories: categories,
+		})
+	}
+
+	f := atomFeed{
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: feed.UpdatedAt.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{HREF: feed.FeedURL, Rel: "self"},
+			{HREF: feed.SiteURL, Rel: "alternate"},
+		},
+		Entries: entries,
+	}
+
+	out, err := xml.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(out), nil
+}
@@ -0,0 +1,80 @@
+package syndication_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/theandrew168/bloggulus/backend/syndication"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func newFeed() syndication.Feed {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	feed := syndication.Feed{
+		ID:        "urn:uuid:00000000-0000-0000-0000-000000000000",
+		Title:     "Bloggulus - foo",
+		SiteURL:   "https://bloggulus.com",
+		FeedURL:   "https://bloggulus.com/feeds/foo/atom.xml",
+		UpdatedAt: now,
+		Entries: []syndication.Entry{
+			{
+				Title:       "Foo & Bar",
+				URL:         "https://example.com/foo",
+				BlogTitle:   "Example",
+				BlogURL:     "https://example.com",
+				PublishedAt: now,
+				Tags:        []string{"Go", "Web"},
+			},
+			{
+				Title:       "Baz",
+				URL:         "https://example.com/baz",
+				BlogTitle:   "Example",
+				BlogURL:     "https://example.com",
+				PublishedAt: now.Add(-24 * time.Hour),
+			},
+		},
+	}
+	return feed
+}
+
+func TestGenerateAtom(t *testing.T) {
+	t.Parallel()
+
+	feed := newFeed()
+
+	doc, err := syndication.GenerateAtom(feed)
+	test.AssertNilError(t, err)
+
+	parsed, err := gofeed.NewParser().Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, parsed.FeedType, "atom")
+	test.AssertEqual(t, parsed.Title, feed.Title)
+	test.AssertEqual(t, parsed.FeedLink, feed.FeedURL)
+	test.AssertEqual(t, len(parsed.Items), len(feed.Entries))
+
+	for i, item := range parsed.Items {
+		entry := feed.Entries[i]
+		test.AssertEqual(t, item.Title, entry.Title)
+		test.AssertEqual(t, item.Link, entry.URL)
+		test.AssertEqual(t, item.Author.Name, entry.BlogTitle)
+		test.AssertEqual(t, item.PublishedParsed.Equal(entry.PublishedAt), true)
+		test.AssertEqual(t, len(item.Categories), len(entry.Tags))
+	}
+}
+
+func TestGenerateAtomEmpty(t *testing.T) {
+	t.Parallel()
+
+	feed := newFeed()
+	feed.Entries = nil
+
+	doc, err := syndication.GenerateAtom(feed)
+	test.AssertNilError(t, err)
+
+	parsed, err := gofeed.NewParser().Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(parsed.Items), 0)
+}
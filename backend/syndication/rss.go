@@ -0,0 +1,75 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type rssSource struct {
+	Value string `xml:",chardata"`
+	URL   string `xml:"url,attr"`
+}
+
+type rssItem struct {
+	Title      string    `xml:"title"`
+	Link       string    `xml:"link"`
+	GUID       rssGUID   `xml:"guid"`
+	PubDate    string    `xml:"pubDate"`
+	Source     rssSource `xml:"source"`
+	Categories []string  `xml:"category"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// Render a Feed as an RSS 2.0 document.
+func GenerateRSS(feed Feed) (string, error) {
+	var items []rssItem
+	for _, entry := range feed.Entries {
+		items = append(items, rssItem{
+			Title:   entry.Title,
+			Link:    entry.URL,
+			GUID:    rssGUID{Value: entry.URL, IsPermaLink: true},
+			PubDate: entry.PublishedAt.UTC().Format(time.RFC1123Z),
+			Source: rssSource{
+				Value: entry.BlogTitle,
+				URL:   entry.BlogURL,
+			},
+			Categories: entry.Tags,
+		})
+	}
+
+	f := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.SiteURL,
+			Description:   feed.Title,
+			LastBuildDate: feed.UpdatedAt.UTC().Format(time.RFC1123Z),
+			Items:         items,
+		},
+	}
+
+	out, err := xml.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(out), nil
+}
@@ -0,0 +1,36 @@
+package syndication_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/theandrew168/bloggulus/backend/syndication"
+	"github.com/theandrew168/bloggulus/backend/test"
+)
+
+func TestGenerateRSS(t *testing.T) {
+	t.Parallel()
+
+	feed := newFeed()
+
+	doc, err := syndication.GenerateRSS(feed)
+	test.AssertNilError(t, err)
+
+	parsed, err := gofeed.NewParser().Parse(strings.NewReader(doc))
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, parsed.FeedType, "rss")
+	test.AssertEqual(t, parsed.Title, feed.Title)
+	test.AssertEqual(t, parsed.Link, feed.SiteURL)
+	test.AssertEqual(t, len(parsed.Items), len(feed.Entries))
+
+	for i, item := range parsed.Items {
+		entry := feed.Entries[i]
+		test.AssertEqual(t, item.Title, entry.Title)
+		test.AssertEqual(t, item.Link, entry.URL)
+		test.AssertEqual(t, item.GUID, entry.URL)
+		test.AssertEqual(t, item.PublishedParsed.Equal(entry.PublishedAt), true)
+		test.AssertEqual(t, len(item.Categories), len(entry.Tags))
+	}
+}
@@ -0,0 +1,23 @@
+// This package renders Bloggulus content (like an account's timeline) as
+// outgoing Atom and RSS feeds for consumption by other feed readers.
+package syndication
+
+import "time"
+
+type Feed struct {
+	ID        string
+	Title     string
+	SiteURL   string
+	FeedURL   string
+	UpdatedAt time.Time
+	Entries   []Entry
+}
+
+type Entry struct {
+	Title       string
+	URL         string
+	BlogTitle   string
+	BlogURL     string
+	PublishedAt time.Time
+	Tags        []string
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -64,6 +65,88 @@ func NewSession(t *testing.T, account *model.Account) (*model.Session, string) {
 	return session, sessionID
 }
 
+func NewAPIToken(t *testing.T, account *model.Account) (*model.APIToken, string) {
+	apiToken, token, err := model.NewAPIToken(
+		account,
+		RandomString(32),
+	)
+	AssertNilError(t, err)
+
+	return apiToken, token
+}
+
+func NewFeedToken(t *testing.T, account *model.Account) (*model.FeedToken, string) {
+	feedToken, token, err := model.NewFeedToken(account)
+	AssertNilError(t, err)
+
+	return feedToken, token
+}
+
+func NewDigest(t *testing.T, account *model.Account) *model.Digest {
+	digest, err := model.NewDigest(
+		account,
+		RandomString(20)+"@example.com",
+		model.DigestFrequencyDaily,
+	)
+	AssertNilError(t, err)
+
+	return digest
+}
+
+func NewWebhook(t *testing.T, account *model.Account) *model.Webhook {
+	webhook, err := model.NewWebhook(
+		account,
+		RandomURL(32),
+		RandomString(32),
+	)
+	AssertNilError(t, err)
+
+	return webhook
+}
+
+func NewWebhookDelivery(t *testing.T, webhook *model.Webhook) *model.WebhookDelivery {
+	delivery, err := model.NewWebhookDelivery(
+		webhook,
+		RandomString(20),
+		`{"foo":"bar"}`,
+	)
+	AssertNilError(t, err)
+
+	return delivery
+}
+
+func NewWebSubSubscription(t *testing.T, blog *model.Blog) *model.WebSubSubscription {
+	subscription, err := model.NewWebSubSubscription(
+		blog,
+		RandomURL(32),
+		RandomURL(32),
+	)
+	AssertNilError(t, err)
+
+	return subscription
+}
+
+func NewTagAlias(t *testing.T) *model.TagAlias {
+	tagAlias, err := model.NewTagAlias(
+		RandomString(32),
+		RandomString(32),
+	)
+	AssertNilError(t, err)
+
+	return tagAlias
+}
+
+func NewContentFilter(t *testing.T, account *model.Account) *model.ContentFilter {
+	filter, err := model.NewContentFilter(
+		account,
+		model.ContentFilterKindKeyword,
+		RandomString(32),
+	)
+	AssertNilError(t, err)
+
+	return filter
+}
+
 // mocks a blog and creates it in the database
 func CreateBlog(t *testing.T, repo *repository.Repository) *model.Blog {
 	t.Helper()
@@ -72,7 +155,7 @@ func CreateBlog(t *testing.T, repo *repository.Repository) *model.Blog {
 	blog := NewBlog(t)
 
 	// create an example blog
-	err := repo.Blog().Create(blog)
+	err := repo.Blog().Create(context.Background(), blog)
 	AssertNilError(t, err)
 
 	return blog
@@ -86,7 +169,7 @@ func CreatePost(t *testing.T, repo *repository.Repository, blog *model.Blog) *mo
 	post := NewPost(t, blog)
 
 	// create an example post
-	err := repo.Post().Create(post)
+	err := repo.Post().Create(context.Background(), post)
 	AssertNilError(t, err)
 
 	return post
@@ -100,7 +183,7 @@ func CreateTag(t *testing.T, repo *repository.Repository) *model.Tag {
 	tag := NewTag(t)
 
 	// create an example tag
-	err := repo.Tag().Create(tag)
+	err := repo.Tag().Create(context.Background(), tag)
 	AssertNilError(t, err)
 
 	return tag
@@ -114,7 +197,7 @@ func CreateAccount(t *testing.T, repo *repository.Repository) *model.Account {
 	account := NewAccount(t)
 
 	// create an example account
-	err := repo.Account().Create(account)
+	err := repo.Account().Create(context.Background(), account)
 	AssertNilError(t, err)
 
 	return account
@@ -128,17 +211,138 @@ func CreateSession(t *testing.T, repo *repository.Repository, account *model.Acc
 	session, sessionID := NewSession(t, account)
 
 	// create an example session
-	err := repo.Session().Create(session)
+	err := repo.Session().Create(context.Background(), session)
 	AssertNilError(t, err)
 
 	return session, sessionID
 }
 
+// mocks an API token and creates it in the database
+func CreateAPIToken(t *testing.T, repo *repository.Repository, account *model.Account) (*model.APIToken, string) {
+	t.Helper()
+
+	// generate some random API token data
+	apiToken, token := NewAPIToken(t, account)
+
+	// create an example API token
+	err := repo.APIToken().Create(context.Background(), apiToken)
+	AssertNilError(t, err)
+
+	return apiToken, token
+}
+
+// mocks a feed token and creates it in the database
+func CreateFeedToken(t *testing.T, repo *repository.Repository, account *model.Account) (*model.FeedToken, string) {
+	t.Helper()
+
+	// generate some random feed token data
+	feedToken, token := NewFeedToken(t, account)
+
+	// create an example feed token
+	err := repo.FeedToken().Create(context.Background(), feedToken)
+	AssertNilError(t, err)
+
+	return feedToken, token
+}
+
 // create an account blog in the database
 func CreateAccountBlog(t *testing.T, repo *repository.Repository, account *model.Account, blog *model.Blog) {
 	t.Helper()
 
 	// create an account blog
-	err := repo.AccountBlog().Create(account, blog)
+	err := repo.AccountBlog().Create(context.Background(), account, blog)
+	AssertNilError(t, err)
+}
+
+// create an account post (saved post) in the database
+func CreateAccountPost(t *testing.T, repo *repository.Repository, account *model.Account, post *model.Post) {
+	t.Helper()
+
+	// create an account post
+	err := repo.AccountPost().Create(context.Background(), account, post)
+	AssertNilError(t, err)
+}
+
+// mocks a digest and creates it in the database
+func CreateDigest(t *testing.T, repo *repository.Repository, account *model.Account) *model.Digest {
+	t.Helper()
+
+	// generate some random digest data
+	digest := NewDigest(t, account)
+
+	// create an example digest
+	err := repo.Digest().Create(context.Background(), digest)
+	AssertNilError(t, err)
+
+	return digest
+}
+
+// mocks a webhook and creates it in the database
+func CreateWebhook(t *testing.T, repo *repository.Repository, account *model.Account) *model.Webhook {
+	t.Helper()
+
+	// generate some random webhook data
+	webhook := NewWebhook(t, account)
+
+	// create an example webhook
+	err := repo.Webhook().Create(context.Background(), webhook)
+	AssertNilError(t, err)
+
+	return webhook
+}
+
+// mocks a webhook delivery and creates it in the database
+func CreateWebhookDelivery(t *testing.T, repo *repository.Repository, webhook *model.Webhook) *model.WebhookDelivery {
+	t.Helper()
+
+	// generate some random webhook delivery data
+	delivery := NewWebhookDelivery(t, webhook)
+
+	// create an example webhook delivery
+	err := repo.WebhookDelivery().Create(context.Background(), delivery)
+	AssertNilError(t, err)
+
+	return delivery
+}
+
+// mocks a WebSub subscription and creates it in the database
+func CreateWebSubSubscription(t *testing.T, repo *repository.Repository, blog *model.Blog) *model.WebSubSubscription {
+	t.Helper()
+
+	// generate some random WebSub subscription data
+	subscription := NewWebSubSubscription(t, blog)
+
+	// create an example WebSub subscription
+	err := repo.WebSubSubscription().Create(context.Background(), subscription)
+	AssertNilError(t, err)
+
+	return subscription
+}
+
+// mocks a tag alias and creates it in the database
+func CreateTagAlias(t *testing.T, repo *repository.Repository) *model.TagAlias {
+	t.Helper()
+
+	// generate some random tag alias data
+	tagAlias := NewTagAlias(t)
+
+	// create an example tag alias
+	err := repo.TagAlias().Create(context.Background(), tagAlias)
+	AssertNilError(t, err)
+
+	return tagAlias
+}
+
+// mocks a content filter and creates it in the database
+func CreateContentFilter(t *testing.T, repo *repository.Repository, account *model.Account) *model.ContentFilter {
+	t.Helper()
+
+	// generate some random content filter data
+	filter := NewContentFilter(t, account)
+
+	// create an example content filter
+	err := repo.ContentFilter().Create(context.Background(), filter)
 	AssertNilError(t, err)
+
+	return filter
 }
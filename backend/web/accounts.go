@@ -14,7 +14,7 @@ import (
 func HandleAccountList(repo *repository.Repository) http.Handler {
 	tmpl := page.NewAccounts()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		accounts, err := repo.Account().List(100, 0)
+		accounts, err := repo.Account().List(r.Context(), 100, 0)
 		if err != nil {
 			util.InternalServerErrorResponse(w, r, err)
 			return
@@ -39,7 +39,7 @@ func HandleAccountDeleteForm(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		account, err := repo.Account().Read(accountID)
+		account, err := repo.Account().Read(r.Context(), accountID)
 		if err != nil {
 			util.ReadErrorResponse(w, r, err)
 			return
@@ -51,7 +51,7 @@ func HandleAccountDeleteForm(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		err = repo.Account().Delete(account)
+		err = repo.Account().Delete(r.Context(), account)
 		if err != nil {
 			util.DeleteErrorResponse(w, r, err)
 			return
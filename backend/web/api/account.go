@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Show which account the request is authenticated as (useful for checking API tokens).
+func HandleAccountRead() http.Handler {
+	type response struct {
+		ID       uuid.UUID `json:"id"`
+		Username string    `json:"username"`
+		IsAdmin  bool      `json:"isAdmin"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		jsonutil.Write(w, http.StatusOK, response{
+			ID:       account.ID(),
+			Username: account.Username(),
+			IsAdmin:  account.IsAdmin(),
+		})
+	})
+}
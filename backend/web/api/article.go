@@ -1,26 +1,191 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/theandrew168/bloggulus/backend/query"
 	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
 )
 
+type PaginationLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+}
+
+// Build links to the other pages of a paginated list while preserving the original query params.
+func newPaginationLinks(r *http.Request, page, size, count int) PaginationLinks {
+	// Prefer the original request URI since the API's path prefix (/api/v1) gets stripped.
+	path := r.URL.Path
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		path = u.Path
+	}
+
+	lastPage := (count + size - 1) / size
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	link := func(page int) string {
+		params := r.URL.Query()
+		params.Set("page", strconv.Itoa(page))
+		params.Set("size", strconv.Itoa(size))
+		return fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+
+	links := PaginationLinks{
+		Self:  link(page),
+		First: link(1),
+		Last:  link(lastPage),
+	}
+	if page > 1 {
+		links.Prev = link(min(page-1, lastPage))
+	}
+	if page < lastPage {
+		links.Next = link(page + 1)
+	}
+
+	return links
+}
+
+// Parse an optional, positive integer query param (using a default if absent).
+func readIntParam(params url.Values, key string, defaultValue int, v util.Validator) int {
+	value := params.Get(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		v.Add(key, "must be a positive integer")
+		return defaultValue
+	}
+
+	return n
+}
+
 func HandleArticleList(qry *query.Query) http.Handler {
 	type response struct {
 		Articles []query.Article `json:"articles"`
+		Count    int             `json:"count"`
+		Page     int             `json:"page"`
+		Size     int             `json:"size"`
+		Links    PaginationLinks `json:"links"`
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		articles, err := qry.ListRecentArticles(2, 0)
+		params := r.URL.Query()
+		v := util.NewValidator()
+
+		search := params.Get("q")
+		tag := params.Get("tag")
+
+		page := readIntParam(params, "page", 1, v)
+		size := readIntParam(params, "size", DefaultPageSize, v)
+		v.Check("size", fmt.Sprintf("must not be more than %d", MaxPageSize), size <= MaxPageSize)
+
+		var blogID uuid.UUID
+		if params.Get("blog") != "" {
+			var err error
+			blogID, err = uuid.Parse(params.Get("blog"))
+			v.Check("blog", "must be a valid blog ID", err == nil)
+		}
+
+		if !v.IsValid() {
+			FailedValidationResponse(w, r, v)
+			return
+		}
+
+		limit, offset := util.PageSizeToLimitOffset(page, size)
+
+		var count int
+		var articles []query.Article
+
+		// Use the simpler queries if only searching or filtering by tag (or not filtering at all).
+		var g errgroup.Group
+		switch {
+		case tag != "" && search == "" && blogID == uuid.Nil:
+			g.Go(func() error {
+				var err error
+				count, err = qry.CountTaggedArticles(r.Context(), tag)
+				return err
+			})
+			g.Go(func() error {
+				var err error
+				articles, err = qry.ListTaggedArticles(r.Context(), tag, limit, offset)
+				return err
+			})
+		case tag != "" || blogID != uuid.Nil:
+			filter := query.ArticleFilter{
+				Search: search,
+				Tag:    tag,
+				BlogID: blogID,
+			}
+			g.Go(func() error {
+				var err error
+				count, err = qry.CountFilteredArticles(r.Context(), filter)
+				return err
+			})
+			g.Go(func() error {
+				var err error
+				articles, err = qry.ListFilteredArticles(r.Context(), filter, limit, offset)
+				return err
+			})
+		case search != "":
+			g.Go(func() error {
+				var err error
+				count, err = qry.CountRelevantArticles(r.Context(), search)
+				return err
+			})
+			g.Go(func() error {
+				var err error
+				articles, err = qry.ListRelevantArticles(r.Context(), search, limit, offset)
+				return err
+			})
+		default:
+			g.Go(func() error {
+				var err error
+				count, err = qry.CountRecentArticles(r.Context())
+				return err
+			})
+			g.Go(func() error {
+				var err error
+				articles, err = qry.ListRecentArticles(r.Context(), limit, offset)
+				return err
+			})
+		}
+
+		err := g.Wait()
 		if err != nil {
-			http.Error(w, "Error fetching articles", http.StatusInternalServerError)
+			ListErrorResponse(w, r, err)
 			return
 		}
 
+		// Always respond with a list (instead of null) even if there are no articles.
+		if articles == nil {
+			articles = []query.Article{}
+		}
+
 		jsonutil.Write(w, http.StatusOK, response{
 			Articles: articles,
+			Count:    count,
+			Page:     page,
+			Size:     size,
+			Links:    newPaginationLinks(r, page, size, count),
 		})
 	})
 }
@@ -0,0 +1,158 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/web/api"
+)
+
+type articleListResponse struct {
+	Articles []query.Article     `json:"articles"`
+	Count    int                 `json:"count"`
+	Page     int                 `json:"page"`
+	Size     int                 `json:"size"`
+	Links    api.PaginationLinks `json:"links"`
+}
+
+func TestHandleArticleList(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/articles?blog="+blog.ID().String(), nil)
+
+	h := api.HandleArticleList(qry)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+
+	var resp articleListResponse
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, resp.Count, 1)
+	test.AssertEqual(t, resp.Page, 1)
+	test.AssertEqual(t, resp.Size, api.DefaultPageSize)
+	test.AssertEqual(t, len(resp.Articles), 1)
+	test.AssertEqual(t, resp.Articles[0].URL, post.URL())
+	test.AssertEqual(t, resp.Articles[0].BlogTitle, blog.Title())
+	test.AssertEqual(t, resp.Links.Prev, "")
+	test.AssertEqual(t, resp.Links.Next, "")
+}
+
+func TestHandleArticleListTag(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+
+	// Tag one of the posts with a unique tag.
+	tag := test.RandomString(32)
+	err := repo.PostTag().Replace(context.Background(), post, []string{tag})
+	test.AssertNilError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/articles?tag="+tag, nil)
+
+	h := api.HandleArticleList(qry)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+
+	var resp articleListResponse
+	err = json.NewDecoder(rr.Body).Decode(&resp)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, resp.Count, 1)
+	test.AssertEqual(t, len(resp.Articles), 1)
+	test.AssertEqual(t, resp.Articles[0].URL, post.URL())
+	test.AssertSliceContains(t, resp.Articles[0].Tags, tag)
+}
+
+func TestHandleArticleListPagination(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	blog := test.CreateBlog(t, repo)
+	test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/articles?page=2&size=2&blog="+blog.ID().String(), nil)
+
+	h := api.HandleArticleList(qry)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+
+	var resp articleListResponse
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	test.AssertNilError(t, err)
+
+	// The second page should only contain the last of the three posts.
+	test.AssertEqual(t, resp.Count, 3)
+	test.AssertEqual(t, len(resp.Articles), 1)
+	test.AssertStringContains(t, resp.Links.Prev, "page=1")
+	test.AssertStringContains(t, resp.Links.Last, "page=2")
+	test.AssertStringContains(t, resp.Links.Self, "blog="+blog.ID().String())
+	test.AssertEqual(t, resp.Links.Next, "")
+}
+
+func TestHandleArticleListInvalidParams(t *testing.T) {
+	t.Parallel()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/articles?page=0&size=1000&blog=foo", nil)
+
+	h := api.HandleArticleList(qry)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusUnprocessableEntity)
+
+	var resp struct {
+		Error map[string]string `json:"error"`
+	}
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	test.AssertNilError(t, err)
+
+	_, ok := resp.Error["page"]
+	test.AssertEqual(t, ok, true)
+	_, ok = resp.Error["size"]
+	test.AssertEqual(t, ok, true)
+	_, ok = resp.Error["blog"]
+	test.AssertEqual(t, ok, true)
+}
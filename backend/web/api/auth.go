@@ -7,6 +7,7 @@ import (
 
 	"github.com/theandrew168/bloggulus/backend/random"
 	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+	"github.com/theandrew168/bloggulus/backend/web/util"
 )
 
 func HandleOAuthSignin(conf *oauth2.Config) http.Handler {
@@ -28,3 +29,16 @@ func HandleOAuthSignin(conf *oauth2.Config) http.Handler {
 		})
 	})
 }
+
+// Like middleware.RequireAccount but responds with a JSON error instead of redirecting.
+func requireAccount(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Handle errors that arise from commands that act upon an account's blogs.
+func blogErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, command.ErrAccountNotFound),
+		errors.Is(err, command.ErrBlogNotFound),
+		errors.Is(err, postgres.ErrNotFound):
+		NotFoundResponse(w, r)
+	case errors.Is(err, command.ErrBlogAlreadyFollowed):
+		ConflictResponse(w, r, "this blog is already being followed")
+	case errors.Is(err, command.ErrBlogNotFollowed):
+		ConflictResponse(w, r, "this blog is not being followed")
+	case errors.Is(err, postgres.ErrConflict):
+		ConflictResponse(w, r, "this request conflicts with an existing resource")
+	default:
+		InternalServerErrorResponse(w, r, err)
+	}
+}
+
+// Check if a string is an absolute HTTP(S) URL.
+func isValidFeedURL(feedURL string) bool {
+	u, err := url.Parse(feedURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func HandleBlogList(qry *query.Query) http.Handler {
+	type response struct {
+		Blogs []query.BlogForAccount `json:"blogs"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		blogs, err := qry.ListBlogsForAccount(r.Context(), account)
+		if err != nil {
+			ListErrorResponse(w, r, err)
+			return
+		}
+
+		// Always respond with a list (instead of null) even if there are no blogs.
+		if blogs == nil {
+			blogs = []query.BlogForAccount{}
+		}
+
+		jsonutil.Write(w, http.StatusOK, response{
+			Blogs: blogs,
+		})
+	})
+}
+
+func HandleBlogCreate(cmd *command.Command) http.Handler {
+	type request struct {
+		FeedURL string `json:"feedURL"`
+	}
+	type response struct {
+		Blog query.BlogForAccount `json:"blog"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		var req request
+		err := jsonutil.Read(r.Body, &req)
+		if err != nil {
+			BadRequestResponse(w, r, err.Error())
+			return
+		}
+
+		v := util.NewValidator()
+		v.CheckRequired("feedURL", req.FeedURL)
+		v.Check("feedURL", "must be a valid HTTP(S) URL", req.FeedURL == "" || isValidFeedURL(req.FeedURL))
+		if !v.IsValid() {
+			FailedValidationResponse(w, r, v)
+			return
+		}
+
+		// Syncing a new blog means fetching its feed, so this can take a moment.
+		blog, err := cmd.AddBlog(r.Context(), account.ID(), req.FeedURL)
+		if err != nil {
+			switch {
+			case errors.Is(err, feed.ErrUnreachableFeed):
+				v.Add("feedURL", "feed could not be reached")
+				FailedValidationResponse(w, r, v)
+			case errors.Is(err, feed.ErrInvalidFeed):
+				v.Add("feedURL", "feed could not be parsed")
+				FailedValidationResponse(w, r, v)
+			default:
+				blogErrorResponse(w, r, err)
+			}
+			return
+		}
+
+		jsonutil.Write(w, http.StatusCreated, response{
+			Blog: query.BlogForAccount{
+				ID:          blog.ID(),
+				Title:       blog.Title(),
+				SiteURL:     blog.SiteURL(),
+				IsFollowing: true,
+			},
+		})
+	})
+}
+
+func HandleBlogFollow(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		blogID, err := uuid.Parse(r.PathValue("blogID"))
+		if err != nil {
+			NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.FollowBlog(r.Context(), account.ID(), blogID)
+		if err != nil {
+			blogErrorResponse(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func HandleBlogUnfollow(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		blogID, err := uuid.Parse(r.PathValue("blogID"))
+		if err != nil {
+			NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.UnfollowBlog(r.Context(), account.ID(), blogID)
+		if err != nil {
+			blogErrorResponse(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,151 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/feed"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/web/api"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+func TestHandleBlogCreate(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	feedBlog := feed.Blog{
+		Title:   test.RandomString(20),
+		SiteURL: test.RandomURL(20),
+		FeedURL: test.RandomURL(20),
+	}
+	atomFeed, err := feedMock.GenerateAtomFeed(feedBlog)
+	test.AssertNilError(t, err)
+
+	feeds := map[string]feed.FetchFeedResponse{
+		feedBlog.FeedURL: {Feed: atomFeed},
+	}
+	cmd := command.New(repo, feedMock.NewFeedFetcher(feeds), test.NewSyncService(t, repo, feeds))
+
+	account := test.CreateAccount(t, repo)
+	h := api.HandleBlogCreate(cmd)
+
+	body := `{"feedURL": "` + feedBlog.FeedURL + `"}`
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/blogs", strings.NewReader(body))
+	r = util.SetContextAccount(r, account)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusCreated)
+
+	var resp struct {
+		Blog struct {
+			Title       string `json:"title"`
+			IsFollowing bool   `json:"isFollowing"`
+		} `json:"blog"`
+	}
+	err = json.NewDecoder(rr.Body).Decode(&resp)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, resp.Blog.Title, feedBlog.Title)
+	test.AssertEqual(t, resp.Blog.IsFollowing, true)
+
+	// Adding the same blog again should be a conflict.
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "/blogs", strings.NewReader(body))
+	r = util.SetContextAccount(r, account)
+	h.ServeHTTP(w, r)
+
+	rr = w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusConflict)
+}
+
+func TestHandleBlogCreateInvalid(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	unreachableFeedURL := test.RandomURL(20)
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	h := api.HandleBlogCreate(cmd)
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`not json`, http.StatusBadRequest},
+		{`{}`, http.StatusUnprocessableEntity},
+		{`{"feedURL": "not a url"}`, http.StatusUnprocessableEntity},
+		{`{"feedURL": "` + unreachableFeedURL + `"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/blogs", strings.NewReader(tt.body))
+		r = util.SetContextAccount(r, account)
+		h.ServeHTTP(w, r)
+
+		rr := w.Result()
+		test.AssertEqual(t, rr.StatusCode, tt.code)
+	}
+}
+
+func TestHandleBlogFollowUnfollow(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+
+	serve := func(h http.Handler, blogID string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/blogs/"+blogID, nil)
+		r.SetPathValue("blogID", blogID)
+		r = util.SetContextAccount(r, account)
+		h.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+
+	follow := api.HandleBlogFollow(cmd)
+	unfollow := api.HandleBlogUnfollow(cmd)
+
+	test.AssertEqual(t, serve(follow, blog.ID().String()), http.StatusNoContent)
+	test.AssertEqual(t, serve(follow, blog.ID().String()), http.StatusConflict)
+	test.AssertEqual(t, serve(unfollow, blog.ID().String()), http.StatusNoContent)
+	test.AssertEqual(t, serve(unfollow, blog.ID().String()), http.StatusConflict)
+
+	// Unknown (or malformed) blog IDs should be a 404.
+	test.AssertEqual(t, serve(follow, test.NewBlog(t).ID().String()), http.StatusNotFound)
+	test.AssertEqual(t, serve(follow, "foo"), http.StatusNotFound)
+}
+
+func TestHandleBlogListUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	qry, closer := test.NewQuery(t)
+	defer closer()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/blogs", nil)
+
+	h := api.HandleBlogList(qry)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusUnauthorized)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+)
+
+// Let's Go Further - Chapter 3.4
+// Every API error is a JSON object with a single "error" field. Most of the time this
+// is a plain message but validation errors use an object (field -> message) instead.
+func errorResponse(w http.ResponseWriter, r *http.Request, status int, message any) {
+	type response struct {
+		Error any `json:"error"`
+	}
+
+	err := jsonutil.Write(w, status, response{Error: message})
+	if err != nil {
+		slog.Error("error writing error response",
+			"error", err.Error(),
+			"url", r.URL.String(),
+		)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Respond with a 400 Bad Request error.
+func BadRequestResponse(w http.ResponseWriter, r *http.Request, message string) {
+	errorResponse(w, r, http.StatusBadRequest, message)
+}
+
+// Respond with a 401 Unauthorized error.
+func UnauthorizedResponse(w http.ResponseWriter, r *http.Request) {
+	message := "you must be authenticated to access this resource"
+	errorResponse(w, r, http.StatusUnauthorized, message)
+}
+
+// Respond with a 404 Not Found error.
+func NotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	message := "the requested resource could not be found"
+	errorResponse(w, r, http.StatusNotFound, message)
+}
+
+// Respond with a 409 Conflict error.
+func ConflictResponse(w http.ResponseWriter, r *http.Request, message string) {
+	errorResponse(w, r, http.StatusConflict, message)
+}
+
+// Respond with a 422 Unprocessable Entity error (including which fields are invalid).
+func FailedValidationResponse(w http.ResponseWriter, r *http.Request, errors map[string]string) {
+	errorResponse(w, r, http.StatusUnprocessableEntity, errors)
+}
+
+// Respond with a 500 Internal Server Error (and log the actual error).
+func InternalServerErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	slog.Error("internal server error",
+		"error", err.Error(),
+		"url", r.URL.String(),
+	)
+
+	message := "the server encountered a problem and could not process your request"
+	errorResponse(w, r, http.StatusInternalServerError, message)
+}
+
+// Handle errors that arise from reading a single row from the database.
+func ReadErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		NotFoundResponse(w, r)
+	default:
+		InternalServerErrorResponse(w, r, err)
+	}
+}
+
+// Handle errors that arise from reading many (zero or more) rows from the database.
+func ListErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	InternalServerErrorResponse(w, r, err)
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Handle errors that arise from commands that act upon an account's content filters.
+func filterErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, command.ErrAccountNotFound),
+		errors.Is(err, command.ErrContentFilterNotFound):
+		NotFoundResponse(w, r)
+	case errors.Is(err, command.ErrContentFilterConflict):
+		ConflictResponse(w, r, "this filter already exists")
+	default:
+		InternalServerErrorResponse(w, r, err)
+	}
+}
+
+func HandleFilterList(qry *query.Query) http.Handler {
+	type response struct {
+		Filters []query.ContentFilter `json:"filters"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		filters, err := qry.ListContentFiltersByAccount(r.Context(), account)
+		if err != nil {
+			ListErrorResponse(w, r, err)
+			return
+		}
+
+		// Always respond with a list (instead of null) even if there are no filters.
+		if filters == nil {
+			filters = []query.ContentFilter{}
+		}
+
+		jsonutil.Write(w, http.StatusOK, response{
+			Filters: filters,
+		})
+	})
+}
+
+func HandleFilterCreate(cmd *command.Command) http.Handler {
+	type request struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	}
+	type response struct {
+		Filter query.ContentFilter `json:"filter"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		var req request
+		err := jsonutil.Read(r.Body, &req)
+		if err != nil {
+			BadRequestResponse(w, r, err.Error())
+			return
+		}
+
+		kind := model.ContentFilterKind(req.Kind)
+		value := kind.NormalizeValue(req.Value)
+
+		v := util.NewValidator()
+		v.Check("kind", "must be one of: keyword, tag, title, blog", kind.IsValid())
+		v.CheckRequired("value", value)
+		v.Check("value", fmt.Sprintf("must not be more than %d characters", model.MaxContentFilterValueLength), utf8.RuneCountInString(value) <= model.MaxContentFilterValueLength)
+		v.Check("value", "must be valid for this kind of filter", value == "" || !kind.IsValid() || kind.IsValidValue(value))
+		if !v.IsValid() {
+			FailedValidationResponse(w, r, v)
+			return
+		}
+
+		filter, err := cmd.CreateContentFilter(r.Context(), account.ID(), kind, value)
+		if err != nil {
+			if errors.Is(err, command.ErrBlogNotFound) {
+				v.Add("value", "blog does not exist")
+				FailedValidationResponse(w, r, v)
+				return
+			}
+			if errors.Is(err, command.ErrContentFilterInvalidPattern) {
+				v.Add("value", "must be valid for this kind of filter")
+				FailedValidationResponse(w, r, v)
+				return
+			}
+
+			filterErrorResponse(w, r, err)
+			return
+		}
+
+		jsonutil.Write(w, http.StatusCreated, response{
+			Filter: query.ContentFilter{
+				ID:    filter.ID(),
+				Kind:  string(filter.Kind()),
+				Value: filter.Value(),
+			},
+		})
+	})
+}
+
+func HandleFilterDelete(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		filterID, err := uuid.Parse(r.PathValue("filterID"))
+		if err != nil {
+			NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.DeleteContentFilter(r.Context(), account.ID(), filterID)
+		if err != nil {
+			filterErrorResponse(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/web/api"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+func TestHandleFilterCreateListDelete(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+
+	// Create a title filter.
+	body := `{"kind": "title", "value": "^sponsored"}`
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/filters", strings.NewReader(body))
+	r = util.SetContextAccount(r, account)
+	api.HandleFilterCreate(cmd).ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusCreated)
+
+	var created struct {
+		Filter query.ContentFilter `json:"filter"`
+	}
+	err := json.NewDecoder(rr.Body).Decode(&created)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, created.Filter.Kind, "title")
+
+	// It should show up in the account's list of filters.
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "/filters", nil)
+	r = util.SetContextAccount(r, account)
+	api.HandleFilterList(qry).ServeHTTP(w, r)
+
+	rr = w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+
+	var listed struct {
+		Filters []query.ContentFilter `json:"filters"`
+	}
+	err = json.NewDecoder(rr.Body).Decode(&listed)
+	test.AssertNilError(t, err)
+	test.AssertEqual(t, len(listed.Filters), 1)
+	test.AssertEqual(t, listed.Filters[0].ID, created.Filter.ID)
+
+	// Deleting it should only work once.
+	mux := http.NewServeMux()
+	mux.Handle("DELETE /filters/{filterID}", api.HandleFilterDelete(cmd))
+	for _, code := range []int{http.StatusNoContent, http.StatusNotFound} {
+		w = httptest.NewRecorder()
+		r = httptest.NewRequest("DELETE", "/filters/"+created.Filter.ID.String(), nil)
+		r = util.SetContextAccount(r, account)
+		mux.ServeHTTP(w, r)
+
+		rr = w.Result()
+		test.AssertEqual(t, rr.StatusCode, code)
+	}
+}
+
+func TestHandleFilterCreateInvalid(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	h := api.HandleFilterCreate(cmd)
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`not json`, http.StatusBadRequest},
+		{`{}`, http.StatusUnprocessableEntity},
+		{`{"kind": "author", "value": "foo"}`, http.StatusUnprocessableEntity},
+		{`{"kind": "keyword", "value": "  "}`, http.StatusUnprocessableEntity},
+		{`{"kind": "title", "value": "(unclosed"}`, http.StatusUnprocessableEntity},
+		{`{"kind": "title", "value": "(?i)foo"}`, http.StatusUnprocessableEntity},
+		{`{"kind": "blog", "value": "not a uuid"}`, http.StatusUnprocessableEntity},
+		{`{"kind": "blog", "value": "` + uuid.New().String() + `"}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/filters", strings.NewReader(tt.body))
+		r = util.SetContextAccount(r, account)
+		h.ServeHTTP(w, r)
+
+		rr := w.Result()
+		test.AssertEqual(t, rr.StatusCode, tt.code)
+	}
+}
@@ -33,8 +33,22 @@ func Handler(
 	}
 
 	mux := http.NewServeMux()
+	mux.Handle("GET /account", requireAccount(HandleAccountRead()))
 	mux.Handle("GET /articles", HandleArticleList(qry))
+	mux.Handle("GET /blogs", requireAccount(HandleBlogList(qry)))
+	mux.Handle("POST /blogs", requireAccount(HandleBlogCreate(cmd)))
+	mux.Handle("POST /blogs/{blogID}/follow", requireAccount(HandleBlogFollow(cmd)))
+	mux.Handle("POST /blogs/{blogID}/unfollow", requireAccount(HandleBlogUnfollow(cmd)))
+	mux.Handle("GET /filters", requireAccount(HandleFilterList(qry)))
+	mux.Handle("POST /filters", requireAccount(HandleFilterCreate(cmd)))
+	mux.Handle("DELETE /filters/{filterID}", requireAccount(HandleFilterDelete(cmd)))
+	mux.Handle("POST /posts/{postID}/save", requireAccount(HandlePostSave(cmd)))
+	mux.Handle("POST /posts/{postID}/unsave", requireAccount(HandlePostUnsave(cmd)))
+	mux.Handle("GET /saved", requireAccount(HandleSavedList(qry)))
 	mux.Handle("GET /signin/github", HandleOAuthSignin(&githubConf))
 	mux.Handle("GET /signin/google", HandleOAuthSignin(&googleConf))
+
+	// Requests that don't match any of the above handlers get a (JSON) 404.
+	mux.HandleFunc("/", NotFoundResponse)
 	return mux
 }
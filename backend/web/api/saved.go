@@ -0,0 +1,144 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Handle errors that arise from commands that act upon an account's saved posts.
+func savedErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, command.ErrAccountNotFound),
+		errors.Is(err, command.ErrPostNotFound):
+		NotFoundResponse(w, r)
+	case errors.Is(err, command.ErrPostAlreadySaved):
+		ConflictResponse(w, r, "this post is already saved")
+	case errors.Is(err, command.ErrPostNotSaved):
+		ConflictResponse(w, r, "this post is not saved")
+	default:
+		InternalServerErrorResponse(w, r, err)
+	}
+}
+
+func HandleSavedList(qry *query.Query) http.Handler {
+	type response struct {
+		Articles []query.Article `json:"articles"`
+		Count    int             `json:"count"`
+		Page     int             `json:"page"`
+		Size     int             `json:"size"`
+		Links    PaginationLinks `json:"links"`
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		params := r.URL.Query()
+		v := util.NewValidator()
+
+		page := readIntParam(params, "page", 1, v)
+		size := readIntParam(params, "size", DefaultPageSize, v)
+		v.Check("size", fmt.Sprintf("must not be more than %d", MaxPageSize), size <= MaxPageSize)
+
+		if !v.IsValid() {
+			FailedValidationResponse(w, r, v)
+			return
+		}
+
+		limit, offset := util.PageSizeToLimitOffset(page, size)
+
+		var count int
+		var articles []query.Article
+
+		var g errgroup.Group
+		g.Go(func() error {
+			var err error
+			count, err = qry.CountSavedArticlesByAccount(r.Context(), account)
+			return err
+		})
+		g.Go(func() error {
+			var err error
+			articles, err = qry.ListSavedArticlesByAccount(r.Context(), account, limit, offset)
+			return err
+		})
+
+		err := g.Wait()
+		if err != nil {
+			ListErrorResponse(w, r, err)
+			return
+		}
+
+		// Always respond with a list (instead of null) even if there are no articles.
+		if articles == nil {
+			articles = []query.Article{}
+		}
+
+		jsonutil.Write(w, http.StatusOK, response{
+			Articles: articles,
+			Count:    count,
+			Page:     page,
+			Size:     size,
+			Links:    newPaginationLinks(r, page, size, count),
+		})
+	})
+}
+
+func HandlePostSave(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		postID, err := uuid.Parse(r.PathValue("postID"))
+		if err != nil {
+			NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.SavePost(r.Context(), account.ID(), postID)
+		if err != nil {
+			savedErrorResponse(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func HandlePostUnsave(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, ok := util.GetContextAccount(r)
+		if !ok {
+			UnauthorizedResponse(w, r)
+			return
+		}
+
+		postID, err := uuid.Parse(r.PathValue("postID"))
+		if err != nil {
+			NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.UnsavePost(r.Context(), account.ID(), postID)
+		if err != nil {
+			savedErrorResponse(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
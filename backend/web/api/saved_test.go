@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	feedMock "github.com/theandrew168/bloggulus/backend/feed/mock"
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/web/api"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+func TestHandlePostSaveUnsave(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	cmd := command.New(repo, feedMock.NewFeedFetcher(nil), test.NewSyncService(t, repo, nil))
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+
+	serve := func(h http.Handler, postID string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/posts/"+postID, nil)
+		r.SetPathValue("postID", postID)
+		r = util.SetContextAccount(r, account)
+		h.ServeHTTP(w, r)
+		return w.Result().StatusCode
+	}
+
+	save := api.HandlePostSave(cmd)
+	unsave := api.HandlePostUnsave(cmd)
+
+	test.AssertEqual(t, serve(save, post.ID().String()), http.StatusNoContent)
+	test.AssertEqual(t, serve(save, post.ID().String()), http.StatusConflict)
+	test.AssertEqual(t, serve(unsave, post.ID().String()), http.StatusNoContent)
+	test.AssertEqual(t, serve(unsave, post.ID().String()), http.StatusConflict)
+
+	// Unknown (or malformed) post IDs should be a 404.
+	test.AssertEqual(t, serve(save, test.NewPost(t, blog).ID().String()), http.StatusNotFound)
+	test.AssertEqual(t, serve(save, "foo"), http.StatusNotFound)
+}
+
+func TestHandleSavedList(t *testing.T) {
+	t.Parallel()
+
+	repo, repoCloser := test.NewRepository(t)
+	defer repoCloser()
+
+	qry, qryCloser := test.NewQuery(t)
+	defer qryCloser()
+
+	account := test.CreateAccount(t, repo)
+	blog := test.CreateBlog(t, repo)
+	post := test.CreatePost(t, repo, blog)
+	test.CreatePost(t, repo, blog)
+	test.CreateAccountPost(t, repo, account, post)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/saved", nil)
+	r = util.SetContextAccount(r, account)
+
+	h := api.HandleSavedList(qry)
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+
+	var resp struct {
+		Articles []struct {
+			Title   string `json:"title"`
+			IsSaved bool   `json:"isSaved"`
+		} `json:"articles"`
+		Count int `json:"count"`
+	}
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, resp.Count, 1)
+	test.AssertEqual(t, len(resp.Articles), 1)
+	test.AssertEqual(t, resp.Articles[0].Title, post.Title())
+	test.AssertEqual(t, resp.Articles[0].IsSaved, true)
+}
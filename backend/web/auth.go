@@ -1,7 +1,6 @@
 package web
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -156,14 +155,14 @@ func HandleOAuthCallback(
 		}
 
 		code := r.URL.Query().Get("code")
-		token, err := conf.Exchange(context.Background(), code)
+		token, err := conf.Exchange(r.Context(), code)
 		if err != nil {
 			slog.Error("failed to exchange code for access token", "error", err.Error())
 			util.BadRequestResponse(w, r)
 			return
 		}
 
-		client := conf.Client(context.Background(), token)
+		client := conf.Client(r.Context(), token)
 		userID, err := fetchUserID(client)
 		if err != nil {
 			slog.Error("failed to fetch user ID", "error", err.Error())
@@ -172,7 +171,7 @@ func HandleOAuthCallback(
 		}
 
 		username := util.HashUserID(userID, secretKey)
-		sessionID, err := cmd.SignIn(username)
+		sessionID, err := cmd.SignIn(r.Context(), username)
 		if err != nil {
 			util.InternalServerErrorResponse(w, r, err)
 			return
@@ -207,7 +206,7 @@ func HandleDebugSignIn(secretKey string, cmd *command.Command) http.Handler {
 		userID = "debug_" + userID
 		username := util.HashUserID(userID, secretKey)
 
-		sessionID, err := cmd.SignIn(username)
+		sessionID, err := cmd.SignIn(r.Context(), username)
 		if err != nil {
 			util.InternalServerErrorResponse(w, r, err)
 			return
@@ -243,7 +242,7 @@ func HandleSignOutForm(cmd *command.Command) http.Handler {
 		cookie := util.NewExpiredCookie(util.SessionCookieName)
 		http.SetCookie(w, &cookie)
 
-		err = cmd.SignOut(sessionID.Value)
+		err = cmd.SignOut(r.Context(), sessionID.Value)
 		if err != nil {
 			switch {
 			case errors.Is(err, command.ErrSessionNotFound):
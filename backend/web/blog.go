@@ -2,12 +2,17 @@ package web
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 
 	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/model"
 	"github.com/theandrew168/bloggulus/backend/repository"
 	"github.com/theandrew168/bloggulus/backend/web/page"
 	"github.com/theandrew168/bloggulus/backend/web/util"
@@ -22,13 +27,13 @@ func HandleBlogRead(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		blog, err := repo.Blog().Read(blogID)
+		blog, err := repo.Blog().Read(r.Context(), blogID)
 		if err != nil {
 			util.ReadErrorResponse(w, r, err)
 			return
 		}
 
-		posts, err := repo.Post().ListByBlog(blog)
+		posts, err := repo.Post().ListByBlog(r.Context(), blog)
 		if err != nil {
 			util.InternalServerErrorResponse(w, r, err)
 			return
@@ -46,6 +51,118 @@ func HandleBlogRead(repo *repository.Repository) http.Handler {
 	})
 }
 
+// Toggle whether or not the full content of a blog's posts is fetched from their pages
+// (useful for blogs whose feeds only include summaries or truncated content).
+func HandleBlogFullContentForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blogID, err := uuid.Parse(r.PathValue("blogID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		fetchFullContent, err := strconv.ParseBool(r.PostFormValue("fetchFullContent"))
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		blog, err := repo.Blog().Read(r.Context(), blogID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		err = blog.SetFetchFullContent(fetchFullContent)
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		err = repo.Blog().Update(r.Context(), blog)
+		if err != nil {
+			util.UpdateErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("blog full content updated",
+			"blog_id", blog.ID(),
+			"blog_title", blog.Title(),
+			"blog_fetch_full_content", blog.FetchFullContent(),
+		)
+
+		// Redirect back to the blog page.
+		http.Redirect(w, r, fmt.Sprintf("/blogs/%s", blog.ID()), http.StatusSeeOther)
+	})
+}
+
+// List the blogs whose most recent syncs have failed (broken or unreachable feeds).
+func HandleFailingBlogList(repo *repository.Repository) http.Handler {
+	tmpl := page.NewFailingBlogs()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blogs, err := repo.Blog().ListFailing(r.Context())
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.FailingBlogsData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Blogs: blogs,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+// Sync a blog right away (ignoring its schedule and any backoff from previous failures).
+func HandleBlogSyncForm(repo *repository.Repository, syncService *job.SyncService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blogID, err := uuid.Parse(r.PathValue("blogID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		blog, err := repo.Blog().Read(r.Context(), blogID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		message := "This blog was synced successfully!"
+		blog, err = syncService.SyncBlog(r.Context(), blog.FeedURL())
+		if err != nil {
+			// Problems with the feed itself are recorded on the blog (anything else is unexpected).
+			syncErr, _ := job.ClassifySyncError(err)
+			if syncErr == model.SyncErrorNone {
+				util.InternalServerErrorResponse(w, r, err)
+				return
+			}
+
+			slog.Info("blog sync retry failed",
+				"blog_id", blogID,
+				"error", err.Error(),
+			)
+
+			message = fmt.Sprintf("This blog failed to sync: %s", syncErr)
+		} else {
+			slog.Info("blog sync retried",
+				"blog_id", blog.ID(),
+				"blog_title", blog.Title(),
+			)
+		}
+
+		cookie := util.NewSessionCookie(util.ToastCookieName, message)
+		http.SetCookie(w, &cookie)
+
+		// Redirect back to wherever the sync was requested from.
+		util.RedirectBack(w, r, "/blogs/failing")
+	})
+}
+
 func HandleBlogDeleteForm(cmd *command.Command) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		blogID, err := uuid.Parse(r.PathValue("blogID"))
@@ -54,7 +171,7 @@ func HandleBlogDeleteForm(cmd *command.Command) http.Handler {
 			return
 		}
 
-		err = cmd.DeleteBlog(blogID)
+		err = cmd.DeleteBlog(r.Context(), blogID)
 		if err != nil {
 			if errors.Is(err, command.ErrBlogNotFound) {
 				util.NotFoundResponse(w, r)
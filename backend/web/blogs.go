@@ -1,23 +1,57 @@
 package web
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"io"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/theandrew168/bloggulus/backend/command"
 	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/opml"
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/query"
 	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
 	"github.com/theandrew168/bloggulus/backend/web/page"
 	"github.com/theandrew168/bloggulus/backend/web/util"
 )
 
-func HandleBlogList(qry *query.Query) http.Handler {
+// Gather everything needed to render the blogs page for an account.
+func newBlogsData(r *http.Request, w http.ResponseWriter, repo *repository.Repository, qry *query.Query, account *model.Account) (page.BlogsData, error) {
+	blogs, err := qry.ListBlogsForAccount(r.Context(), account)
+	if err != nil {
+		return page.BlogsData{}, err
+	}
+
+	_, err = repo.FeedToken().ReadByAccount(r.Context(), account)
+	if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+		return page.BlogsData{}, err
+	}
+
+	data := page.BlogsData{
+		BaseData: util.GetTemplateBaseData(r, w),
+
+		HasFeedToken: err == nil,
+	}
+	for _, blog := range blogs {
+		data.Blogs = append(data.Blogs, page.BlogsBlogData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			BlogForAccount: blog,
+		})
+	}
+
+	return data, nil
+}
+
+func HandleBlogList(repo *repository.Repository, qry *query.Query) http.Handler {
 	tmpl := page.NewBlogs()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		account, isLoggedIn := util.GetContextAccount(r)
@@ -26,30 +60,20 @@ func HandleBlogList(qry *query.Query) http.Handler {
 			return
 		}
 
-		blogs, err := qry.ListBlogsForAccount(account)
+		data, err := newBlogsData(r, w, repo, qry, account)
 		if err != nil {
 			util.ListErrorResponse(w, r, err)
 			return
 		}
 
-		data := page.BlogsData{
-			BaseData: util.GetTemplateBaseData(r, w),
-		}
-		for _, blog := range blogs {
-			data.Blogs = append(data.Blogs, page.BlogsBlogData{
-				BaseData: util.GetTemplateBaseData(r, w),
-
-				BlogForAccount: blog,
-			})
-		}
-
 		util.Render(w, r, 200, func(w io.Writer) error {
 			return tmpl.Render(w, data)
 		})
 	})
 }
 
-func HandleBlogCreateForm(repo *repository.Repository, syncService *job.SyncService) http.Handler {
+func HandleBlogCreateForm(repo *repository.Repository, cmd *command.Command, syncService *job.SyncService) http.Handler {
+	tmpl := page.NewDiscover()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		account, isLoggedIn := util.GetContextAccount(r)
 		if !isLoggedIn {
@@ -66,10 +90,51 @@ func HandleBlogCreateForm(repo *repository.Repository, syncService *job.SyncServ
 		feedURL := r.PostForm.Get("feedURL")
 
 		// Check if the blog already exists.
-		blog, err := repo.Blog().ReadByFeedURL(feedURL)
+		blog, err := repo.Blog().ReadByFeedURL(r.Context(), feedURL)
+		if errors.Is(err, postgres.ErrNotFound) {
+			// If not, the URL might be a website instead of a feed so look for its feed(s).
+			feeds, err := cmd.DiscoverFeeds(r.Context(), feedURL)
+			if err != nil {
+				slog.Info("no feeds discovered",
+					"error", err.Error(),
+					"url", feedURL,
+				)
+
+				cookie := util.NewSessionCookie(util.ToastCookieName, "No feeds were found at this URL. Please check it and try again.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/blogs", http.StatusSeeOther)
+				return
+			}
+
+			// If multiple feeds were found, let the user choose which one to follow.
+			if len(feeds) > 1 {
+				data := page.DiscoverData{
+					BaseData: util.GetTemplateBaseData(r, w),
+
+					PageURL: feedURL,
+				}
+				for _, feed := range feeds {
+					data.Feeds = append(data.Feeds, page.DiscoverFeedData{
+						URL:   feed.URL,
+						Title: feed.Title,
+					})
+				}
+
+				util.Render(w, r, 200, func(w io.Writer) error {
+					return tmpl.Render(w, data)
+				})
+				return
+			}
+
+			// Otherwise, check if the discovered feed's blog already exists.
+			feedURL = feeds[0].URL
+			blog, err = repo.Blog().ReadByFeedURL(r.Context(), feedURL)
+		}
+
 		if err == nil {
 			// If it does, follow it for the current user.
-			err = repo.AccountBlog().Create(account, blog)
+			err = repo.AccountBlog().Create(r.Context(), account, blog)
 			if err != nil {
 				if !errors.Is(err, postgres.ErrConflict) {
 					slog.Error("error following blog",
@@ -104,10 +169,13 @@ func HandleBlogCreateForm(repo *repository.Repository, syncService *job.SyncServ
 			return
 		}
 
-		// Use the SyncService to add the new blog.
+		// Use the SyncService to add the new blog. This outlives the request
+		// so it can't use the request's context (which is done once we respond).
 		// TODO: Make this respect graceful shutdowns.
 		go func() {
-			blog, err := syncService.SyncBlog(feedURL)
+			ctx := context.Background()
+
+			blog, err := syncService.SyncBlog(ctx, feedURL)
 			if err != nil {
 				slog.Error("error adding blog",
 					"error", err.Error(),
@@ -123,7 +191,7 @@ func HandleBlogCreateForm(repo *repository.Repository, syncService *job.SyncServ
 				"blog_title", blog.Title(),
 			)
 
-			err = repo.AccountBlog().Create(account, blog)
+			err = repo.AccountBlog().Create(ctx, account, blog)
 			if err != nil {
 				if !errors.Is(err, postgres.ErrConflict) {
 					slog.Error("error following blog",
@@ -153,6 +221,113 @@ func HandleBlogCreateForm(repo *repository.Repository, syncService *job.SyncServ
 	})
 }
 
+// Limit the size of uploaded OPML files to 1MB (the request body as a whole is
+// capped by middleware.MaxMultipartRequestBodySize before the form is parsed).
+const MaxImportFileSize = 1 * 1024 * 1024
+
+// How long an import is allowed to run before the remaining feeds are reported as
+// timed out (the user can always upload the same file again to finish up).
+const ImportTimeout = 60 * time.Second
+
+func HandleBlogImportForm(cmd *command.Command) http.Handler {
+	tmpl := page.NewImport()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := r.ParseMultipartForm(MaxImportFileSize)
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		file, header, err := r.FormFile("opml")
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+		defer file.Close()
+
+		if header.Size > MaxImportFileSize {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		feeds, err := opml.Parse(file)
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		var feedURLs []string
+		for _, feed := range feeds {
+			feedURLs = append(feedURLs, feed.FeedURL)
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), ImportTimeout)
+		defer cancel()
+
+		results, err := cmd.ImportFeeds(ctx, account.ID(), feedURLs)
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.ImportData{
+			BaseData: util.GetTemplateBaseData(r, w),
+		}
+		for _, result := range results {
+			data.Results = append(data.Results, page.ImportResultData{
+				FeedURL: result.FeedURL,
+				Status:  string(result.Status),
+			})
+		}
+
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleBlogExport(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		blogs, err := repo.Blog().ListByAccount(r.Context(), account)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		var feeds []opml.Feed
+		for _, blog := range blogs {
+			feeds = append(feeds, opml.Feed{
+				FeedURL: blog.FeedURL(),
+				SiteURL: blog.SiteURL(),
+				Title:   blog.Title(),
+			})
+		}
+
+		doc, err := opml.Generate("Bloggulus Subscriptions", feeds, timeutil.Now())
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="bloggulus.opml"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(doc))
+	})
+}
+
 func HandleBlogFollowForm(repo *repository.Repository) http.Handler {
 	tmpl := page.NewBlogs()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -174,14 +349,14 @@ func HandleBlogFollowForm(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		blog, err := repo.Blog().Read(blogID)
+		blog, err := repo.Blog().Read(r.Context(), blogID)
 		if err != nil {
 			util.ReadErrorResponse(w, r, err)
 			return
 		}
 
 		// Follow the blog and check for ErrConflict (already following).
-		err = repo.AccountBlog().Create(account, blog)
+		err = repo.AccountBlog().Create(r.Context(), account, blog)
 		if err != nil {
 			switch {
 			case errors.Is(err, postgres.ErrConflict):
@@ -242,14 +417,14 @@ func HandleBlogUnfollowForm(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		blog, err := repo.Blog().Read(blogID)
+		blog, err := repo.Blog().Read(r.Context(), blogID)
 		if err != nil {
 			util.ReadErrorResponse(w, r, err)
 			return
 		}
 
 		// Unfollow the blog and check for ErrNotFound (already not following).
-		err = repo.AccountBlog().Delete(account, blog)
+		err = repo.AccountBlog().Delete(r.Context(), account, blog)
 		if err != nil {
 			switch {
 			case errors.Is(err, postgres.ErrNotFound):
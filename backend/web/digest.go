@@ -0,0 +1,217 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/mail"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Limit the length of digest email addresses.
+const MaxDigestEmailLength = 254
+
+func HandleDigestRead(repo *repository.Repository) http.Handler {
+	tmpl := page.NewDigest()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		// Not having a digest yet is perfectly fine (the form will be empty).
+		digest, err := repo.Digest().ReadByAccount(r.Context(), account)
+		if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.DigestData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Digest: digest,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+// Subscribe to a digest or update the settings of an existing one.
+func HandleDigestUpdateForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		email := r.PostForm.Get("email")
+		frequency := model.DigestFrequency(r.PostForm.Get("frequency"))
+
+		_, parseErr := mail.ParseAddress(email)
+
+		v := util.NewValidator()
+		v.CheckRequired("email", email)
+		v.CheckMaxCharacters("email", email, MaxDigestEmailLength)
+		v.Check("email", "Please provide a valid email address", parseErr == nil)
+		v.Check("frequency", "Please choose a daily or weekly digest", frequency.IsValid())
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a valid email address and frequency for the digest.")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/digest", http.StatusSeeOther)
+			return
+		}
+
+		digest, err := repo.Digest().ReadByAccount(r.Context(), account)
+		if err != nil {
+			if !errors.Is(err, postgres.ErrNotFound) {
+				util.InternalServerErrorResponse(w, r, err)
+				return
+			}
+
+			// No digest exists yet so create a new one.
+			digest, err = model.NewDigest(account, email, frequency)
+			if err != nil {
+				util.InternalServerErrorResponse(w, r, err)
+				return
+			}
+
+			err = repo.Digest().Create(r.Context(), digest)
+			if err != nil {
+				util.CreateErrorResponse(w, r, err)
+				return
+			}
+		} else {
+			err = digest.SetEmail(email)
+			if err != nil {
+				util.InternalServerErrorResponse(w, r, err)
+				return
+			}
+
+			err = digest.SetFrequency(frequency)
+			if err != nil {
+				util.InternalServerErrorResponse(w, r, err)
+				return
+			}
+
+			// Saving an unverified digest again sends out a fresh confirmation link.
+			if !digest.IsVerified() {
+				digest.ResetVerification()
+			}
+
+			err = repo.Digest().Update(r.Context(), digest)
+			if err != nil {
+				util.UpdateErrorResponse(w, r, err)
+				return
+			}
+		}
+
+		slog.Info("digest updated",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"digest_id", digest.ID(),
+			"digest_frequency", digest.Frequency(),
+		)
+
+		message := "Your digest settings have been saved."
+		if !digest.IsVerified() {
+			message = "Your digest settings have been saved. Check your inbox for a link to confirm your email address."
+		}
+
+		cookie := util.NewSessionCookie(util.ToastCookieName, message)
+		http.SetCookie(w, &cookie)
+
+		http.Redirect(w, r, "/digest", http.StatusSeeOther)
+	})
+}
+
+// Confirm a digest's email address by following the link that was sent to it.
+// The token alone is proof of ownership so this doesn't require being logged in.
+func HandleDigestVerify(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		digest, err := repo.Digest().ReadByVerificationToken(r.Context(), token)
+		if err != nil && !errors.Is(err, postgres.ErrNotFound) {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		now := timeutil.Now()
+		if err != nil || digest.IsVerificationExpired(now) {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "This confirmation link is invalid or has expired. Save your digest settings again to get a new one.")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/digest", http.StatusSeeOther)
+			return
+		}
+
+		digest.Verify(now)
+		err = repo.Digest().Update(r.Context(), digest)
+		if err != nil {
+			util.UpdateErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("digest verified",
+			"account_id", digest.AccountID(),
+			"digest_id", digest.ID(),
+		)
+
+		cookie := util.NewSessionCookie(util.ToastCookieName, "Your email address has been confirmed.")
+		http.SetCookie(w, &cookie)
+
+		http.Redirect(w, r, "/digest", http.StatusSeeOther)
+	})
+}
+
+func HandleDigestDeleteForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		digest, err := repo.Digest().ReadByAccount(r.Context(), account)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		err = repo.Digest().Delete(r.Context(), digest)
+		if err != nil {
+			util.DeleteErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("digest deleted",
+			"account_id", account.ID(),
+			"account_username", account.Username(),
+			"digest_id", digest.ID(),
+		)
+
+		// Redirect back to the digest page.
+		http.Redirect(w, r, "/digest", http.StatusSeeOther)
+	})
+}
@@ -0,0 +1,177 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/syndication"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// How many articles to include in an account's personal feed.
+const FeedArticleLimit = 50
+
+type FeedFormat string
+
+const (
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatRSS  FeedFormat = "rss"
+)
+
+// Build the (relative) path to an account's personal feed in a given format.
+func FeedPath(accountID uuid.UUID, format FeedFormat, token string) string {
+	return fmt.Sprintf("/feeds/%s/%s.xml?token=%s", accountID, format, url.QueryEscape(token))
+}
+
+func HandleAccountFeed(siteURL string, repo *repository.Repository, qry *query.Query, format FeedFormat) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accountID, err := uuid.Parse(r.PathValue("accountID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		// Respond with a 404 (instead of a 403) to avoid leaking which accounts exist.
+		token := r.URL.Query().Get("token")
+		feedToken, err := repo.FeedToken().ReadByToken(r.Context(), token)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		if feedToken.AccountID() != accountID {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		account, err := repo.Account().Read(r.Context(), accountID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		articles, err := qry.ListRecentArticlesByAccount(r.Context(), account, false, FeedArticleLimit, 0)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		feed := syndication.Feed{
+			ID:      "urn:uuid:" + account.ID().String(),
+			Title:   "Bloggulus - " + account.Username(),
+			SiteURL: siteURL,
+			FeedURL: siteURL + FeedPath(account.ID(), format, token),
+		}
+
+		// Articles are sorted by recency so the first one is the newest. An empty feed
+		// (nothing followed yet or everything filtered out) has been around since the
+		// account was created.
+		if len(articles) > 0 {
+			feed.UpdatedAt = articles[0].PublishedAt
+		} else {
+			feed.UpdatedAt = account.CreatedAt()
+		}
+		for _, article := range articles {
+			feed.Entries = append(feed.Entries, syndication.Entry{
+				Title:       article.Title,
+				URL:         article.URL,
+				BlogTitle:   article.BlogTitle,
+				BlogURL:     article.BlogURL,
+				PublishedAt: article.PublishedAt,
+				Tags:        article.Tags,
+			})
+		}
+
+		var doc string
+		var contentType string
+		switch format {
+		case FeedFormatRSS:
+			doc, err = syndication.GenerateRSS(feed)
+			contentType = "application/rss+xml; charset=utf-8"
+		default:
+			doc, err = syndication.GenerateAtom(feed)
+			contentType = "application/atom+xml; charset=utf-8"
+		}
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// The ETag is a hash of the whole document so that it changes whenever the
+		// feed does: new posts, but also follows, unfollows, and content filters. No
+		// Last-Modified is sent because the newest post's date can't capture those.
+		// ServeContent handles the If-None-Match checks and 304 responses.
+		hash := sha256.Sum256([]byte(doc))
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("ETag", fmt.Sprintf(`"%s-%s"`, format, hex.EncodeToString(hash[:16])))
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader([]byte(doc)))
+	})
+}
+
+func HandleFeedTokenCreateForm(repo *repository.Repository, qry *query.Query, cmd *command.Command) http.Handler {
+	tmpl := page.NewBlogs()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		token, err := cmd.CreateFeedToken(r.Context(), account.ID())
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		data, err := newBlogsData(r, w, repo, qry, account)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		// Render the page directly (instead of redirecting) since this is the only
+		// time that the plaintext token (and therefore the feed URLs) will be available.
+		data.AtomFeedURL = FeedPath(account.ID(), FeedFormatAtom, token)
+		data.RSSFeedURL = FeedPath(account.ID(), FeedFormatRSS, token)
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleFeedTokenDeleteForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := cmd.DeleteFeedToken(r.Context(), account.ID())
+		if err != nil {
+			if errors.Is(err, command.ErrFeedTokenNotFound) {
+				util.NotFoundResponse(w, r)
+				return
+			}
+
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// Redirect back to the blogs page.
+		http.Redirect(w, r, "/blogs", http.StatusSeeOther)
+	})
+}
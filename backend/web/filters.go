@@ -0,0 +1,150 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+func HandleFilterList(qry *query.Query) http.Handler {
+	tmpl := page.NewFilters()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		var filters []query.ContentFilter
+		var blogs []query.BlogForAccount
+
+		var g errgroup.Group
+		g.Go(func() error {
+			var err error
+			filters, err = qry.ListContentFiltersByAccount(r.Context(), account)
+			return err
+		})
+		g.Go(func() error {
+			var err error
+			blogs, err = qry.ListBlogsForAccount(r.Context(), account)
+			return err
+		})
+
+		err := g.Wait()
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.FiltersData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Filters: filters,
+		}
+
+		// Only blogs that the account follows can be hidden.
+		data.Blogs = slices.DeleteFunc(blogs, func(blog query.BlogForAccount) bool {
+			return !blog.IsFollowing
+		})
+
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleFilterCreateForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		kind := model.ContentFilterKind(r.PostForm.Get("kind"))
+		value := kind.NormalizeValue(r.PostForm.Get("value"))
+
+		v := util.NewValidator()
+		v.Check("kind", "Please select a valid kind of filter", kind.IsValid())
+		v.CheckRequired("value", value)
+		v.CheckMaxCharacters("value", value, model.MaxContentFilterValueLength)
+		v.Check("value", "Please provide a valid value for this kind of filter", kind.IsValidValue(value))
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a valid filter (title patterns must be valid regular expressions).")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/filters", http.StatusSeeOther)
+			return
+		}
+
+		_, err = cmd.CreateContentFilter(r.Context(), account.ID(), kind, value)
+		if err != nil {
+			switch {
+			case errors.Is(err, command.ErrContentFilterConflict):
+				cookie := util.NewSessionCookie(util.ToastCookieName, "This filter already exists.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/filters", http.StatusSeeOther)
+			case errors.Is(err, command.ErrContentFilterInvalidPattern):
+				cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a valid filter (title patterns must be valid regular expressions).")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/filters", http.StatusSeeOther)
+			case errors.Is(err, command.ErrBlogNotFound):
+				util.NotFoundResponse(w, r)
+			default:
+				util.InternalServerErrorResponse(w, r, err)
+			}
+			return
+		}
+
+		// Redirect back to the filters page.
+		http.Redirect(w, r, "/filters", http.StatusSeeOther)
+	})
+}
+
+func HandleFilterDeleteForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		filterID, err := uuid.Parse(r.PathValue("filterID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.DeleteContentFilter(r.Context(), account.ID(), filterID)
+		if err != nil {
+			if errors.Is(err, command.ErrContentFilterNotFound) {
+				util.NotFoundResponse(w, r)
+				return
+			}
+
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// Redirect back to the filters page.
+		http.Redirect(w, r, "/filters", http.StatusSeeOther)
+	})
+}
@@ -93,9 +93,11 @@ func Handler(
 
 	// The main application routes start here.
 	mux.Handle("GET /{$}", HandleIndexPage(qry))
+	mux.Handle("GET /tags", HandleTagList(repo, qry))
+	mux.Handle("GET /tags/{name}", HandleIndexPage(qry))
 
 	apiHandler := api.Handler(conf, cmd, qry)
-	mux.Handle("GET /api/v1/", http.StripPrefix("/api/v1", apiHandler))
+	mux.Handle("/api/v1/", http.StripPrefix("/api/v1", apiHandler))
 
 	// Check if the debug auth method should be enabled.
 	enableDebugAuth := os.Getenv("ENABLE_DEBUG_AUTH") != ""
@@ -112,13 +114,60 @@ func Handler(
 	mux.Handle("POST /signout", HandleSignOutForm(cmd))
 
 	// Public blog routes.
-	mux.Handle("GET /blogs", requireAccount(HandleBlogList(qry)))
-	mux.Handle("POST /blogs/create", requireAccount(HandleBlogCreateForm(repo, syncService)))
+	mux.Handle("GET /blogs", requireAccount(HandleBlogList(repo, qry)))
+	mux.Handle("POST /blogs/create", requireAccount(HandleBlogCreateForm(repo, cmd, syncService)))
+	mux.Handle("POST /blogs/import", requireAccount(HandleBlogImportForm(cmd)))
+	mux.Handle("GET /blogs/export.opml", requireAccount(HandleBlogExport(repo)))
 	mux.Handle("POST /blogs/{blogID}/follow", requireAccount(HandleBlogFollowForm(repo)))
 	mux.Handle("POST /blogs/{blogID}/unfollow", requireAccount(HandleBlogUnfollowForm(repo)))
+	mux.Handle("POST /blogs/{blogID}/read", requireAccount(HandleBlogMarkReadForm(cmd)))
+
+	// Reader view routes.
+	mux.Handle("GET /posts/{postID}", requireAccount(HandleReaderPage(qry)))
+
+	// Read state routes.
+	mux.Handle("POST /posts/read", requireAccount(HandlePostMarkAllReadForm(cmd)))
+	mux.Handle("POST /posts/{postID}/read", requireAccount(HandlePostMarkReadForm(cmd)))
+	mux.Handle("POST /posts/{postID}/unread", requireAccount(HandlePostMarkUnreadForm(cmd)))
+
+	// Saved post routes.
+	mux.Handle("GET /saved", requireAccount(HandleSavedPage(qry)))
+	mux.Handle("POST /posts/{postID}/save", requireAccount(HandlePostSaveForm(cmd)))
+	mux.Handle("POST /posts/{postID}/unsave", requireAccount(HandlePostUnsaveForm(cmd)))
+
+	// Email digest routes.
+	mux.Handle("GET /digest", requireAccount(HandleDigestRead(repo)))
+	mux.Handle("POST /digest", requireAccount(HandleDigestUpdateForm(repo)))
+	mux.Handle("POST /digest/delete", requireAccount(HandleDigestDeleteForm(repo)))
+	mux.Handle("GET /digest/verify", HandleDigestVerify(repo))
+
+	// Personal API token routes.
+	mux.Handle("GET /tokens", requireAccount(HandleTokenList(repo)))
+	mux.Handle("POST /tokens/create", requireAccount(HandleTokenCreateForm(repo, cmd)))
+	mux.Handle("POST /tokens/{tokenID}/delete", requireAccount(HandleTokenDeleteForm(cmd)))
+
+	// Outbound webhook routes.
+	mux.Handle("GET /webhooks", requireAccount(HandleWebhookList(repo)))
+	mux.Handle("POST /webhooks/create", requireAccount(HandleWebhookCreateForm(cmd)))
+	mux.Handle("GET /webhooks/{webhookID}", requireAccount(HandleWebhookRead(repo)))
+	mux.Handle("POST /webhooks/{webhookID}/delete", requireAccount(HandleWebhookDeleteForm(cmd)))
+
+	// Content filter routes.
+	mux.Handle("GET /filters", requireAccount(HandleFilterList(qry)))
+	mux.Handle("POST /filters/create", requireAccount(HandleFilterCreateForm(cmd)))
+	mux.Handle("POST /filters/{filterID}/delete", requireAccount(HandleFilterDeleteForm(cmd)))
+
+	// Personal (token-authenticated) account feeds.
+	mux.Handle("GET /feeds/{accountID}/atom.xml", HandleAccountFeed(conf.SiteURL, repo, qry, FeedFormatAtom))
+	mux.Handle("GET /feeds/{accountID}/rss.xml", HandleAccountFeed(conf.SiteURL, repo, qry, FeedFormatRSS))
+	mux.Handle("POST /feeds/token/create", requireAccount(HandleFeedTokenCreateForm(repo, qry, cmd)))
+	mux.Handle("POST /feeds/token/delete", requireAccount(HandleFeedTokenDeleteForm(cmd)))
 
 	// Private (admin only) blog + post routes.
+	mux.Handle("GET /blogs/failing", requireAdmin(HandleFailingBlogList(repo)))
 	mux.Handle("GET /blogs/{blogID}", requireAdmin(HandleBlogRead(repo)))
+	mux.Handle("POST /blogs/{blogID}/sync", requireAdmin(HandleBlogSyncForm(repo, syncService)))
+	mux.Handle("POST /blogs/{blogID}/content", requireAdmin(HandleBlogFullContentForm(repo)))
 	mux.Handle("POST /blogs/{blogID}/delete", requireAdmin(HandleBlogDeleteForm(cmd)))
 	mux.Handle("GET /blogs/{blogID}/posts/{postID}", requireAdmin(HandlePostRead(repo)))
 	mux.Handle("POST /blogs/{blogID}/posts/{postID}/delete", requireAdmin(HandlePostDeleteForm(repo)))
@@ -127,6 +176,16 @@ func Handler(
 	mux.Handle("GET /accounts", requireAdmin(HandleAccountList(repo)))
 	mux.Handle("POST /accounts/{accountID}/delete", requireAdmin(HandleAccountDeleteForm(repo)))
 
+	// Private (admin only) tag + tag alias routes. These live under /admin so that
+	// they never shadow the public /tags/{name} pages (for tags like "manage").
+	mux.Handle("GET /admin/tags", requireAdmin(HandleTagManage(repo, qry)))
+	mux.Handle("POST /admin/tags/create", requireAdmin(HandleTagCreateForm(repo)))
+	mux.Handle("POST /admin/tags/{tagID}/rename", requireAdmin(HandleTagRenameForm(repo)))
+	mux.Handle("POST /admin/tags/{tagID}/delete", requireAdmin(HandleTagDeleteForm(repo)))
+	mux.Handle("GET /admin/tags/aliases", requireAdmin(HandleTagAliasList(repo)))
+	mux.Handle("POST /admin/tags/aliases/create", requireAdmin(HandleTagAliasCreateForm(repo)))
+	mux.Handle("POST /admin/tags/aliases/{tagAliasID}/delete", requireAdmin(HandleTagAliasDeleteForm(repo)))
+
 	// Debug endpoint for testing toasts.
 	mux.HandleFunc("GET /toast", func(w http.ResponseWriter, r *http.Request) {
 		cookie := util.NewSessionCookie(util.ToastCookieName, "Toasts are awesome!")
@@ -144,11 +203,22 @@ func Handler(
 		middleware.RecoverPanic(),
 		middleware.AddConfig(conf),
 		middleware.CompressFiles(),
+		middleware.LimitRequestBodySize(),
 		middleware.PreventCSRF(),
 		middleware.AddSecureHeaders(),
-		middleware.LimitRequestBodySize(),
 		middleware.Authenticate(repo),
 	)
 
-	return handler
+	// WebSub hub callbacks are server-to-server requests that can't include CSRF
+	// tokens and carry entire feeds in their bodies. So they get routed around the
+	// browser-oriented middleware above (and enforce their own body size limit).
+	websubMux := http.NewServeMux()
+	websubMux.Handle("GET /websub/{subscriptionID}", HandleWebSubVerify(repo))
+	websubMux.Handle("POST /websub/{subscriptionID}", HandleWebSubContent(repo, syncService))
+
+	root := http.NewServeMux()
+	root.Handle("/websub/", middleware.Use(websubMux, middleware.RecoverPanic()))
+	root.Handle("/", handler)
+
+	return root
 }
@@ -4,10 +4,12 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
 	"github.com/theandrew168/bloggulus/backend/web/page"
 	"github.com/theandrew168/bloggulus/backend/web/util"
 )
@@ -21,6 +23,23 @@ func HandleIndexPage(qry *query.Query) http.Handler {
 		// check search param
 		search := r.URL.Query().Get("q")
 
+		// check tag (either from the path via /tags/{name} or from the tag param)
+		tag := r.PathValue("name")
+		if tag == "" {
+			tag = r.URL.Query().Get("tag")
+		}
+
+		// searching takes priority over filtering by tag
+		if search != "" {
+			tag = ""
+		}
+
+		// check unread param (only applies to logged in users who aren't searching or filtering)
+		unread := isLoggedIn && search == "" && tag == "" && r.URL.Query().Get("unread") != ""
+
+		// check hidden param (only applies to logged in users, shows articles hidden by their content filters)
+		showHidden := isLoggedIn && r.URL.Query().Get("hidden") != ""
+
 		// check page param
 		p, err := strconv.Atoi(r.URL.Query().Get("p"))
 		if err != nil {
@@ -40,29 +59,51 @@ func HandleIndexPage(qry *query.Query) http.Handler {
 
 		// Two levels of decision making here:
 		// 1. Is the user logged in?
-		// 2. Is the user searching?
+		// 2. Is the user searching (or filtering by tag)?
 		var g errgroup.Group
 		if isLoggedIn {
 			if search != "" {
 				g.Go(func() error {
 					var err error
-					count, err = qry.CountRelevantArticlesByAccount(account, search)
+					count, err = qry.CountRelevantArticlesByAccount(r.Context(), account, search, showHidden)
+					return err
+				})
+				g.Go(func() error {
+					var err error
+					articles, err = qry.ListRelevantArticlesByAccount(r.Context(), account, search, showHidden, limit, offset)
+					return err
+				})
+			} else if tag != "" {
+				g.Go(func() error {
+					var err error
+					count, err = qry.CountTaggedArticlesByAccount(r.Context(), account, tag, showHidden)
+					return err
+				})
+				g.Go(func() error {
+					var err error
+					articles, err = qry.ListTaggedArticlesByAccount(r.Context(), account, tag, showHidden, limit, offset)
+					return err
+				})
+			} else if unread {
+				g.Go(func() error {
+					var err error
+					count, err = qry.CountUnreadArticlesByAccount(r.Context(), account, showHidden)
 					return err
 				})
 				g.Go(func() error {
 					var err error
-					articles, err = qry.ListRelevantArticlesByAccount(account, search, limit, offset)
+					articles, err = qry.ListUnreadArticlesByAccount(r.Context(), account, showHidden, limit, offset)
 					return err
 				})
 			} else {
 				g.Go(func() error {
 					var err error
-					count, err = qry.CountRecentArticlesByAccount(account)
+					count, err = qry.CountRecentArticlesByAccount(r.Context(), account, showHidden)
 					return err
 				})
 				g.Go(func() error {
 					var err error
-					articles, err = qry.ListRecentArticlesByAccount(account, limit, offset)
+					articles, err = qry.ListRecentArticlesByAccount(r.Context(), account, showHidden, limit, offset)
 					return err
 				})
 			}
@@ -70,23 +111,34 @@ func HandleIndexPage(qry *query.Query) http.Handler {
 			if search != "" {
 				g.Go(func() error {
 					var err error
-					count, err = qry.CountRelevantArticles(search)
+					count, err = qry.CountRelevantArticles(r.Context(), search)
+					return err
+				})
+				g.Go(func() error {
+					var err error
+					articles, err = qry.ListRelevantArticles(r.Context(), search, limit, offset)
+					return err
+				})
+			} else if tag != "" {
+				g.Go(func() error {
+					var err error
+					count, err = qry.CountTaggedArticles(r.Context(), tag)
 					return err
 				})
 				g.Go(func() error {
 					var err error
-					articles, err = qry.ListRelevantArticles(search, limit, offset)
+					articles, err = qry.ListTaggedArticles(r.Context(), tag, limit, offset)
 					return err
 				})
 			} else {
 				g.Go(func() error {
 					var err error
-					count, err = qry.CountRecentArticles()
+					count, err = qry.CountRecentArticles(r.Context())
 					return err
 				})
 				g.Go(func() error {
 					var err error
-					articles, err = qry.ListRecentArticles(limit, offset)
+					articles, err = qry.ListRecentArticles(r.Context(), limit, offset)
 					return err
 				})
 			}
@@ -98,14 +150,25 @@ func HandleIndexPage(qry *query.Query) http.Handler {
 			return
 		}
 
+		base := util.GetTemplateBaseData(r, w)
 		data := page.IndexData{
-			BaseData: util.GetTemplateBaseData(r, w),
+			BaseData: base,
 
 			Search:       search,
-			Articles:     articles,
+			Tag:          tag,
+			Unread:       unread,
+			ShowHidden:   showHidden,
+			ReadBefore:   timeutil.Now().Format(time.RFC3339),
 			HasMorePages: p*s < count,
 			NextPage:     p + 1,
 		}
+		for _, article := range articles {
+			data.Articles = append(data.Articles, page.IndexArticleData{
+				BaseData: base,
+
+				Article: article,
+			})
+		}
 		util.Render(w, r, 200, func(w io.Writer) error {
 			return tmpl.Render(w, data)
 		})
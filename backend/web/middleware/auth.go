@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/web/api/jsonutil"
 	"github.com/theandrew168/bloggulus/backend/web/util"
 )
 
@@ -24,10 +26,54 @@ func signInRedirectURL(path string) *url.URL {
 	return url
 }
 
+// Extract the token from an "Authorization: Bearer <token>" header (if present).
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", false
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
 func Authenticate(repo *repository.Repository) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
+			// Check for an API token (these take precedence over session cookies).
+			if token, ok := bearerToken(r); ok {
+				account, err := repo.Account().ReadByAPIToken(r.Context(), token)
+				if err != nil {
+					// Unlike session cookies, an invalid API token is an error. Otherwise,
+					// scripts would silently run as anonymous users.
+					if errors.Is(err, postgres.ErrNotFound) {
+						w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+						jsonutil.Write(w, http.StatusUnauthorized, map[string]string{
+							"error": "invalid or revoked API token",
+						})
+						return
+					}
+
+					util.InternalServerErrorResponse(w, r, err)
+					return
+				}
+
+				r = util.SetContextAccount(r, account)
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			// Check for a sessionID cookie.
 			sessionID, err := r.Cookie(util.SessionCookieName)
 			if err != nil {
@@ -36,7 +82,7 @@ func Authenticate(repo *repository.Repository) Middleware {
 			}
 
 			// Lookup the account linked to the session.
-			account, err := repo.Account().ReadBySessionID(sessionID.Value)
+			account, err := repo.Account().ReadBySessionID(r.Context(), sessionID.Value)
 			if err != nil {
 				// If the user has an invalid / expired session cookie, delete it.
 				if errors.Is(err, postgres.ErrNotFound) {
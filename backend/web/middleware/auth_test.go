@@ -80,6 +80,53 @@ func TestAuthenticateInvalidSession(t *testing.T) {
 	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
 }
 
+func TestAuthenticateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	account := test.CreateAccount(t, repo)
+	_, token := test.CreateAPIToken(t, repo, account)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := util.GetContextAccount(r)
+		test.AssertEqual(t, ok, true)
+		test.AssertEqual(t, got.ID(), account.ID())
+	})
+
+	h := middleware.Use(next, middleware.Authenticate(repo))
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+}
+
+func TestAuthenticateInvalidAPIToken(t *testing.T) {
+	t.Parallel()
+
+	repo, closer := test.NewRepository(t)
+	defer closer()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer foobar")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	h := middleware.Use(next, middleware.Authenticate(repo))
+	h.ServeHTTP(w, r)
+
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusUnauthorized)
+}
+
 func TestRequireAccount(t *testing.T) {
 	t.Parallel()
 
@@ -15,6 +15,14 @@ func PreventCSRF() Middleware {
 
 		csrfHandler := nosurf.New(next)
 		csrfHandler.SetBaseCookie(baseCookie)
+
+		// Requests authenticated by an API token don't need CSRF protection because
+		// browsers never attach Authorization headers automatically.
+		csrfHandler.ExemptFunc(func(r *http.Request) bool {
+			_, ok := bearerToken(r)
+			return ok
+		})
+
 		return csrfHandler
 	}
 }
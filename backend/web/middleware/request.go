@@ -1,15 +1,30 @@
 package middleware
 
-import "net/http"
+import (
+	"mime"
+	"net/http"
+)
 
 // Limit the size of the request body to 4KB.
 const MaxRequestBodySize = 4 * 1024
 
-// Places an upper limit on the size of every request body.
+// Limit the size of multipart request bodies (file uploads) to 2MB.
+const MaxMultipartRequestBodySize = 2 * 1024 * 1024
+
+// Places an upper limit on the size of every request body. This must run before
+// anything that parses the body (like CSRF protection) in order to be effective.
 func LimitRequestBodySize() Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodySize)
+			limit := int64(MaxRequestBodySize)
+
+			// File uploads (like OPML imports) are allowed to be a bit larger.
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType == "multipart/form-data" {
+				limit = MaxMultipartRequestBodySize
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
 
 			next.ServeHTTP(w, r)
 		})
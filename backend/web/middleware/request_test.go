@@ -1,6 +1,7 @@
 package middleware_test
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -49,3 +50,63 @@ func TestLimitRequestBodySize(t *testing.T) {
 	rr := w.Result()
 	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
 }
+
+func TestLimitRequestBodySizeMultipart(t *testing.T) {
+	t.Parallel()
+
+	// Prepare the mock ResponseWriter and Request.
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", newInfiniteReader())
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=foo")
+
+	// Prepare the stub handler.
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Reading more than the regular limit is fine for file uploads.
+		buf := make([]byte, middleware.MaxRequestBodySize+1)
+		_, err := io.ReadFull(r.Body, buf)
+		test.AssertNilError(t, err)
+
+		// But reading more than the multipart limit is not.
+		buf = make([]byte, middleware.MaxMultipartRequestBodySize)
+		_, err = io.ReadFull(r.Body, buf)
+		test.AssertErrorAs(t, err, new(*http.MaxBytesError))
+	})
+
+	// Wrap the stub handler in the middleware we want to test.
+	limitRequestBodySize := middleware.LimitRequestBodySize()
+	h := limitRequestBodySize(next)
+
+	// Serve the HTTP request.
+	h.ServeHTTP(w, r)
+
+	// Verify that our stub handler was executed.
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusOK)
+}
+
+func TestLimitRequestBodySizeBeforeCSRF(t *testing.T) {
+	t.Parallel()
+
+	// Prepare the mock ResponseWriter and Request.
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", newInfiniteReader())
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=foo")
+
+	// Prepare the stub handler (which should never be reached).
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler should not be reached")
+	})
+
+	// The CSRF check parses the form, so it must only ever see a limited body.
+	h := middleware.Use(next,
+		middleware.LimitRequestBodySize(),
+		middleware.PreventCSRF(),
+	)
+
+	// Serve the HTTP request (this would never finish without the limit).
+	h.ServeHTTP(w, r)
+
+	// Verify that the request was rejected.
+	rr := w.Result()
+	test.AssertEqual(t, rr.StatusCode, http.StatusBadRequest)
+}
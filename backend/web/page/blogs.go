@@ -16,6 +16,14 @@ type BlogsData struct {
 	layout.BaseData
 
 	Blogs []BlogsBlogData
+
+	// Whether the account has a token for its personal feed (the feed URLs below
+	// are only available right after a new token is created).
+	HasFeedToken bool
+
+	// Personal (token-authenticated) feeds of the account's timeline.
+	AtomFeedURL string
+	RSSFeedURL  string
 }
 
 // Since this data might be re-rendered per-row via HTMX, we have to include
@@ -0,0 +1,41 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed digest.html
+var DigestHTML string
+
+type DigestData struct {
+	layout.BaseData
+
+	// The account's current digest (nil if not subscribed).
+	Digest *model.Digest
+}
+
+type DigestPage struct {
+	tmpl *template.Template
+}
+
+func NewDigest() *DigestPage {
+	sources := []string{
+		layout.BaseHTML,
+		DigestHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := DigestPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *DigestPage) Render(w io.Writer, data DigestData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
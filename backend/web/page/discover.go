@@ -0,0 +1,45 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed discover.html
+var DiscoverHTML string
+
+type DiscoverData struct {
+	layout.BaseData
+
+	PageURL string
+	Feeds   []DiscoverFeedData
+}
+
+type DiscoverFeedData struct {
+	URL   string
+	Title string
+}
+
+type DiscoverPage struct {
+	tmpl *template.Template
+}
+
+func NewDiscover() *DiscoverPage {
+	sources := []string{
+		layout.BaseHTML,
+		DiscoverHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := DiscoverPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *DiscoverPage) Render(w io.Writer, data DiscoverData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
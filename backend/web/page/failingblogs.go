@@ -0,0 +1,40 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed failingblogs.html
+var FailingBlogsHTML string
+
+type FailingBlogsData struct {
+	layout.BaseData
+
+	Blogs []*model.Blog
+}
+
+type FailingBlogsPage struct {
+	tmpl *template.Template
+}
+
+func NewFailingBlogs() *FailingBlogsPage {
+	sources := []string{
+		layout.BaseHTML,
+		FailingBlogsHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := FailingBlogsPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *FailingBlogsPage) Render(w io.Writer, data FailingBlogsData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
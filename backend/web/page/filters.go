@@ -0,0 +1,42 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed filters.html
+var FiltersHTML string
+
+type FiltersData struct {
+	layout.BaseData
+
+	Filters []query.ContentFilter
+	// Blogs that the account follows (which can be filtered out entirely).
+	Blogs []query.BlogForAccount
+}
+
+type FiltersPage struct {
+	tmpl *template.Template
+}
+
+func NewFilters() *FiltersPage {
+	sources := []string{
+		layout.BaseHTML,
+		FiltersHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := FiltersPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *FiltersPage) Render(w io.Writer, data FiltersData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
@@ -0,0 +1,44 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed import.html
+var ImportHTML string
+
+type ImportData struct {
+	layout.BaseData
+
+	Results []ImportResultData
+}
+
+type ImportResultData struct {
+	FeedURL string
+	Status  string
+}
+
+type ImportPage struct {
+	tmpl *template.Template
+}
+
+func NewImport() *ImportPage {
+	sources := []string{
+		layout.BaseHTML,
+		ImportHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := ImportPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *ImportPage) Render(w io.Writer, data ImportData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
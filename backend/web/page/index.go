@@ -16,9 +16,23 @@ type IndexData struct {
 	layout.BaseData
 
 	Search       string
-	Articles     []query.Article
+	Tag          string
+	Unread       bool
+	Saved        bool
+	ShowHidden   bool
+	Articles     []IndexArticleData
 	HasMorePages bool
 	NextPage     int
+
+	// Posts published before this time (when the page was rendered) get marked as read.
+	ReadBefore string
+}
+
+// Since the read and saved states might be re-rendered per-article via HTMX, we have to include
+// the layout.BaseData to ensure CSRF prevention still works.
+type IndexArticleData struct {
+	layout.BaseData
+	query.Article
 }
 
 type IndexPage struct {
@@ -41,3 +55,11 @@ func NewIndex() *IndexPage {
 func (p *IndexPage) Render(w io.Writer, data IndexData) error {
 	return p.tmpl.ExecuteTemplate(w, "default", data)
 }
+
+func (p *IndexPage) RenderRead(w io.Writer, data IndexArticleData) error {
+	return p.tmpl.ExecuteTemplate(w, "read", data)
+}
+
+func (p *IndexPage) RenderSave(w io.Writer, data IndexArticleData) error {
+	return p.tmpl.ExecuteTemplate(w, "save", data)
+}
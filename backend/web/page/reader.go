@@ -0,0 +1,44 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed reader.html
+var ReaderHTML string
+
+type ReaderData struct {
+	layout.BaseData
+	query.ReaderArticle
+
+	// The post's content: either sanitized HTML (from its feed) or plain text
+	// paragraphs (extracted from its page). Both are empty if neither is available.
+	Content    template.HTML
+	Paragraphs []string
+}
+
+type ReaderPage struct {
+	tmpl *template.Template
+}
+
+func NewReader() *ReaderPage {
+	sources := []string{
+		layout.BaseHTML,
+		ReaderHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := ReaderPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *ReaderPage) Render(w io.Writer, data ReaderData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
@@ -0,0 +1,40 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed tagaliases.html
+var TagAliasesHTML string
+
+type TagAliasesData struct {
+	layout.BaseData
+
+	TagAliases []*model.TagAlias
+}
+
+type TagAliasesPage struct {
+	tmpl *template.Template
+}
+
+func NewTagAliases() *TagAliasesPage {
+	sources := []string{
+		layout.BaseHTML,
+		TagAliasesHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := TagAliasesPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *TagAliasesPage) Render(w io.Writer, data TagAliasesData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
@@ -0,0 +1,53 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed tagmanage.html
+var TagManageHTML string
+
+type TagManageData struct {
+	layout.BaseData
+
+	Tags []TagManageTagData
+
+	// Name of a (not yet created) tag to preview along with the articles it would match.
+	Preview         string
+	PreviewCount    int
+	PreviewArticles []query.Article
+}
+
+type TagManageTagData struct {
+	ID    uuid.UUID
+	Name  string
+	Count int
+}
+
+type TagManagePage struct {
+	tmpl *template.Template
+}
+
+func NewTagManage() *TagManagePage {
+	sources := []string{
+		layout.BaseHTML,
+		TagManageHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := TagManagePage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *TagManagePage) Render(w io.Writer, data TagManageData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
@@ -0,0 +1,46 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed tags.html
+var TagsHTML string
+
+type TagsData struct {
+	layout.BaseData
+
+	Tags []TagsTagData
+}
+
+type TagsTagData struct {
+	Name  string
+	Count int
+	// Relative size of the tag within the cloud (from 1 to 5).
+	Weight int
+}
+
+type TagsPage struct {
+	tmpl *template.Template
+}
+
+func NewTags() *TagsPage {
+	sources := []string{
+		layout.BaseHTML,
+		TagsHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := TagsPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *TagsPage) Render(w io.Writer, data TagsData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
@@ -0,0 +1,43 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed tokens.html
+var TokensHTML string
+
+type TokensData struct {
+	layout.BaseData
+
+	Tokens []*model.APIToken
+
+	// The plaintext value of a newly-created token (only shown once).
+	NewToken string
+}
+
+type TokensPage struct {
+	tmpl *template.Template
+}
+
+func NewTokens() *TokensPage {
+	sources := []string{
+		layout.BaseHTML,
+		TokensHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := TokensPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *TokensPage) Render(w io.Writer, data TokensData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
@@ -0,0 +1,41 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed webhook.html
+var WebhookHTML string
+
+type WebhookData struct {
+	layout.BaseData
+
+	Webhook    *model.Webhook
+	Deliveries []*model.WebhookDelivery
+}
+
+type WebhookPage struct {
+	tmpl *template.Template
+}
+
+func NewWebhook() *WebhookPage {
+	sources := []string{
+		layout.BaseHTML,
+		WebhookHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := WebhookPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *WebhookPage) Render(w io.Writer, data WebhookData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
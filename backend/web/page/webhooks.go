@@ -0,0 +1,40 @@
+package page
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/web/layout"
+)
+
+//go:embed webhooks.html
+var WebhooksHTML string
+
+type WebhooksData struct {
+	layout.BaseData
+
+	Webhooks []*model.Webhook
+}
+
+type WebhooksPage struct {
+	tmpl *template.Template
+}
+
+func NewWebhooks() *WebhooksPage {
+	sources := []string{
+		layout.BaseHTML,
+		WebhooksHTML,
+	}
+
+	tmpl := newTemplate("default", sources)
+	page := WebhooksPage{
+		tmpl: tmpl,
+	}
+	return &page
+}
+
+func (p *WebhooksPage) Render(w io.Writer, data WebhooksData) error {
+	return p.tmpl.ExecuteTemplate(w, "default", data)
+}
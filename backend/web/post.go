@@ -22,7 +22,7 @@ func HandlePostRead(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		post, err := repo.Post().Read(postID)
+		post, err := repo.Post().Read(r.Context(), postID)
 		if err != nil {
 			util.ReadErrorResponse(w, r, err)
 			return
@@ -53,13 +53,13 @@ func HandlePostDeleteForm(repo *repository.Repository) http.Handler {
 			return
 		}
 
-		post, err := repo.Post().Read(postID)
+		post, err := repo.Post().Read(r.Context(), postID)
 		if err != nil {
 			util.ReadErrorResponse(w, r, err)
 			return
 		}
 
-		err = repo.Post().Delete(post)
+		err = repo.Post().Delete(r.Context(), post)
 		if err != nil {
 			util.DeleteErrorResponse(w, r, err)
 			return
@@ -0,0 +1,140 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Handle errors that arise from marking posts (or blogs) as read or unread.
+func readErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, command.ErrPostNotFound),
+		errors.Is(err, command.ErrBlogNotFound),
+		errors.Is(err, command.ErrAccountNotFound):
+		util.NotFoundResponse(w, r)
+	default:
+		util.InternalServerErrorResponse(w, r, err)
+	}
+}
+
+// Build a handler that marks a single post as read or unread.
+func handlePostMarkForm(cmd *command.Command, isRead bool) http.Handler {
+	tmpl := page.NewIndex()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		postID, err := uuid.Parse(r.PathValue("postID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		if isRead {
+			err = cmd.MarkPostRead(r.Context(), account.ID(), postID)
+		} else {
+			err = cmd.MarkPostUnread(r.Context(), account.ID(), postID)
+		}
+		if err != nil {
+			readErrorResponse(w, r, err)
+			return
+		}
+
+		// If the request came in via HTMX, re-render the read / unread toggle.
+		if util.IsHTMXRequest(r) {
+			data := page.IndexArticleData{
+				BaseData: util.GetTemplateBaseData(r, w),
+
+				Article: query.Article{
+					ID:     postID,
+					IsRead: isRead,
+				},
+			}
+			util.Render(w, r, 200, func(w io.Writer) error {
+				return tmpl.RenderRead(w, data)
+			})
+			return
+		}
+
+		util.RedirectBack(w, r, "/")
+	})
+}
+
+func HandlePostMarkReadForm(cmd *command.Command) http.Handler {
+	return handlePostMarkForm(cmd, true)
+}
+
+func HandlePostMarkUnreadForm(cmd *command.Command) http.Handler {
+	return handlePostMarkForm(cmd, false)
+}
+
+func HandlePostMarkAllReadForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		// Default to marking everything as read if no timestamp was provided.
+		before := timeutil.Now()
+		if value := r.PostForm.Get("before"); value != "" {
+			before, err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				util.BadRequestResponse(w, r)
+				return
+			}
+		}
+
+		err = cmd.MarkAllPostsRead(r.Context(), account.ID(), before)
+		if err != nil {
+			readErrorResponse(w, r, err)
+			return
+		}
+
+		util.RedirectBack(w, r, "/")
+	})
+}
+
+func HandleBlogMarkReadForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		blogID, err := uuid.Parse(r.PathValue("blogID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.MarkBlogRead(r.Context(), account.ID(), blogID)
+		if err != nil {
+			readErrorResponse(w, r, err)
+			return
+		}
+
+		http.Redirect(w, r, "/blogs", http.StatusSeeOther)
+	})
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/sanitize"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Show a post's stored content (along with links to the newer and older posts in
+// the account's timeline) without having to leave Bloggulus.
+func HandleReaderPage(qry *query.Query) http.Handler {
+	tmpl := page.NewReader()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		postID, err := uuid.Parse(r.PathValue("postID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		article, err := qry.ReadReaderArticleByAccount(r.Context(), account, postID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.ReaderData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			ReaderArticle: article,
+		}
+
+		// Full content is only fetched for blogs whose feeds don't include it, so prefer
+		// it (when available) over the feed's (likely truncated) content.
+		if article.FullContent != "" {
+			data.Paragraphs = strings.Split(article.FullContent, "\n\n")
+		} else {
+			data.Content = template.HTML(sanitize.HTML(article.Content, article.BlogURL))
+		}
+
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+func HandleSavedPage(qry *query.Query) http.Handler {
+	tmpl := page.NewIndex()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		// check page param
+		p, err := strconv.Atoi(r.URL.Query().Get("p"))
+		if err != nil {
+			p = 1
+		}
+
+		if p < 1 {
+			p = 1
+		}
+
+		// assume size is always 20 (for now...)
+		s := 20
+		limit, offset := util.PageSizeToLimitOffset(p, s)
+
+		var count int
+		var articles []query.Article
+
+		var g errgroup.Group
+		g.Go(func() error {
+			var err error
+			count, err = qry.CountSavedArticlesByAccount(r.Context(), account)
+			return err
+		})
+		g.Go(func() error {
+			var err error
+			articles, err = qry.ListSavedArticlesByAccount(r.Context(), account, limit, offset)
+			return err
+		})
+
+		err = g.Wait()
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		base := util.GetTemplateBaseData(r, w)
+		data := page.IndexData{
+			BaseData: base,
+
+			Saved:        true,
+			HasMorePages: p*s < count,
+			NextPage:     p + 1,
+		}
+		for _, article := range articles {
+			data.Articles = append(data.Articles, page.IndexArticleData{
+				BaseData: base,
+
+				Article: article,
+			})
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+// Build a handler that saves or unsaves a single post.
+func handlePostSaveForm(cmd *command.Command, isSaved bool) http.Handler {
+	tmpl := page.NewIndex()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		postID, err := uuid.Parse(r.PathValue("postID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		// NOTE: A toggle that's already in the requested state (from a stale page or
+		// a double submit) isn't an error from the user's point of view.
+		if isSaved {
+			err = cmd.SavePost(r.Context(), account.ID(), postID)
+			if errors.Is(err, command.ErrPostAlreadySaved) {
+				err = nil
+			}
+		} else {
+			err = cmd.UnsavePost(r.Context(), account.ID(), postID)
+			if errors.Is(err, command.ErrPostNotSaved) {
+				err = nil
+			}
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, command.ErrPostNotFound),
+				errors.Is(err, command.ErrAccountNotFound):
+				util.NotFoundResponse(w, r)
+			default:
+				util.InternalServerErrorResponse(w, r, err)
+			}
+			return
+		}
+
+		// If the request came in via HTMX, re-render the save / unsave toggle.
+		if util.IsHTMXRequest(r) {
+			data := page.IndexArticleData{
+				BaseData: util.GetTemplateBaseData(r, w),
+
+				Article: query.Article{
+					ID:      postID,
+					IsSaved: isSaved,
+				},
+			}
+			util.Render(w, r, 200, func(w io.Writer) error {
+				return tmpl.RenderSave(w, data)
+			})
+			return
+		}
+
+		util.RedirectBack(w, r, "/")
+	})
+}
+
+func HandlePostSaveForm(cmd *command.Command) http.Handler {
+	return handlePostSaveForm(cmd, true)
+}
+
+func HandlePostUnsaveForm(cmd *command.Command) http.Handler {
+	return handlePostSaveForm(cmd, false)
+}
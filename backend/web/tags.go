@@ -0,0 +1,457 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/query"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Limit the length of tag aliases and names.
+const MaxTagNameLength = 64
+
+// How far back to look when weighting tags by their recent articles.
+const TagCloudWindow = 30 * 24 * time.Hour
+
+// Limit the number of tags shown in the tag cloud.
+const MaxTagCloudSize = 500
+
+// How long to reuse the tag cloud's article counts before counting them again.
+const TagCloudCacheDuration = 5 * time.Minute
+
+// Limit the number of tags shown when managing them.
+const MaxTagManageSize = 1000
+
+// Limit the number of recent articles shown when previewing a new tag.
+const MaxTagPreviewSize = 10
+
+// Counting recent articles for every tag is expensive (and the tags page is public) so
+// the counts are shared between requests for a short while.
+type tagCountCache struct {
+	mu        sync.Mutex
+	counts    map[string]int
+	expiresAt time.Time
+}
+
+func (c *tagCountCache) get(ctx context.Context, qry *query.Query, now time.Time) (map[string]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts != nil && now.Before(c.expiresAt) {
+		return c.counts, nil
+	}
+
+	counts, err := qry.CountRecentArticlesByTag(ctx, now.Add(-TagCloudWindow), MaxTagCloudSize)
+	if err != nil {
+		return nil, err
+	}
+
+	c.counts = counts
+	c.expiresAt = now.Add(TagCloudCacheDuration)
+	return counts, nil
+}
+
+func HandleTagList(repo *repository.Repository, qry *query.Query) http.Handler {
+	tmpl := page.NewTags()
+	var cache tagCountCache
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags, err := repo.Tag().List(r.Context(), MaxTagCloudSize, 0)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		counts, err := cache.get(r.Context(), qry, timeutil.Now())
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// Find the most popular tag (to scale the others against).
+		maxCount := 0
+		for _, count := range counts {
+			maxCount = max(maxCount, count)
+		}
+
+		data := page.TagsData{
+			BaseData: util.GetTemplateBaseData(r, w),
+		}
+		for _, tag := range tags {
+			count := counts[tag.Name()]
+			data.Tags = append(data.Tags, page.TagsTagData{
+				Name:   tag.Name(),
+				Count:  count,
+				Weight: TagWeight(count, maxCount),
+			})
+		}
+
+		// Show the tags alphabetically (ignoring case).
+		slices.SortFunc(data.Tags, func(a, b page.TagsTagData) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+// Determine a tag's weight (from 1 to 5) based on its count relative to the most popular tag.
+func TagWeight(count, maxCount int) int {
+	if count <= 0 || maxCount <= 0 {
+		return 1
+	}
+
+	return 1 + (4*count)/maxCount
+}
+
+func HandleTagManage(repo *repository.Repository, qry *query.Query) http.Handler {
+	tmpl := page.NewTagManage()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		preview := cleanTagName(r.URL.Query().Get("preview"))
+		if len(preview) > MaxTagNameLength {
+			preview = ""
+		}
+
+		var tags []*model.Tag
+		var counts map[string]int
+		var previewCount int
+		var previewArticles []query.Article
+
+		var g errgroup.Group
+		g.Go(func() error {
+			var err error
+			tags, err = repo.Tag().List(r.Context(), MaxTagManageSize, 0)
+			return err
+		})
+		g.Go(func() error {
+			var err error
+			counts, err = qry.CountArticlesByTag(r.Context(), MaxTagManageSize)
+			return err
+		})
+		if preview != "" {
+			g.Go(func() error {
+				var err error
+				previewCount, err = qry.CountTaggedArticles(r.Context(), preview)
+				return err
+			})
+			g.Go(func() error {
+				var err error
+				previewArticles, err = qry.ListTaggedArticles(r.Context(), preview, MaxTagPreviewSize, 0)
+				return err
+			})
+		}
+
+		err := g.Wait()
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.TagManageData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Preview:         preview,
+			PreviewCount:    previewCount,
+			PreviewArticles: previewArticles,
+		}
+		for _, tag := range tags {
+			data.Tags = append(data.Tags, page.TagManageTagData{
+				ID:    tag.ID(),
+				Name:  tag.Name(),
+				Count: counts[tag.Name()],
+			})
+		}
+
+		// Show the tags alphabetically (ignoring case).
+		slices.SortFunc(data.Tags, func(a, b page.TagManageTagData) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleTagCreateForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		name := cleanTagName(r.PostForm.Get("name"))
+
+		v := util.NewValidator()
+		v.CheckRequired("name", name)
+		v.CheckMaxCharacters("name", name, MaxTagNameLength)
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a tag name (of up to 64 characters).")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+			return
+		}
+
+		tag, err := model.NewTag(name)
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		err = repo.Tag().Create(r.Context(), tag)
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				cookie := util.NewSessionCookie(util.ToastCookieName, "This tag already exists.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+				return
+			}
+
+			util.CreateErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("tag created",
+			"tag_id", tag.ID(),
+			"tag_name", tag.Name(),
+		)
+
+		// Redirect back to the manage tags page.
+		http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+	})
+}
+
+func HandleTagRenameForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tagID, err := uuid.Parse(r.PathValue("tagID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		err = r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		name := cleanTagName(r.PostForm.Get("name"))
+
+		v := util.NewValidator()
+		v.CheckRequired("name", name)
+		v.CheckMaxCharacters("name", name, MaxTagNameLength)
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a tag name (of up to 64 characters).")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+			return
+		}
+
+		tag, err := repo.Tag().Read(r.Context(), tagID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		oldName := tag.Name()
+		tag.SetName(name)
+
+		// Keep any aliases and tag filters pointing at the tag under its new name.
+		err = repo.WithTransaction(r.Context(), func(tx *repository.Repository) error {
+			err := tx.Tag().Update(r.Context(), tag)
+			if err != nil {
+				return err
+			}
+
+			err = tx.TagAlias().Rename(r.Context(), oldName, tag.Name())
+			if err != nil {
+				return err
+			}
+
+			return tx.ContentFilter().RenameTag(r.Context(), oldName, tag.Name())
+		})
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				cookie := util.NewSessionCookie(util.ToastCookieName, "A tag with this name already exists.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+				return
+			}
+
+			util.UpdateErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("tag renamed",
+			"tag_id", tag.ID(),
+			"tag_old_name", oldName,
+			"tag_name", tag.Name(),
+		)
+
+		// Redirect back to the manage tags page.
+		http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+	})
+}
+
+func HandleTagDeleteForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tagID, err := uuid.Parse(r.PathValue("tagID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		tag, err := repo.Tag().Read(r.Context(), tagID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		err = repo.Tag().Delete(r.Context(), tag)
+		if err != nil {
+			util.DeleteErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("tag deleted",
+			"tag_id", tag.ID(),
+			"tag_name", tag.Name(),
+		)
+
+		// Redirect back to the manage tags page.
+		http.Redirect(w, r, "/admin/tags", http.StatusSeeOther)
+	})
+}
+
+// Tidy up a tag name provided by an admin (while preserving its case).
+func cleanTagName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func HandleTagAliasList(repo *repository.Repository) http.Handler {
+	tmpl := page.NewTagAliases()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tagAliases, err := repo.TagAlias().List(r.Context())
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.TagAliasesData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			TagAliases: tagAliases,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleTagAliasCreateForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		alias := model.NormalizeTagName(r.PostForm.Get("alias"))
+		name := r.PostForm.Get("name")
+
+		v := util.NewValidator()
+		v.CheckRequired("alias", alias)
+		v.CheckMaxCharacters("alias", alias, MaxTagNameLength)
+		v.CheckRequired("name", name)
+		v.CheckMaxCharacters("name", name, MaxTagNameLength)
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide both an alias and a tag (of up to 64 characters each).")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/admin/tags/aliases", http.StatusSeeOther)
+			return
+		}
+
+		tagAlias, err := model.NewTagAlias(alias, name)
+		if err != nil {
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		err = repo.TagAlias().Create(r.Context(), tagAlias)
+		if err != nil {
+			if errors.Is(err, postgres.ErrConflict) {
+				cookie := util.NewSessionCookie(util.ToastCookieName, "This alias already exists.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/admin/tags/aliases", http.StatusSeeOther)
+				return
+			}
+
+			util.CreateErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("tag alias created",
+			"tag_alias_id", tagAlias.ID(),
+			"tag_alias_alias", tagAlias.Alias(),
+			"tag_alias_name", tagAlias.Name(),
+		)
+
+		// Redirect back to the tag aliases page.
+		http.Redirect(w, r, "/admin/tags/aliases", http.StatusSeeOther)
+	})
+}
+
+func HandleTagAliasDeleteForm(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tagAliasID, err := uuid.Parse(r.PathValue("tagAliasID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		tagAlias, err := repo.TagAlias().Read(r.Context(), tagAliasID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		err = repo.TagAlias().Delete(r.Context(), tagAlias)
+		if err != nil {
+			util.DeleteErrorResponse(w, r, err)
+			return
+		}
+
+		slog.Info("tag alias deleted",
+			"tag_alias_id", tagAlias.ID(),
+			"tag_alias_alias", tagAlias.Alias(),
+			"tag_alias_name", tagAlias.Name(),
+		)
+
+		// Redirect back to the tag aliases page.
+		http.Redirect(w, r, "/admin/tags/aliases", http.StatusSeeOther)
+	})
+}
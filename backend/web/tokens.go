@@ -0,0 +1,135 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+// Limit the length of API token names.
+const MaxTokenNameLength = 64
+
+func HandleTokenList(repo *repository.Repository) http.Handler {
+	tmpl := page.NewTokens()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		tokens, err := repo.APIToken().ListByAccount(r.Context(), account)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.TokensData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Tokens: tokens,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleTokenCreateForm(repo *repository.Repository, cmd *command.Command) http.Handler {
+	tmpl := page.NewTokens()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		name := r.PostForm.Get("name")
+
+		v := util.NewValidator()
+		v.CheckRequired("name", name)
+		v.CheckMaxCharacters("name", name, MaxTokenNameLength)
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a name (up to 64 characters) for the token.")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/tokens", http.StatusSeeOther)
+			return
+		}
+
+		token, err := cmd.CreateAPIToken(r.Context(), account.ID(), name)
+		if err != nil {
+			if errors.Is(err, command.ErrAPITokenNameConflict) {
+				cookie := util.NewSessionCookie(util.ToastCookieName, "A token with this name already exists.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/tokens", http.StatusSeeOther)
+				return
+			}
+
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		tokens, err := repo.APIToken().ListByAccount(r.Context(), account)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		// Render the page directly (instead of redirecting) since this is the only
+		// time that the plaintext token will ever be available.
+		data := page.TokensData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Tokens:   tokens,
+			NewToken: token,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleTokenDeleteForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		tokenID, err := uuid.Parse(r.PathValue("tokenID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.DeleteAPIToken(r.Context(), account.ID(), tokenID)
+		if err != nil {
+			if errors.Is(err, command.ErrAPITokenNotFound) {
+				util.NotFoundResponse(w, r)
+				return
+			}
+
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// Redirect back to the tokens page.
+		http.Redirect(w, r, "/tokens", http.StatusSeeOther)
+	})
+}
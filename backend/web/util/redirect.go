@@ -0,0 +1,22 @@
+package util
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Redirect back to the page that submitted a form (based on the Referer header).
+// Only the path and query are kept in order to prevent open redirects.
+func RedirectBack(w http.ResponseWriter, r *http.Request, fallback string) {
+	target := fallback
+
+	referer, err := url.Parse(r.Referer())
+	if err == nil && referer.Path != "" && referer.Host == r.Host {
+		target = referer.Path
+		if referer.RawQuery != "" {
+			target += "?" + referer.RawQuery
+		}
+	}
+
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/command"
+	"github.com/theandrew168/bloggulus/backend/netutil"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/web/page"
+	"github.com/theandrew168/bloggulus/backend/web/util"
+)
+
+const (
+	// Limit the length of webhook URLs and secrets.
+	MaxWebhookURLLength    = 2048
+	MaxWebhookSecretLength = 256
+
+	// Show (at most) this many deliveries in a webhook's delivery log.
+	WebhookDeliveryLogSize = 50
+)
+
+func HandleWebhookList(repo *repository.Repository) http.Handler {
+	tmpl := page.NewWebhooks()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		webhooks, err := repo.Webhook().ListByAccount(r.Context(), account)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.WebhooksData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Webhooks: webhooks,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleWebhookRead(repo *repository.Repository) http.Handler {
+	tmpl := page.NewWebhook()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		webhookID, err := uuid.Parse(r.PathValue("webhookID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		webhook, err := repo.Webhook().Read(r.Context(), webhookID)
+		if err != nil {
+			util.ReadErrorResponse(w, r, err)
+			return
+		}
+
+		// Accounts can only view their own webhooks (pretend that others don't exist).
+		if webhook.AccountID() != account.ID() {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		deliveries, err := repo.WebhookDelivery().ListByWebhook(r.Context(), webhook, WebhookDeliveryLogSize)
+		if err != nil {
+			util.ListErrorResponse(w, r, err)
+			return
+		}
+
+		data := page.WebhookData{
+			BaseData: util.GetTemplateBaseData(r, w),
+
+			Webhook:    webhook,
+			Deliveries: deliveries,
+		}
+		util.Render(w, r, 200, func(w io.Writer) error {
+			return tmpl.Render(w, data)
+		})
+	})
+}
+
+func HandleWebhookCreateForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			util.BadRequestResponse(w, r)
+			return
+		}
+
+		webhookURL := r.PostForm.Get("url")
+		secret := r.PostForm.Get("secret")
+
+		u, parseErr := url.Parse(webhookURL)
+		isHTTP := parseErr == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+
+		v := util.NewValidator()
+		v.CheckRequired("url", webhookURL)
+		v.CheckMaxCharacters("url", webhookURL, MaxWebhookURLLength)
+		v.Check("url", "Please provide a valid HTTP(S) URL", isHTTP)
+		v.CheckMaxCharacters("secret", secret, MaxWebhookSecretLength)
+		if !v.IsValid() {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a valid HTTP(S) URL (and a secret of up to 256 characters) for the webhook.")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/webhooks", http.StatusSeeOther)
+			return
+		}
+
+		// Webhooks can't be used to reach internal services (they'd be refused when sent anyway).
+		err = netutil.CheckURL(r.Context(), webhookURL)
+		if err != nil {
+			cookie := util.NewSessionCookie(util.ToastCookieName, "Please provide a webhook URL that points to a public address.")
+			http.SetCookie(w, &cookie)
+
+			http.Redirect(w, r, "/webhooks", http.StatusSeeOther)
+			return
+		}
+
+		webhook, err := cmd.CreateWebhook(r.Context(), account.ID(), webhookURL, secret)
+		if err != nil {
+			if errors.Is(err, command.ErrWebhookURLConflict) {
+				cookie := util.NewSessionCookie(util.ToastCookieName, "A webhook with this URL already exists.")
+				http.SetCookie(w, &cookie)
+
+				http.Redirect(w, r, "/webhooks", http.StatusSeeOther)
+				return
+			}
+
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// Redirect to the new webhook's page (which shows its secret).
+		http.Redirect(w, r, "/webhooks/"+webhook.ID().String(), http.StatusSeeOther)
+	})
+}
+
+func HandleWebhookDeleteForm(cmd *command.Command) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		account, isLoggedIn := util.GetContextAccount(r)
+		if !isLoggedIn {
+			util.ForbiddenResponse(w, r)
+			return
+		}
+
+		webhookID, err := uuid.Parse(r.PathValue("webhookID"))
+		if err != nil {
+			util.NotFoundResponse(w, r)
+			return
+		}
+
+		err = cmd.DeleteWebhook(r.Context(), account.ID(), webhookID)
+		if err != nil {
+			if errors.Is(err, command.ErrWebhookNotFound) {
+				util.NotFoundResponse(w, r)
+				return
+			}
+
+			util.InternalServerErrorResponse(w, r, err)
+			return
+		}
+
+		// Redirect back to the webhooks page.
+		http.Redirect(w, r, "/webhooks", http.StatusSeeOther)
+	})
+}
@@ -0,0 +1,187 @@
+package web
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/feed"
+	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/model"
+	"github.com/theandrew168/bloggulus/backend/postgres"
+	"github.com/theandrew168/bloggulus/backend/repository"
+	"github.com/theandrew168/bloggulus/backend/timeutil"
+	"github.com/theandrew168/bloggulus/backend/websub"
+)
+
+// Limit the size of feed content pushed by WebSub hubs to 4MB.
+const MaxWebSubContentSize = 4 * 1024 * 1024
+
+// Hubs are machines (not browsers) so these handlers respond with plain text errors.
+func webSubError(w http.ResponseWriter, code int) {
+	http.Error(w, http.StatusText(code), code)
+}
+
+// Read the subscription identified by the request's path (if it exists).
+func readWebSubSubscription(w http.ResponseWriter, r *http.Request, repo *repository.Repository) (*model.WebSubSubscription, bool) {
+	subscriptionID, err := uuid.Parse(r.PathValue("subscriptionID"))
+	if err != nil {
+		webSubError(w, http.StatusNotFound)
+		return nil, false
+	}
+
+	subscription, err := repo.WebSubSubscription().Read(r.Context(), subscriptionID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			webSubError(w, http.StatusNotFound)
+			return nil, false
+		}
+
+		slog.Error("internal server error",
+			"error", err.Error(),
+			"url", r.URL.String(),
+		)
+		webSubError(w, http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return subscription, true
+}
+
+// Handle a hub's verification of intent (or denial) for a subscription request.
+func HandleWebSubVerify(repo *repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscription, ok := readWebSubSubscription(w, r, repo)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		mode := query.Get("hub.mode")
+		topic := query.Get("hub.topic")
+
+		// Ignore requests for topics that this subscription isn't about.
+		if topic != subscription.TopicURL() {
+			webSubError(w, http.StatusNotFound)
+			return
+		}
+
+		switch mode {
+		case "subscribe":
+			leaseSeconds, err := strconv.Atoi(query.Get("hub.lease_seconds"))
+			if err != nil || leaseSeconds <= 0 {
+				webSubError(w, http.StatusBadRequest)
+				return
+			}
+
+			subscription.SetLease(timeutil.Now(), time.Duration(leaseSeconds)*time.Second)
+			err = repo.WebSubSubscription().Update(r.Context(), subscription)
+			if err != nil {
+				slog.Error("internal server error",
+					"error", err.Error(),
+					"url", r.URL.String(),
+				)
+				webSubError(w, http.StatusInternalServerError)
+				return
+			}
+
+			slog.Info("websub subscription verified",
+				"websub_subscription_id", subscription.ID(),
+				"hub_url", subscription.HubURL(),
+				"topic_url", subscription.TopicURL(),
+				"lease_expires_at", subscription.LeaseExpiresAt(),
+			)
+
+			// Echo back the challenge to confirm the subscription.
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(query.Get("hub.challenge")))
+		case "denied":
+			// Nothing else to do: the blog will keep being polled.
+			slog.Warn("websub subscription denied",
+				"websub_subscription_id", subscription.ID(),
+				"hub_url", subscription.HubURL(),
+				"topic_url", subscription.TopicURL(),
+				"reason", query.Get("hub.reason"),
+			)
+
+			w.WriteHeader(http.StatusOK)
+		default:
+			// We never ask to unsubscribe so refuse to confirm any such requests.
+			webSubError(w, http.StatusNotFound)
+		}
+	})
+}
+
+// Handle feed content pushed by a hub.
+func HandleWebSubContent(repo *repository.Repository, syncService *job.SyncService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subscription, ok := readWebSubSubscription(w, r, repo)
+		if !ok {
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxWebSubContentSize))
+		if err != nil {
+			webSubError(w, http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// Per the spec, content with an invalid signature must still be acknowledged
+		// (so that the sender learns nothing) but should otherwise be ignored.
+		signature := r.Header.Get(websub.SignatureHeader)
+		if !websub.VerifySignature(subscription.Secret(), string(body), signature) {
+			slog.Warn("websub content has an invalid signature",
+				"websub_subscription_id", subscription.ID(),
+				"hub_url", subscription.HubURL(),
+			)
+
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		blog, err := repo.Blog().Read(r.Context(), subscription.BlogID())
+		if err != nil {
+			slog.Error("internal server error",
+				"error", err.Error(),
+				"url", r.URL.String(),
+			)
+			webSubError(w, http.StatusInternalServerError)
+			return
+		}
+
+		err = syncService.SyncBlogContent(r.Context(), blog, string(body))
+		if err != nil {
+			// Retrying an invalid feed won't help so don't ask the hub to.
+			if errors.Is(err, feed.ErrInvalidFeed) {
+				slog.Warn("websub content is not a valid feed",
+					"websub_subscription_id", subscription.ID(),
+					"blog_id", blog.ID(),
+					"error", err.Error(),
+				)
+
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+
+			slog.Error("internal server error",
+				"error", err.Error(),
+				"url", r.URL.String(),
+			)
+			webSubError(w, http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("websub content received",
+			"websub_subscription_id", subscription.ID(),
+			"blog_id", blog.ID(),
+			"blog_title", blog.Title(),
+		)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
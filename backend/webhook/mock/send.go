@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/theandrew168/bloggulus/backend/webhook"
+)
+
+// ensure WebhookSender interface is satisfied
+var _ webhook.WebhookSender = (*WebhookSender)(nil)
+
+// An in-memory webhook sender that records every request it is asked to send and
+// responds with a fixed status code for each URL (unknown URLs are unreachable).
+type WebhookSender struct {
+	mu          sync.Mutex
+	statusCodes map[string]int
+	requests    []webhook.SendWebhookRequest
+}
+
+func NewWebhookSender(statusCodes map[string]int) *WebhookSender {
+	s := WebhookSender{statusCodes: statusCodes}
+	return &s
+}
+
+func (s *WebhookSender) SendWebhook(ctx context.Context, request webhook.SendWebhookRequest) (webhook.SendWebhookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, request)
+
+	statusCode, ok := s.statusCodes[request.URL]
+	if !ok {
+		return webhook.SendWebhookResponse{}, webhook.ErrUnreachableWebhook
+	}
+
+	return webhook.SendWebhookResponse{StatusCode: statusCode}, nil
+}
+
+// List all requests sent to a specific URL (in the order they were sent).
+func (s *WebhookSender) RequestsTo(url string) []webhook.SendWebhookRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []webhook.SendWebhookRequest
+	for _, request := range s.requests {
+		if request.URL == url {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/netutil"
+	"github.com/theandrew168/bloggulus/backend/webhook"
+)
+
+const UserAgent = "Bloggulus-Webhook/0.5.2 (+https://bloggulus.com)"
+
+// Don't let a slow receiver hold up the rest of the delivery queue.
+const SendTimeout = 10 * time.Second
+
+// ensure WebhookSender interface is satisfied
+var _ webhook.WebhookSender = (*WebhookSender)(nil)
+
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender() *WebhookSender {
+	// Webhook URLs are provided by users so only public addresses can be sent to.
+	client := netutil.NewClient(netutil.ClientConfig{
+		Timeout: SendTimeout,
+	})
+
+	// Don't follow redirects (the receiver's response is recorded as-is).
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	s := WebhookSender{
+		client: client,
+	}
+	return &s
+}
+
+func (s *WebhookSender) SendWebhook(ctx context.Context, request webhook.SendWebhookRequest) (webhook.SendWebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", request.URL, strings.NewReader(request.Payload))
+	if err != nil {
+		return webhook.SendWebhookResponse{}, webhook.ErrUnreachableWebhook
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, request.Event)
+	req.Header.Set(webhook.DeliveryHeader, request.DeliveryID.String())
+	req.Header.Set(webhook.TimestampHeader, webhook.FormatTimestamp(request.Timestamp))
+	req.Header.Set(webhook.SignatureHeader, request.Signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return webhook.SendWebhookResponse{}, webhook.ErrUnreachableWebhook
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the underlying connection can be reused.
+	io.Copy(io.Discard, resp.Body)
+
+	sendWebhookResponse := webhook.SendWebhookResponse{
+		StatusCode: resp.StatusCode,
+	}
+	return sendWebhookResponse, nil
+}
@@ -0,0 +1,36 @@
+package web_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/webhook"
+	webhookWeb "github.com/theandrew168/bloggulus/backend/webhook/web"
+)
+
+func TestSendWebhookForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+	}))
+	defer server.Close()
+
+	// Webhooks are never sent to local (or otherwise non-public) servers.
+	sender := webhookWeb.NewWebhookSender()
+	_, err := sender.SendWebhook(context.Background(), webhook.SendWebhookRequest{
+		URL:        server.URL,
+		Event:      webhook.EventPostCreated,
+		DeliveryID: uuid.New(),
+		Payload:    "{}",
+	})
+	test.AssertErrorIs(t, err, webhook.ErrUnreachableWebhook)
+	test.AssertEqual(t, requested.Load(), false)
+}
@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/theandrew168/bloggulus/backend/model"
+)
+
+var (
+	ErrUnreachableWebhook = errors.New("webhook: unreachable webhook")
+)
+
+const (
+	// Sent whenever a new post is found for a blog that the account follows.
+	EventPostCreated = "post.created"
+)
+
+const (
+	EventHeader     = "X-Bloggulus-Event"
+	DeliveryHeader  = "X-Bloggulus-Delivery"
+	TimestampHeader = "X-Bloggulus-Timestamp"
+	SignatureHeader = "X-Bloggulus-Signature"
+)
+
+// Receivers should reject requests whose timestamp is further than this from their
+// own clock (in either direction) so that captured requests can't be replayed later.
+const SignatureTolerance = 5 * time.Minute
+
+type PostPayload struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"publishedAt"`
+}
+
+type BlogPayload struct {
+	ID      uuid.UUID `json:"id"`
+	Title   string    `json:"title"`
+	SiteURL string    `json:"siteURL"`
+}
+
+type PostCreatedPayload struct {
+	Event string      `json:"event"`
+	Post  PostPayload `json:"post"`
+	Blog  BlogPayload `json:"blog"`
+}
+
+// Build the JSON body for a "post.created" event.
+func NewPostCreatedPayload(blog *model.Blog, post *model.Post) (string, error) {
+	payload := PostCreatedPayload{
+		Event: EventPostCreated,
+		Post: PostPayload{
+			ID:          post.ID(),
+			Title:       post.Title(),
+			URL:         post.URL(),
+			PublishedAt: post.PublishedAt(),
+		},
+		Blog: BlogPayload{
+			ID:      blog.ID(),
+			Title:   blog.Title(),
+			SiteURL: blog.SiteURL(),
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// Sign a request with a webhook's secret (HMAC-SHA256). The signed content is the
+// request's timestamp (in Unix seconds, as sent in the timestamp header), a period,
+// and then the body. Receivers should compute the same value, compare it to the
+// signature header, and check that the timestamp is within SignatureTolerance.
+func Sign(secret string, timestamp time.Time, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(FormatTimestamp(timestamp) + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Format a timestamp the way it is sent in the timestamp header (Unix seconds).
+func FormatTimestamp(timestamp time.Time) string {
+	return strconv.FormatInt(timestamp.Unix(), 10)
+}
+
+// Check a request's timestamp and signature headers the same way that receivers should.
+func Verify(secret, timestampHeader, signatureHeader, body string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	timestamp := time.Unix(seconds, 0)
+	if timestamp.Before(now.Add(-SignatureTolerance)) || timestamp.After(now.Add(SignatureTolerance)) {
+		return false
+	}
+
+	signature := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(signature), []byte(signatureHeader))
+}
+
+type SendWebhookRequest struct {
+	URL        string
+	DeliveryID uuid.UUID
+	Event      string
+	Payload    string
+	Timestamp  time.Time
+	Signature  string
+}
+
+type SendWebhookResponse struct {
+	StatusCode int
+}
+
+// Any response with a status code outside of the 2xx range is considered a failure.
+func (r SendWebhookResponse) IsSuccess() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+type WebhookSender interface {
+	SendWebhook(ctx context.Context, request SendWebhookRequest) (SendWebhookResponse, error)
+}
@@ -0,0 +1,64 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/webhook"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// The timestamp (in Unix seconds) and a period are signed along with the body.
+	timestamp := time.Unix(1700000000, 0)
+	signature := webhook.Sign("Jefe", timestamp, "what do ya want for nothing?")
+	test.AssertEqual(t, signature, "sha256=1cdd0650c8be1cb0974b1788d458b1e781206cfef59b85faafc582d2e182c57e")
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	secret := "secret"
+	body := `{"event":"post.created"}`
+	now := time.Unix(1700000000, 0)
+
+	timestamp := webhook.FormatTimestamp(now)
+	signature := webhook.Sign(secret, now, body)
+
+	// A fresh, correctly signed request is accepted.
+	test.AssertEqual(t, webhook.Verify(secret, timestamp, signature, body, now), true)
+	test.AssertEqual(t, webhook.Verify(secret, timestamp, signature, body, now.Add(webhook.SignatureTolerance)), true)
+
+	// But not once it falls outside of the tolerance window.
+	test.AssertEqual(t, webhook.Verify(secret, timestamp, signature, body, now.Add(webhook.SignatureTolerance+time.Second)), false)
+	test.AssertEqual(t, webhook.Verify(secret, timestamp, signature, body, now.Add(-webhook.SignatureTolerance-time.Second)), false)
+
+	// Or if anything has been tampered with.
+	test.AssertEqual(t, webhook.Verify(secret, timestamp, signature, body+" ", now), false)
+	test.AssertEqual(t, webhook.Verify(secret, webhook.FormatTimestamp(now.Add(time.Second)), signature, body, now), false)
+	test.AssertEqual(t, webhook.Verify("other", timestamp, signature, body, now), false)
+	test.AssertEqual(t, webhook.Verify(secret, "not a timestamp", signature, body, now), false)
+}
+
+func TestNewPostCreatedPayload(t *testing.T) {
+	t.Parallel()
+
+	blog := test.NewBlog(t)
+	post := test.NewPost(t, blog)
+
+	body, err := webhook.NewPostCreatedPayload(blog, post)
+	test.AssertNilError(t, err)
+
+	var payload webhook.PostCreatedPayload
+	err = json.Unmarshal([]byte(body), &payload)
+	test.AssertNilError(t, err)
+
+	test.AssertEqual(t, payload.Event, webhook.EventPostCreated)
+	test.AssertEqual(t, payload.Post.ID, post.ID())
+	test.AssertEqual(t, payload.Post.URL, post.URL())
+	test.AssertEqual(t, payload.Blog.ID, blog.ID())
+	test.AssertEqual(t, payload.Blog.Title, blog.Title())
+}
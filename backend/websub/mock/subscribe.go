@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/theandrew168/bloggulus/backend/websub"
+)
+
+// ensure Subscriber interface is satisfied
+var _ websub.Subscriber = (*Subscriber)(nil)
+
+// An in-memory subscriber that records every request it is asked to make.
+type Subscriber struct {
+	mu       sync.Mutex
+	requests []websub.SubscribeRequest
+}
+
+func NewSubscriber() *Subscriber {
+	s := Subscriber{}
+	return &s
+}
+
+func (s *Subscriber) Subscribe(ctx context.Context, request websub.SubscribeRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, request)
+	return nil
+}
+
+// List all requests made to a specific hub (in the order they were made).
+func (s *Subscriber) RequestsTo(hubURL string) []websub.SubscribeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []websub.SubscribeRequest
+	for _, request := range s.requests {
+		if request.HubURL == hubURL {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/theandrew168/bloggulus/backend/netutil"
+	"github.com/theandrew168/bloggulus/backend/websub"
+)
+
+const UserAgent = "Bloggulus/0.5.2 (+https://bloggulus.com)"
+
+// Don't let a slow hub hold up the rest of the renewals.
+const SubscribeTimeout = 10 * time.Second
+
+// ensure Subscriber interface is satisfied
+var _ websub.Subscriber = (*Subscriber)(nil)
+
+type Subscriber struct {
+	client *http.Client
+}
+
+func NewSubscriber() *Subscriber {
+	// Hub URLs come from feeds (which anyone can add) so only public addresses can be contacted.
+	s := Subscriber{
+		client: netutil.NewClient(netutil.ClientConfig{
+			Timeout: SubscribeTimeout,
+		}),
+	}
+	return &s
+}
+
+// Ask a hub to subscribe. The hub will confirm (or deny) the subscription later
+// on by making a verification request to the callback URL.
+func (s *Subscriber) Subscribe(ctx context.Context, request websub.SubscribeRequest) error {
+	form := url.Values{}
+	form.Set("hub.mode", "subscribe")
+	form.Set("hub.topic", request.TopicURL)
+	form.Set("hub.callback", request.CallbackURL)
+	form.Set("hub.secret", request.Secret)
+	form.Set("hub.lease_seconds", strconv.Itoa(request.LeaseSeconds))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", request.HubURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return websub.ErrUnreachableHub
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return websub.ErrUnreachableHub
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the underlying connection can be reused.
+	io.Copy(io.Discard, resp.Body)
+
+	// Hubs should respond with a 202 Accepted (but any 2xx is fine).
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return websub.ErrUnreachableHub
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package web_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/websub"
+	websubWeb "github.com/theandrew168/bloggulus/backend/websub/web"
+)
+
+func TestSubscribeForbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// Hubs are never contacted at local (or otherwise non-public) addresses.
+	subscriber := websubWeb.NewSubscriber()
+	err := subscriber.Subscribe(context.Background(), websub.SubscribeRequest{
+		HubURL:       server.URL,
+		TopicURL:     test.RandomURL(32),
+		CallbackURL:  test.RandomURL(32),
+		Secret:       test.RandomString(32),
+		LeaseSeconds: 3600,
+	})
+	test.AssertErrorIs(t, err, websub.ErrUnreachableHub)
+	test.AssertEqual(t, requested.Load(), false)
+}
@@ -0,0 +1,67 @@
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strings"
+)
+
+// Based on:
+// https://www.w3.org/TR/websub/
+
+var (
+	ErrUnreachableHub = errors.New("websub: unreachable hub")
+)
+
+// Hubs sign content distribution requests using this header.
+const SignatureHeader = "X-Hub-Signature"
+
+type SubscribeRequest struct {
+	HubURL       string
+	TopicURL     string
+	CallbackURL  string
+	Secret       string
+	LeaseSeconds int
+}
+
+type Subscriber interface {
+	Subscribe(ctx context.Context, request SubscribeRequest) error
+}
+
+// Check the signature of a content distribution request. The header has the form
+// "method=signature" where method is one of the hash functions allowed by the spec.
+func VerifySignature(secret, body, header string) bool {
+	method, signature, ok := strings.Cut(header, "=")
+	if !ok {
+		return false
+	}
+
+	var h func() hash.Hash
+	switch method {
+	case "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha384":
+		h = sha512.New384
+	case "sha512":
+		h = sha512.New
+	default:
+		return false
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(h, []byte(secret))
+	mac.Write([]byte(body))
+	return hmac.Equal(got, mac.Sum(nil))
+}
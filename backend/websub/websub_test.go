@@ -0,0 +1,32 @@
+package websub_test
+
+import (
+	"testing"
+
+	"github.com/theandrew168/bloggulus/backend/test"
+	"github.com/theandrew168/bloggulus/backend/websub"
+)
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	// Example values taken from the HMAC test vectors (RFC 2202 and RFC 4231, test case 2).
+	secret := "Jefe"
+	body := "what do ya want for nothing?"
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "sha1=effcdf6ae5eb2fa2d27416d5f184df9c259a7c79", want: true},
+		{header: "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", want: true},
+		{header: "sha256=0000000000000000000000000000000000000000000000000000000000000000", want: false},
+		{header: "md5=750c783e6ab0b503eaa86e310a5db738", want: false},
+		{header: "sha256", want: false},
+		{header: "", want: false},
+	}
+	for _, tt := range tests {
+		got := websub.VerifySignature(secret, body, tt.header)
+		test.AssertEqual(t, got, tt.want)
+	}
+}
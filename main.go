@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"embed"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -16,10 +17,15 @@ import (
 	"github.com/theandrew168/bloggulus/backend/config"
 	feedweb "github.com/theandrew168/bloggulus/backend/feed/web"
 	"github.com/theandrew168/bloggulus/backend/job"
+	"github.com/theandrew168/bloggulus/backend/mail/smtp"
+	"github.com/theandrew168/bloggulus/backend/opml"
 	"github.com/theandrew168/bloggulus/backend/postgres"
 	"github.com/theandrew168/bloggulus/backend/query"
+	readabilityweb "github.com/theandrew168/bloggulus/backend/readability/web"
 	"github.com/theandrew168/bloggulus/backend/repository"
 	"github.com/theandrew168/bloggulus/backend/web"
+	webhookweb "github.com/theandrew168/bloggulus/backend/webhook/web"
+	websubweb "github.com/theandrew168/bloggulus/backend/websub/web"
 )
 
 //go:embed public
@@ -48,6 +54,8 @@ func run() error {
 
 	// Check for any specific action flags.
 	migrate := flag.Bool("migrate", false, "apply migrations and exit")
+	importFile := flag.String("import", "", "import feeds from an OPML file and exit (requires -account)")
+	importAccount := flag.String("account", "", "username of the account to import feeds for")
 	flag.Parse()
 
 	// Load the application's config file.
@@ -82,15 +90,38 @@ func run() error {
 
 	// Init the database storage interfaces.
 	repo := repository.New(pool)
-	cmd := command.New(repo, feedFetcher)
 	qry := query.New(pool)
 
 	// Init the sync service and do an initial sync.
 	syncService := job.NewSyncService(repo, feedFetcher)
 
+	// Commands add new blogs through the same sync service.
+	cmd := command.New(repo, feedFetcher, syncService)
+
+	// Exit now if just importing feeds.
+	if *importFile != "" {
+		return importFeeds(repo, cmd, *importFile, *importAccount)
+	}
+
 	// Init the session service and clear any expired session tokens.
 	sessionService := job.NewSessionService(repo)
 
+	// Init the WebSub service and request any new or expiring subscriptions.
+	websubService := job.NewWebSubService(repo, websubweb.NewSubscriber(), conf.SiteURL)
+
+	// Init the webhook service and attempt any pending deliveries.
+	webhookService := job.NewWebhookService(repo, webhookweb.NewWebhookSender())
+
+	// Init the full content service and fetch any pending post content.
+	fullContentService := job.NewFullContentService(repo, readabilityweb.NewPageFetcher())
+
+	// Init the digest service (only if an SMTP server has been configured).
+	var digestService *job.DigestService
+	if conf.SMTPHost != "" {
+		mailer := smtp.NewMailer(conf.SMTPHost, conf.SMTPPort, conf.SMTPUsername, conf.SMTPPassword, conf.SMTPFrom)
+		digestService = job.NewDigestService(repo, qry, mailer, conf.SiteURL)
+	}
+
 	// Let systemd know that we are good to go (no-op if not using systemd).
 	daemon.SdNotify(false, daemon.SdNotifyReady)
 
@@ -149,8 +180,106 @@ func run() error {
 		}
 	}()
 
+	// Start the WebSub subscription service in the background.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := websubService.Run(ctx)
+		if err != nil {
+			slog.Error("error running websub service",
+				"error", err.Error(),
+			)
+		}
+	}()
+
+	// Start the webhook delivery service in the background.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := webhookService.Run(ctx)
+		if err != nil {
+			slog.Error("error running webhook service",
+				"error", err.Error(),
+			)
+		}
+	}()
+
+	// Start the full content service in the background.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := fullContentService.Run(ctx)
+		if err != nil {
+			slog.Error("error running full content service",
+				"error", err.Error(),
+			)
+		}
+	}()
+
+	// Start the digest service in the background.
+	if digestService != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := digestService.Run(ctx)
+			if err != nil {
+				slog.Error("error running digest service",
+					"error", err.Error(),
+				)
+			}
+		}()
+	}
+
 	// Wait for all services to stop.
 	wg.Wait()
 
 	return nil
 }
+
+// Import (add and follow) the feeds from an OPML file for the given account and
+// print the outcome for each one.
+func importFeeds(repo *repository.Repository, cmd *command.Command, path string, username string) error {
+	if username == "" {
+		return errors.New("an account username (-account) is required to import feeds")
+	}
+
+	// Create a context that cancels upon receiving an interrupt signal.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	account, err := repo.Account().ReadByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("account %q: %w", username, err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	feeds, err := opml.Parse(file)
+	if err != nil {
+		return err
+	}
+
+	var feedURLs []string
+	for _, feed := range feeds {
+		feedURLs = append(feedURLs, feed.FeedURL)
+	}
+
+	results, err := cmd.ImportFeeds(ctx, account.ID(), feedURLs)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		fmt.Printf("%s: %s\n", result.FeedURL, result.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+CREATE TABLE api_token (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	account_id UUID NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	hash TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+	-- Token names must be unique per account.
+	CONSTRAINT api_token_account_id_name_key UNIQUE (account_id, name)
+);
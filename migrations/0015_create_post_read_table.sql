@@ -0,0 +1,10 @@
+CREATE TABLE post_read (
+	account_id UUID NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+	post_id UUID NOT NULL REFERENCES post(id) ON DELETE CASCADE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (account_id, post_id)
+);
+
+-- Used when checking if a post has been read (and cascading post deletes).
+CREATE INDEX post_read_post_id_idx ON post_read(post_id);
@@ -0,0 +1,11 @@
+-- Posts that an account has saved (bookmarked) for later.
+CREATE TABLE account_post (
+	account_id UUID NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+	post_id UUID NOT NULL REFERENCES post(id) ON DELETE CASCADE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (account_id, post_id)
+);
+
+-- Used when checking if a post has been saved (and cascading post deletes).
+CREATE INDEX account_post_post_id_idx ON account_post(post_id);
@@ -0,0 +1,11 @@
+CREATE TABLE digest (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	account_id UUID NOT NULL UNIQUE REFERENCES account(id) ON DELETE CASCADE,
+	email TEXT NOT NULL,
+	frequency TEXT NOT NULL,
+	sent_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+	CONSTRAINT digest_frequency_check CHECK (frequency IN ('daily', 'weekly'))
+);
@@ -0,0 +1,11 @@
+CREATE TABLE webhook (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	account_id UUID NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+	-- Webhook URLs must be unique per account.
+	CONSTRAINT webhook_account_id_url_key UNIQUE (account_id, url)
+);
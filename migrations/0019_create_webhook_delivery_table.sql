@@ -0,0 +1,21 @@
+CREATE TABLE webhook_delivery (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	webhook_id UUID NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+	event TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	status TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at TIMESTAMPTZ NOT NULL,
+	last_status_code INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+	CONSTRAINT webhook_delivery_status_check CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+-- Used when listing a webhook's delivery log (and cascading webhook deletes).
+CREATE INDEX webhook_delivery_webhook_id_idx ON webhook_delivery(webhook_id);
+
+-- Used when polling the queue for pending deliveries that are ready to be attempted.
+CREATE INDEX webhook_delivery_pending_idx ON webhook_delivery(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,11 @@
+CREATE TABLE websub_subscription (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	blog_id UUID NOT NULL UNIQUE REFERENCES blog(id) ON DELETE CASCADE,
+	hub_url TEXT NOT NULL,
+	topic_url TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	requested_at TIMESTAMPTZ NOT NULL,
+	lease_expires_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
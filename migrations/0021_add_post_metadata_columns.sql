@@ -0,0 +1,7 @@
+-- Extra (optional) metadata found in feed items.
+ALTER TABLE post
+	ADD COLUMN authors TEXT[] NOT NULL DEFAULT '{}',
+	ADD COLUMN summary TEXT NOT NULL DEFAULT '',
+	ADD COLUMN categories TEXT[] NOT NULL DEFAULT '{}',
+	ADD COLUMN image_url TEXT NOT NULL DEFAULT '',
+	ADD COLUMN enclosures JSONB NOT NULL DEFAULT '[]';
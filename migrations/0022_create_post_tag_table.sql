@@ -0,0 +1,30 @@
+-- Tags provided by a post's feed (like RSS <category> elements). Names are normalized
+-- (lowercase with collapsed whitespace) before being stored.
+CREATE TABLE post_tag (
+	post_id UUID NOT NULL REFERENCES post(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (post_id, name)
+);
+
+-- Used when filtering articles by tag.
+CREATE INDEX post_tag_name_idx ON post_tag(name);
+
+-- Admin-managed aliases that map a (normalized) tag name to its canonical name.
+CREATE TABLE tag_alias (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	alias TEXT NOT NULL UNIQUE,
+	name TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Tag any existing posts based on their stored categories.
+INSERT INTO post_tag
+	(post_id, name)
+SELECT DISTINCT
+	post.id,
+	regexp_replace(lower(btrim(category)), '\s+', ' ', 'g')
+FROM post, unnest(post.categories) AS category
+WHERE btrim(category) != ''
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,17 @@
+-- Per-account rules that hide matching posts (by keyword, tag, title pattern, or blog).
+-- Blog filters store the blog's ID as their value (and reference the blog itself).
+CREATE TABLE content_filter (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	account_id UUID NOT NULL REFERENCES account(id) ON DELETE CASCADE,
+	kind TEXT NOT NULL,
+	value TEXT NOT NULL,
+	blog_id UUID REFERENCES blog(id) ON DELETE CASCADE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+	CONSTRAINT content_filter_kind_check CHECK (kind IN ('keyword', 'tag', 'title', 'blog')),
+	CONSTRAINT content_filter_blog_id_check CHECK ((kind = 'blog') = (blog_id IS NOT NULL)),
+
+	-- Filters must be unique per account.
+	CONSTRAINT content_filter_account_id_kind_value_key UNIQUE (account_id, kind, value)
+);
@@ -0,0 +1,15 @@
+-- Blogs can opt into having the full content of their posts fetched (for feeds
+-- that only include summaries).
+ALTER TABLE blog ADD COLUMN fetch_full_content BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Content extracted from a post's page (fetched_at is NULL until it has been attempted).
+ALTER TABLE post ADD COLUMN full_content TEXT NOT NULL DEFAULT '';
+ALTER TABLE post ADD COLUMN full_content_fetched_at TIMESTAMPTZ;
+
+-- Include the full content when searching posts.
+ALTER TABLE post DROP COLUMN fts_data;
+ALTER TABLE post ADD COLUMN fts_data TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', title || ' ' || content || ' ' || full_content)) STORED;
+CREATE INDEX post_fts_data_idx ON post USING GIN(fts_data);
+
+-- Used when looking for posts that still need their full content fetched.
+CREATE INDEX post_full_content_fetched_at_idx ON post(blog_id) WHERE full_content_fetched_at IS NULL;
@@ -0,0 +1,11 @@
+-- Each blog is synced on its own schedule: the interval (in seconds) grows while
+-- nothing changes and shrinks when new posts show up.
+ALTER TABLE blog
+	ADD COLUMN sync_interval_seconds INTEGER NOT NULL DEFAULT 7200,
+	ADD COLUMN next_sync_at TIMESTAMPTZ;
+
+-- Existing blogs keep their current (fixed) schedule until their next sync.
+UPDATE blog SET next_sync_at = synced_at + INTERVAL '2 hours';
+ALTER TABLE blog ALTER COLUMN next_sync_at SET NOT NULL;
+
+CREATE INDEX blog_next_sync_at_idx ON blog(next_sync_at);
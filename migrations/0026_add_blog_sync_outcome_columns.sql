@@ -0,0 +1,11 @@
+-- The outcome of each blog's most recent sync (used to find and back off broken feeds).
+ALTER TABLE blog
+	ADD COLUMN last_sync_status_code INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN last_sync_error TEXT NOT NULL DEFAULT '' CHECK (last_sync_error IN ('', 'unreachable', 'parse', 'timeout')),
+	ADD COLUMN sync_failures INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN last_sync_success_at TIMESTAMPTZ;
+
+-- Assume that every existing blog was last synced successfully.
+UPDATE blog SET last_sync_success_at = synced_at;
+
+CREATE INDEX blog_sync_failures_idx ON blog(sync_failures) WHERE sync_failures > 0;
@@ -0,0 +1,2 @@
+-- Blogs whose feeds are gone for good (410 Gone) are retired and no longer synced.
+ALTER TABLE blog ADD COLUMN retired_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+-- Each account has (at most) one token for accessing its personal feed.
+CREATE TABLE feed_token (
+	id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	account_id UUID NOT NULL UNIQUE REFERENCES account(id) ON DELETE CASCADE,
+	hash TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
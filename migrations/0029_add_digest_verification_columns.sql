@@ -0,0 +1,5 @@
+-- Digests are only sent to email addresses that have been verified (via an emailed link).
+ALTER TABLE digest
+	ADD COLUMN verification_hash TEXT UNIQUE,
+	ADD COLUMN verification_sent_at TIMESTAMPTZ,
+	ADD COLUMN verified_at TIMESTAMPTZ;